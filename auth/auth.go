@@ -0,0 +1,111 @@
+// Package auth authenticates requests to the server.
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/justinas/alice"
+	"github.com/twitchtv/twirp"
+	"go.uber.org/zap"
+
+	"github.com/bakins/sqliterpc/internal/logging"
+)
+
+// Principal is an authenticated caller.
+type Principal struct {
+	// Name identifies the caller, such as the JWT subject.
+	Name string
+	// Roles are used for authorization.
+	Roles []string
+	// Method is how the caller was authenticated.
+	Method string
+}
+
+// HasRole returns true if the principal has the role.
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Authenticator authenticates a request.
+type Authenticator interface {
+	// Authenticate returns ErrNoCredentials if the request does not have
+	// credentials for this authenticator, so the next one may be tried.
+	// Any other error rejects the request.
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// ErrNoCredentials is returned by an Authenticator when the request
+// does not have credentials it handles.
+var ErrNoCredentials = errors.New("no credentials")
+
+// Middleware authenticates requests using the first authenticator that
+// handles the request's credentials and adds the principal to the context.
+// Requests that are not authenticated are rejected with a Twirp
+// unauthenticated error.
+func Middleware(authenticators ...Authenticator) alice.Constructor {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, err := authenticate(r, authenticators)
+			if err != nil {
+				logging.Info(r.Context(), "authentication failed", zap.Error(err))
+
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				_ = twirp.WriteError(w, twirp.NewError(twirp.Unauthenticated, "unauthenticated"))
+				return
+			}
+
+			ctx := ToContext(r.Context(), principal)
+			ctx = logging.WithFields(ctx, zap.String("principal", principal.Name))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func authenticate(r *http.Request, authenticators []Authenticator) (*Principal, error) {
+	for _, a := range authenticators {
+		principal, err := a.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+
+		return principal, err
+	}
+
+	return nil, ErrNoCredentials
+}
+
+type principalKeyType struct{}
+
+var principalKey = principalKeyType{}
+
+func ToContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+// FromContext returns the principal for the request, or nil if
+// the request was not authenticated.
+func FromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey).(*Principal)
+	return principal
+}
+
+// bearerToken returns the token from the Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "Bearer "
+
+	header := r.Header.Get("Authorization")
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	return header[len(prefix):], true
+}
@@ -0,0 +1,239 @@
+package auth_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"hash"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bakins/sqliterpc/auth"
+)
+
+func signJWT(t *testing.T, alg string, newHash func() hash.Hash, secret []byte, claims map[string]interface{}) string {
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	mac := hmac.New(newHash, secret)
+	mac.Write([]byte(signed))
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// serve returns the status code and the principal seen by the handler.
+func serve(t *testing.T, authenticators []auth.Authenticator, r *http.Request) (int, *auth.Principal) {
+	var principal *auth.Principal
+
+	handler := auth.Middleware(authenticators...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal = auth.FromContext(r.Context())
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	return w.Code, principal
+}
+
+func bearerRequest(token string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/twirp/sqlite.rpc.v0.DatabaseService/Exec", nil)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return r
+}
+
+func TestTokens(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "tokens")
+
+	err := os.WriteFile(filename, []byte("# comment\n\nsecret-one alice admin,readonly\nsecret-two bob\n"), 0o600)
+	require.NoError(t, err)
+
+	tokens, err := auth.LoadTokens(filename)
+	require.NoError(t, err)
+
+	authenticators := []auth.Authenticator{tokens}
+
+	code, principal := serve(t, authenticators, bearerRequest("secret-one"))
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "alice", principal.Name)
+	require.Equal(t, []string{"admin", "readonly"}, principal.Roles)
+	require.True(t, principal.HasRole("readonly"))
+	require.Equal(t, "token", principal.Method)
+
+	code, principal = serve(t, authenticators, bearerRequest("secret-two"))
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "bob", principal.Name)
+	require.Empty(t, principal.Roles)
+
+	code, _ = serve(t, authenticators, bearerRequest("secret-three"))
+	require.Equal(t, http.StatusUnauthorized, code)
+
+	code, _ = serve(t, authenticators, bearerRequest(""))
+	require.Equal(t, http.StatusUnauthorized, code)
+
+	err = os.WriteFile(filename, []byte("secret-one\n"), 0o600)
+	require.NoError(t, err)
+
+	_, err = auth.LoadTokens(filename)
+	require.Error(t, err)
+}
+
+func TestJWT(t *testing.T) {
+	secret := []byte("testing-secret")
+
+	jwt := auth.NewJWT(secret, auth.WithIssuer("tests"), auth.WithAudience("sqliterpc"))
+
+	// tokens are tried first, so JWTs must fall through
+	authenticators := []auth.Authenticator{
+		auth.NewTokens(map[string]auth.Principal{"static": {Name: "static"}}),
+		jwt,
+	}
+
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"sub":   "alice",
+			"iss":   "tests",
+			"aud":   []string{"other", "sqliterpc"},
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"roles": []string{"readonly"},
+		}
+	}
+
+	algorithms := []struct {
+		name    string
+		newHash func() hash.Hash
+	}{
+		{name: "HS256", newHash: sha256.New},
+		{name: "HS384", newHash: sha512.New384},
+		{name: "HS512", newHash: sha512.New},
+	}
+
+	for _, alg := range algorithms {
+		t.Run(alg.name, func(t *testing.T) {
+			code, principal := serve(t, authenticators, bearerRequest(signJWT(t, alg.name, alg.newHash, secret, valid())))
+			require.Equal(t, http.StatusOK, code)
+			require.Equal(t, "alice", principal.Name)
+			require.Equal(t, []string{"readonly"}, principal.Roles)
+			require.Equal(t, "jwt", principal.Method)
+		})
+	}
+
+	tests := []struct {
+		name   string
+		alg    string
+		secret []byte
+		modify func(map[string]interface{})
+	}{
+		{
+			name:   "wrong secret",
+			alg:    "HS256",
+			secret: []byte("wrong"),
+		},
+		{
+			name:   "none algorithm",
+			alg:    "none",
+			secret: secret,
+		},
+		{
+			name:   "expired",
+			alg:    "HS256",
+			secret: secret,
+			modify: func(claims map[string]interface{}) {
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			},
+		},
+		{
+			name:   "not yet valid",
+			alg:    "HS256",
+			secret: secret,
+			modify: func(claims map[string]interface{}) {
+				claims["nbf"] = time.Now().Add(time.Hour).Unix()
+			},
+		},
+		{
+			name:   "issuer",
+			alg:    "HS256",
+			secret: secret,
+			modify: func(claims map[string]interface{}) {
+				claims["iss"] = "someone-else"
+			},
+		},
+		{
+			name:   "audience",
+			alg:    "HS256",
+			secret: secret,
+			modify: func(claims map[string]interface{}) {
+				claims["aud"] = "other"
+			},
+		},
+		{
+			name:   "subject",
+			alg:    "HS256",
+			secret: secret,
+			modify: func(claims map[string]interface{}) {
+				delete(claims, "sub")
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			claims := valid()
+			if test.modify != nil {
+				test.modify(claims)
+			}
+
+			code, _ := serve(t, authenticators, bearerRequest(signJWT(t, test.alg, sha256.New, test.secret, claims)))
+			require.Equal(t, http.StatusUnauthorized, code)
+		})
+	}
+}
+
+func TestClientCertificate(t *testing.T) {
+	authenticators := []auth.Authenticator{auth.ClientCertificate{}}
+
+	r := bearerRequest("")
+	r.TLS = &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{
+			{
+				{
+					Subject: pkix.Name{
+						CommonName:         "reporting",
+						OrganizationalUnit: []string{"analytics"},
+					},
+				},
+			},
+		},
+	}
+
+	code, principal := serve(t, authenticators, r)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "reporting", principal.Name)
+	require.Equal(t, []string{"analytics"}, principal.Roles)
+	require.Equal(t, "tls", principal.Method)
+
+	// not verified
+	r = bearerRequest("")
+	r.TLS = &tls.ConnectionState{}
+
+	code, _ = serve(t, authenticators, r)
+	require.Equal(t, http.StatusUnauthorized, code)
+}
@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"net/http"
+)
+
+// ClientCertificate authenticates requests using verified TLS client
+// certificates. The subject common name is the principal name, and the
+// organizational units are the roles. The server must be configured to
+// verify client certificates, such as with tls.VerifyClientCertIfGiven.
+type ClientCertificate struct{}
+
+var _ Authenticator = ClientCertificate{}
+
+// Authenticate returns ErrNoCredentials if the request does not
+// have a verified client certificate.
+func (ClientCertificate) Authenticate(r *http.Request) (*Principal, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	cert := r.TLS.VerifiedChains[0][0]
+
+	if cert.Subject.CommonName == "" {
+		return nil, ErrNoCredentials
+	}
+
+	principal := Principal{
+		Name:   cert.Subject.CommonName,
+		Roles:  cert.Subject.OrganizationalUnit,
+		Method: "tls",
+	}
+
+	return &principal, nil
+}
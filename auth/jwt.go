@@ -0,0 +1,218 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// JWT authenticates requests with HMAC signed JSON Web Tokens
+// sent as bearer tokens. HS256, HS384, and HS512 are supported.
+// The subject is the principal name, and the "roles" claim, if present,
+// is a list of roles.
+// see https://www.rfc-editor.org/rfc/rfc7519
+type JWT struct {
+	secret []byte
+	cfg    jwtConfig
+}
+
+var _ Authenticator = &JWT{}
+
+type JWTOption interface {
+	apply(*jwtConfig)
+}
+
+type jwtConfig struct {
+	issuer   string
+	audience string
+	leeway   time.Duration
+}
+
+type jwtOptionFunc func(*jwtConfig)
+
+func (f jwtOptionFunc) apply(c *jwtConfig) {
+	f(c)
+}
+
+// WithIssuer requires the "iss" claim to be issuer.
+func WithIssuer(issuer string) JWTOption {
+	return jwtOptionFunc(func(c *jwtConfig) {
+		c.issuer = issuer
+	})
+}
+
+// WithAudience requires the "aud" claim to contain audience.
+func WithAudience(audience string) JWTOption {
+	return jwtOptionFunc(func(c *jwtConfig) {
+		c.audience = audience
+	})
+}
+
+// WithLeeway allows for clock skew when checking the "exp" and "nbf" claims.
+func WithLeeway(leeway time.Duration) JWTOption {
+	return jwtOptionFunc(func(c *jwtConfig) {
+		c.leeway = leeway
+	})
+}
+
+func NewJWT(secret []byte, options ...JWTOption) *JWT {
+	cfg := jwtConfig{
+		leeway: time.Minute,
+	}
+
+	for _, o := range options {
+		o.apply(&cfg)
+	}
+
+	j := JWT{
+		secret: secret,
+		cfg:    cfg,
+	}
+
+	return &j
+}
+
+var jwtAlgorithms = map[string]func() hash.Hash{
+	"HS256": sha256.New,
+	"HS384": sha512.New384,
+	"HS512": sha512.New,
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+}
+
+type jwtClaims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *float64 `json:"exp"`
+	NotBefore *float64 `json:"nbf"`
+	Roles     []string `json:"roles"`
+}
+
+// audience may be a single string or a list.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*a = audience{s}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+
+	*a = list
+
+	return nil
+}
+
+// Authenticate returns ErrNoCredentials if the bearer token is not a JWT.
+func (j *JWT) Authenticate(r *http.Request) (*Principal, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrNoCredentials
+	}
+
+	claims, err := j.verify(parts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT: %w", err)
+	}
+
+	principal := Principal{
+		Name:   claims.Subject,
+		Roles:  claims.Roles,
+		Method: "jwt",
+	}
+
+	return &principal, nil
+}
+
+func (j *JWT) verify(parts []string) (*jwtClaims, error) {
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+
+	newHash, ok := jwtAlgorithms[header.Algorithm]
+	if !ok {
+		return nil, fmt.Errorf("unsupported algorithm %q", header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+
+	mac := hmac.New(newHash, j.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errors.New("signature mismatch")
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("claims: %w", err)
+	}
+
+	now := time.Now()
+
+	if claims.ExpiresAt != nil && now.After(time.Unix(int64(*claims.ExpiresAt), 0).Add(j.cfg.leeway)) {
+		return nil, errors.New("expired")
+	}
+
+	if claims.NotBefore != nil && now.Before(time.Unix(int64(*claims.NotBefore), 0).Add(-j.cfg.leeway)) {
+		return nil, errors.New("not yet valid")
+	}
+
+	if j.cfg.issuer != "" && claims.Issuer != j.cfg.issuer {
+		return nil, errors.New("unexpected issuer")
+	}
+
+	if j.cfg.audience != "" && !contains(claims.Audience, j.cfg.audience) {
+		return nil, errors.New("unexpected audience")
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("missing subject")
+	}
+
+	return &claims, nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Tokens authenticates requests with static bearer tokens.
+type Tokens struct {
+	// keyed by the hash of the token, so lookups do not leak the token through timing
+	principals map[[sha256.Size]byte]*Principal
+}
+
+var _ Authenticator = &Tokens{}
+
+// NewTokens creates an authenticator for the tokens. The key is the token.
+func NewTokens(tokens map[string]Principal) *Tokens {
+	t := Tokens{
+		principals: make(map[[sha256.Size]byte]*Principal, len(tokens)),
+	}
+
+	for token, principal := range tokens {
+		p := principal
+		if p.Method == "" {
+			p.Method = "token"
+		}
+
+		t.principals[sha256.Sum256([]byte(token))] = &p
+	}
+
+	return &t
+}
+
+// LoadTokens reads tokens from a file. Each line is a token, the principal name,
+// and optionally a comma separated list of roles, separated by whitespace.
+// Blank lines and lines starting with # are ignored.
+//
+//	# token name roles
+//	4f6d2e0c9b alice admin
+//	8a1b7c3d5e reporting readonly,analytics
+func LoadTokens(filename string) (*Tokens, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	tokens := make(map[string]Principal)
+
+	scanner := bufio.NewScanner(f)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("%s:%d: expected token, name, and optional roles", filename, line)
+		}
+
+		if _, ok := tokens[fields[0]]; ok {
+			return nil, fmt.Errorf("%s:%d: duplicate token", filename, line)
+		}
+
+		principal := Principal{
+			Name: fields[1],
+		}
+
+		if len(fields) == 3 {
+			principal.Roles = strings.Split(fields[2], ",")
+		}
+
+		tokens[fields[0]] = principal
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewTokens(tokens), nil
+}
+
+// Authenticate returns ErrNoCredentials for unknown tokens, so other
+// bearer token authenticators may be tried.
+func (t *Tokens) Authenticate(r *http.Request) (*Principal, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	principal, ok := t.principals[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	return principal, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/server"
+)
+
+type clientConfig struct {
+	URL   string `kong:"default='http://127.0.0.1:8080',help='Server URL. Include /db/{name} for a server using --database-dir.'"`
+	Token string `kong:"env=SQLITERPC_TOKEN,help='Bearer token to authenticate with.'"`
+}
+
+func (c clientConfig) client(ctx context.Context) (sqliterpc.AdminService, context.Context, error) {
+	ctx, err := c.withToken(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sqliterpc.NewAdminServiceProtobufClient(c.URL, http.DefaultClient), ctx, nil
+}
+
+func (c clientConfig) databaseClient(ctx context.Context) (sqliterpc.DatabaseService, context.Context, error) {
+	ctx, err := c.withToken(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sqliterpc.NewDatabaseServiceProtobufClient(c.URL, http.DefaultClient), ctx, nil
+}
+
+// withToken returns a context that authenticates requests with the token.
+func (c clientConfig) withToken(ctx context.Context) (context.Context, error) {
+	if c.Token == "" {
+		return ctx, nil
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+c.Token)
+
+	return twirp.WithHTTPRequestHeaders(ctx, header)
+}
+
+type backupCmd struct {
+	clientConfig `kong:"embed"`
+	File         string `kong:"arg,help='File to write the backup to.'"`
+}
+
+func backup(ctx context.Context, cfg backupCmd) error {
+	client := http.DefaultClient
+	if cfg.Token != "" {
+		client = &http.Client{Transport: bearerTransport{token: cfg.Token}}
+	}
+
+	// write to a temporary file so an existing backup is not left partially written.
+	tmp := cfg.File + ".tmp"
+
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	err = server.FetchBackup(ctx, client, cfg.URL, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	return os.Rename(tmp, cfg.File)
+}
+
+type restoreCmd struct {
+	clientConfig `kong:"embed"`
+	File         string `kong:"arg,type=existingfile,help='Backup file to restore.'"`
+}
+
+func restore(ctx context.Context, cfg restoreCmd) error {
+	data, err := os.ReadFile(cfg.File)
+	if err != nil {
+		return err
+	}
+
+	client, ctx, err := cfg.client(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Restore(ctx, &sqliterpc.RestoreRequest{Database: data})
+	return err
+}
+
+// bearerTransport authenticates requests made by a replica to its primary.
+type bearerTransport struct {
+	token string
+}
+
+func (t bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -1,11 +1,18 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
 	"log"
 	"net/http"
+	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/NYTimes/gziphandler"
 	"github.com/alecthomas/kong"
@@ -14,27 +21,85 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.uber.org/zap"
 
-	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/auth"
 	"github.com/bakins/sqliterpc/internal/logging"
+	"github.com/bakins/sqliterpc/ratelimit"
 	"github.com/bakins/sqliterpc/server"
 	"github.com/bakins/twirpotel"
 )
 
+type cli struct {
+	Serve   config     `kong:"cmd,default=withargs,help='Serve databases. This is the default command.'"`
+	Backup  backupCmd  `kong:"cmd,help='Write a backup of a database on a running server to a file.'"`
+	Restore restoreCmd `kong:"cmd,help='Replace a database on a running server with a backup.'"`
+	Migrate migrateCmd `kong:"cmd,help='Apply or revert schema migrations on a running server.'"`
+}
+
 func main() {
-	var cli config
+	var c cli
 
-	kong.Parse(&cli)
+	kctx := kong.Parse(&c)
 
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGQUIT)
 	defer cancel()
 
-	if err := run(ctx, cli); err != nil {
+	var err error
+
+	switch kctx.Command() {
+	case "backup <file>":
+		err = backup(ctx, c.Backup)
+	case "restore <file>":
+		err = restore(ctx, c.Restore)
+	case "migrate up":
+		err = migrateUp(ctx, c.Migrate)
+	case "migrate down":
+		err = migrateDown(ctx, c.Migrate)
+	case "migrate status":
+		err = migrateStatus(ctx, c.Migrate)
+	default:
+		err = run(ctx, c.Serve)
+	}
+
+	if err != nil {
 		log.Fatal(err)
 	}
 }
 
 type config struct {
-	Database string `kong:"default=sqliterpc.db"`
+	Database        string `kong:"default=sqliterpc.db"`
+	DatabaseDir     string `kong:"help='Serve all databases in this directory under /db/{name}/. Overrides --database.'"`
+	MaxDatabases    int    `kong:"default=64,help='Maximum number of open databases when using --database-dir.'"`
+	CreateDatabases bool   `kong:"help='Create databases on first use when using --database-dir.'"`
+	Listen          string `kong:"default=127.0.0.1:8080,help='Address to listen on.'"`
+	TLSCert         string `kong:"name=tls-cert,type=existingfile,help='TLS certificate file. Enables TLS.'"`
+	TLSKey          string `kong:"name=tls-key,type=existingfile,help='TLS key file.'"`
+	TLSClientCA     string `kong:"name=tls-client-ca,type=existingfile,help='CA file for verifying client certificates. Enables client certificate authentication.'"`
+	TokenFile       string `kong:"type=existingfile,help='File of bearer tokens. Enables token authentication.'"`
+	JWTSecretFile   string `kong:"name=jwt-secret-file,type=existingfile,help='File containing the HMAC secret for JWTs. Enables JWT authentication.'"`
+	JWTIssuer       string `kong:"name=jwt-issuer,help='Required JWT issuer.'"`
+	JWTAudience     string `kong:"name=jwt-audience,help='Required JWT audience.'"`
+	PolicyFile      string `kong:"type=existingfile,help='JSON file of per principal and role policies. Once set, backup and restore require an admin policy.'"`
+	RateLimitFile   string `kong:"type=existingfile,help='JSON file of per method rate and concurrency limits for each principal or client address.'"`
+	Primary         string `kong:"help='URL of a primary server. Serves --database as a read replica of it.'"`
+	PrimaryToken    string `kong:"env=SQLITERPC_PRIMARY_TOKEN,help='Bearer token to authenticate with the primary.'"`
+
+	Mode            string        `kong:"default=rwc,help='How databases are opened: ro, rw, rwc, or memory.'"`
+	BusyTimeout     time.Duration `kong:"default=5s,help='How long to wait for a lock held by another connection.'"`
+	Synchronous     string        `kong:"help='Synchronous mode: off, normal, full, or extra. Defaults to the sqlite default.'"`
+	ForeignKeys     bool          `kong:"help='Enforce foreign key constraints.'"`
+	TxLock          string        `kong:"name=txlock,default=deferred,help='Default transaction locking: deferred, immediate, or exclusive.'"`
+	CacheSize       int           `kong:"help='Page cache size per connection. Positive is pages, negative is KiB. Zero uses the sqlite default.'"`
+	MmapSize        int64         `kong:"help='Maximum bytes of memory mapped I/O. Zero disables it.'"`
+	TempStore       string        `kong:"help='Where temporary tables are kept: default, file, or memory.'"`
+	LockingMode     string        `kong:"help='Locking mode: normal or exclusive.'"`
+	MaxReadConns    int           `kong:"default=16,help='Maximum connections per database for queries that only read.'"`
+	MaxWriteConns   int           `kong:"default=1,help='Maximum connections per database for writes.'"`
+	ConnMaxLifetime time.Duration `kong:"help='How long a connection may be reused. Zero reuses connections forever.'"`
+
+	StatementTimeout    time.Duration `kong:"help='How long a statement may run when the request does not set a timeout. Zero is no limit.'"`
+	MaxStatementTimeout time.Duration `kong:"help='Longest timeout a request may set. Zero is no limit.'"`
+	MaxRows             int64         `kong:"help='Maximum rows in a query response. Zero is no limit.'"`
+	MaxResponseBytes    int64         `kong:"help='Maximum size in bytes of a query response. Zero is no limit.'"`
 }
 
 func run(ctx context.Context, cfg config) error {
@@ -45,28 +110,199 @@ func run(ctx context.Context, cfg config) error {
 
 	defer logger.Sync()
 
-	db, err := server.New(cfg.Database)
+	handler, closer, err := newHandler(cfg)
 	if err != nil {
 		return err
 	}
 
-	defer db.Close()
+	defer closer.Close()
+
+	authenticators, err := newAuthenticators(cfg)
+	if err != nil {
+		return err
+	}
 
 	chain := alice.New(
 		logging.Middleware(logger),
+	)
+
+	if len(authenticators) > 0 {
+		chain = chain.Append(auth.Middleware(authenticators...))
+	}
+
+	if cfg.RateLimitFile != "" {
+		limits, err := ratelimit.Load(cfg.RateLimitFile)
+		if err != nil {
+			return err
+		}
+
+		limiter, err := ratelimit.New(limits)
+		if err != nil {
+			return err
+		}
+
+		// after authentication, so requests are limited by principal.
+		chain = chain.Append(limiter.Middleware)
+	}
+
+	chain = chain.Append(
 		func(next http.Handler) http.Handler {
 			return otelhttp.NewHandler(next, "sqliterpc")
 		},
 		gziphandler.GzipHandler,
 	)
 
-	ts := sqliterpc.NewDatabaseServiceServer(
-		db,
-		twirp.WithServerInterceptors(twirpotel.ServerInterceptor()),
-	)
+	svr := http.Server{
+		Addr:    cfg.Listen,
+		Handler: chain.Then(handler),
+	}
+
+	if cfg.TLSCert == "" {
+		if cfg.TLSClientCA != "" {
+			return errors.New("--tls-client-ca requires --tls-cert")
+		}
+
+		return svr.ListenAndServe()
+	}
+
+	if cfg.TLSClientCA != "" {
+		pem, err := os.ReadFile(cfg.TLSClientCA)
+		if err != nil {
+			return err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.New("no certificates found in " + cfg.TLSClientCA)
+		}
+
+		svr.TLSConfig = &tls.Config{
+			ClientCAs:  pool,
+			ClientAuth: tls.VerifyClientCertIfGiven,
+		}
+	}
+
+	return svr.ListenAndServeTLS(cfg.TLSCert, cfg.TLSKey)
+}
+
+func newAuthenticators(cfg config) ([]auth.Authenticator, error) {
+	var authenticators []auth.Authenticator
+
+	if cfg.TLSClientCA != "" {
+		authenticators = append(authenticators, auth.ClientCertificate{})
+	}
+
+	if cfg.TokenFile != "" {
+		tokens, err := auth.LoadTokens(cfg.TokenFile)
+		if err != nil {
+			return nil, err
+		}
 
-	mux := http.NewServeMux()
-	mux.Handle(ts.PathPrefix(), ts)
+		authenticators = append(authenticators, tokens)
+	}
+
+	if cfg.JWTSecretFile != "" {
+		secret, err := os.ReadFile(cfg.JWTSecretFile)
+		if err != nil {
+			return nil, err
+		}
+
+		secret = bytes.TrimSpace(secret)
+		if len(secret) == 0 {
+			return nil, errors.New(cfg.JWTSecretFile + " is empty")
+		}
+
+		var options []auth.JWTOption
+		if cfg.JWTIssuer != "" {
+			options = append(options, auth.WithIssuer(cfg.JWTIssuer))
+		}
+		if cfg.JWTAudience != "" {
+			options = append(options, auth.WithAudience(cfg.JWTAudience))
+		}
+
+		authenticators = append(authenticators, auth.NewJWT(secret, options...))
+	}
+
+	return authenticators, nil
+}
+
+func newHandler(cfg config) (http.Handler, io.Closer, error) {
+	interceptors := twirp.WithServerInterceptors(twirpotel.ServerInterceptor())
+
+	options := serverOptions(cfg)
+
+	if cfg.PolicyFile != "" {
+		policies, err := server.LoadPolicies(cfg.PolicyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		options = append(options, server.WithPolicies(policies))
+	}
+
+	if cfg.DatabaseDir != "" {
+		m, err := server.NewManager(
+			cfg.DatabaseDir,
+			server.WithMaxOpenDatabases(cfg.MaxDatabases),
+			server.WithCreateDatabases(cfg.CreateDatabases),
+			server.WithServerOptions(options...),
+			server.WithTwirpOptions(interceptors),
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return m, m, nil
+	}
+
+	db, err := server.New(cfg.Database, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return server.NewHandler(db, interceptors), db, nil
+}
+
+func serverOptions(cfg config) []server.Option {
+	options := []server.Option{
+		server.WithOpenMode(server.OpenMode(cfg.Mode)),
+		server.WithBusyTimeout(cfg.BusyTimeout),
+		server.WithForeignKeys(cfg.ForeignKeys),
+		server.WithTxLock(server.TxLock(cfg.TxLock)),
+		server.WithMmapSize(cfg.MmapSize),
+		server.WithMaxReadConns(cfg.MaxReadConns),
+		server.WithMaxWriteConns(cfg.MaxWriteConns),
+		server.WithConnMaxLifetime(cfg.ConnMaxLifetime),
+		server.WithStatementTimeout(cfg.StatementTimeout),
+		server.WithMaxStatementTimeout(cfg.MaxStatementTimeout),
+		server.WithMaxRows(cfg.MaxRows),
+		server.WithMaxResponseBytes(cfg.MaxResponseBytes),
+	}
+
+	if cfg.Synchronous != "" {
+		options = append(options, server.WithSynchronous(server.SynchronousMode(cfg.Synchronous)))
+	}
+
+	if cfg.CacheSize != 0 {
+		options = append(options, server.WithCacheSize(cfg.CacheSize))
+	}
+
+	if cfg.TempStore != "" {
+		options = append(options, server.WithTempStore(server.TempStore(cfg.TempStore)))
+	}
+
+	if cfg.LockingMode != "" {
+		options = append(options, server.WithLockingMode(server.LockingMode(cfg.LockingMode)))
+	}
+
+	if cfg.Primary != "" {
+		client := http.DefaultClient
+		if cfg.PrimaryToken != "" {
+			client = &http.Client{Transport: bearerTransport{token: cfg.PrimaryToken}}
+		}
+
+		options = append(options, server.WithPrimary(cfg.Primary, client))
+	}
 
-	return http.ListenAndServe("127.0.0.1:8080", chain.Then(mux))
+	return options
 }
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bakins/sqliterpc/migrate"
+)
+
+type migrateCmd struct {
+	clientConfig `kong:"embed"`
+	Dir          string `kong:"default=migrations,type=path,help='Directory of migration files named {version}_{name}.up.sql and {version}_{name}.down.sql.'"`
+
+	Up     struct{}       `kong:"cmd,help='Apply migrations that have not been applied.'"`
+	Down   migrateDownCmd `kong:"cmd,help='Revert the most recently applied migrations.'"`
+	Status struct{}       `kong:"cmd,help='List migrations and whether they have been applied.'"`
+}
+
+type migrateDownCmd struct {
+	Steps int `kong:"default=1,help='Number of migrations to revert.'"`
+}
+
+func (c migrateCmd) runner(ctx context.Context) (*migrate.Runner, context.Context, error) {
+	migrations, err := migrate.LoadDir(c.Dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, ctx, err := c.databaseClient(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return migrate.New(client, migrations), ctx, nil
+}
+
+func migrateUp(ctx context.Context, cfg migrateCmd) error {
+	runner, ctx, err := cfg.runner(ctx)
+	if err != nil {
+		return err
+	}
+
+	done, err := runner.Up(ctx)
+	for _, m := range done {
+		fmt.Printf("applied %d_%s\n", m.Version, m.Name)
+	}
+
+	return err
+}
+
+func migrateDown(ctx context.Context, cfg migrateCmd) error {
+	runner, ctx, err := cfg.runner(ctx)
+	if err != nil {
+		return err
+	}
+
+	done, err := runner.Down(ctx, cfg.Down.Steps)
+	for _, m := range done {
+		fmt.Printf("reverted %d_%s\n", m.Version, m.Name)
+	}
+
+	return err
+}
+
+func migrateStatus(ctx context.Context, cfg migrateCmd) error {
+	runner, ctx, err := cfg.runner(ctx)
+	if err != nil {
+		return err
+	}
+
+	status, err := runner.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED\t")
+
+	for _, s := range status {
+		applied := "pending"
+		if s.Applied {
+			applied = s.AppliedAt.Format(time.RFC3339)
+		}
+
+		var note string
+		switch {
+		case s.Missing:
+			note = "missing"
+		case s.Modified:
+			note = "modified"
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Name, applied, note)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,50 @@
+package driver
+
+import (
+	"context"
+	"net/http"
+)
+
+// TokenSource returns the bearer token to send with a request.
+// It is called for every request, so should cache tokens as needed.
+type TokenSource func(ctx context.Context) (string, error)
+
+// WithToken sends token as a bearer token with every request.
+func WithToken(token string) Option {
+	return WithTokenSource(staticToken(token))
+}
+
+// WithTokenSource sends a bearer token from source with every request.
+func WithTokenSource(source TokenSource) Option {
+	return optionFunc(func(d *Driver) {
+		d.tokenSource = source
+	})
+}
+
+func staticToken(token string) TokenSource {
+	return func(context.Context) (string, error) {
+		return token, nil
+	}
+}
+
+// bearerTransport adds the Authorization header to requests.
+type bearerTransport struct {
+	next   http.RoundTripper
+	source TokenSource
+}
+
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source(req.Context())
+	if err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+
+	// a RoundTripper must not modify the request
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,115 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+
+	"github.com/bakins/sqliterpc"
+)
+
+var ErrNotConnection = errors.New("not a sqliterpc connection")
+
+// Batch runs all steps of req in a single round trip and a single transaction.
+// conn must be from a database opened with this driver.
+func Batch(ctx context.Context, conn *sql.Conn, req *sqliterpc.BatchRequest) (*sqliterpc.BatchResponse, error) {
+	var resp *sqliterpc.BatchResponse
+
+	err := conn.Raw(func(driverConn interface{}) error {
+		c, ok := driverConn.(*connection)
+		if !ok {
+			return ErrNotConnection
+		}
+
+		if c.client == nil {
+			return ErrConnectionClosed
+		}
+
+		if c.transactionID != "" {
+			return ErrTransactionInProgress
+		}
+
+		var err error
+		resp, err = c.client.Batch(ctx, req)
+		if err == nil {
+			c.cluster.wrote()
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ExecStep creates a batch step that executes query.
+func ExecStep(query string, args ...interface{}) (*sqliterpc.BatchStep, error) {
+	values, err := argsToParameters(args)
+	if err != nil {
+		return nil, err
+	}
+
+	step := sqliterpc.BatchStep{
+		Step: &sqliterpc.BatchStep_Exec{
+			Exec: &sqliterpc.ExecRequest{
+				Sql:        query,
+				Parameters: values,
+			},
+		},
+	}
+
+	return &step, nil
+}
+
+// QueryStep creates a batch step that runs query and returns the rows.
+func QueryStep(query string, args ...interface{}) (*sqliterpc.BatchStep, error) {
+	values, err := argsToParameters(args)
+	if err != nil {
+		return nil, err
+	}
+
+	step := sqliterpc.BatchStep{
+		Step: &sqliterpc.BatchStep_Query{
+			Query: &sqliterpc.QueryRequest{
+				Sql:        query,
+				Parameters: values,
+			},
+		},
+	}
+
+	return &step, nil
+}
+
+// Parameters converts args to parameters for requests, such as those for ExecScript.
+// Use sql.Named for named parameters.
+func Parameters(args ...interface{}) ([]*sqliterpc.Value, error) {
+	return argsToParameters(args)
+}
+
+// argsToParameters converts args the same way database/sql does.
+func argsToParameters(args []interface{}) ([]*sqliterpc.Value, error) {
+	named := make([]driver.NamedValue, len(args))
+
+	for i, arg := range args {
+		var name string
+		if n, ok := arg.(sql.NamedArg); ok {
+			name, arg = n.Name, n.Value
+		}
+
+		v, err := driver.DefaultParameterConverter.ConvertValue(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		named[i] = driver.NamedValue{
+			Name:    name,
+			Ordinal: i + 1,
+			Value:   v,
+		}
+	}
+
+	return namedValueToParameters(named)
+}
@@ -0,0 +1,286 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+)
+
+const (
+	minEndpointBackoff = time.Second
+	maxEndpointBackoff = time.Minute
+)
+
+// WithReadYourWrites sends queries to the primary for duration after each
+// write, so they see the write even if replicas have not applied it yet.
+// It only applies when there are replicas.
+func WithReadYourWrites(duration time.Duration) Option {
+	return optionFunc(func(d *Driver) {
+		d.readYourWrites = duration
+	})
+}
+
+// endpoint is a server. Endpoints that fail are not used for queries
+// until a backoff has passed.
+type endpoint struct {
+	url        string
+	client     sqliterpc.DatabaseService
+	httpClient *http.Client
+	retries    int
+	codec      Codec
+
+	lock     sync.Mutex
+	failures int
+	retryAt  time.Time
+}
+
+func newEndpoint(baseURL string, httpClient *http.Client, d *Driver) *endpoint {
+	interceptors := []twirp.Interceptor{errorInterceptor}
+
+	if d.timeout > 0 {
+		interceptors = append(interceptors, timeoutInterceptor(d.timeout))
+	}
+
+	if d.retries > 0 {
+		interceptors = append(interceptors, retryInterceptor(d.retries))
+	}
+
+	options := twirp.WithClientInterceptors(interceptors...)
+
+	e := endpoint{
+		url:        baseURL,
+		httpClient: httpClient,
+		retries:    d.retries,
+		codec:      d.codec,
+	}
+
+	if d.codec == CodecJSON {
+		e.client = sqliterpc.NewDatabaseServiceJSONClient(baseURL, httpClient, options)
+	} else {
+		e.client = sqliterpc.NewDatabaseServiceProtobufClient(baseURL, httpClient, options)
+	}
+
+	return &e
+}
+
+func (e *endpoint) healthy(now time.Time) bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return !now.Before(e.retryAt)
+}
+
+// call calls fn and records whether the endpoint is available.
+func (e *endpoint) call(ctx context.Context, fn func(*endpoint) error) error {
+	err := fn(e)
+
+	switch {
+	case ctx.Err() != nil:
+		// the caller gave up, which says nothing about the endpoint.
+	case unavailable(err):
+		e.failed()
+	default:
+		e.succeeded()
+	}
+
+	return err
+}
+
+func (e *endpoint) failed() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	backoff := minEndpointBackoff << e.failures
+	if backoff > maxEndpointBackoff || backoff <= 0 {
+		backoff = maxEndpointBackoff
+	} else {
+		e.failures++
+	}
+
+	e.retryAt = time.Now().Add(backoff)
+}
+
+func (e *endpoint) succeeded() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.failures = 0
+	e.retryAt = time.Time{}
+}
+
+// unavailable returns true if err means the request did not reach a server
+// that could handle it. A server returns an unavailable error with a sqlite
+// code when the database is busy, which says nothing about the endpoint.
+func unavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var twerr twirp.Error
+	if errors.As(err, &twerr) {
+		return twerr.Code() == twirp.Unavailable && twerr.Meta(sqliterpc.ErrorMetaCode) == ""
+	}
+
+	return false
+}
+
+// busy returns true if err is a server's database being busy.
+func busy(err error) bool {
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) {
+		return false
+	}
+
+	return twerr.Code() == twirp.Unavailable && twerr.Meta(sqliterpc.ErrorMetaCode) != ""
+}
+
+// isReplicaWrite returns true if err is a replica rejecting a write.
+func isReplicaWrite(err error) bool {
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) {
+		return false
+	}
+
+	return twerr.Code() == twirp.FailedPrecondition && twerr.Meta(sqliterpc.ErrorMetaPrimary) != ""
+}
+
+// cluster is a primary and its read replicas. It is shared by all
+// connections from a connector.
+type cluster struct {
+	primary  *endpoint
+	replicas []*endpoint
+	// next is used to choose replicas in turn.
+	next           uint32
+	readYourWrites time.Duration
+	// lastWrite is the time of the last write in unix nanoseconds.
+	lastWrite int64
+}
+
+// parseEndpoints parses a comma separated list of urls. Query parameters
+// follow the last url and apply to all of them. The primary is the url
+// or host matching the primary parameter, or the first url.
+func parseEndpoints(name string) ([]*url.URL, url.Values, error) {
+	list, rawQuery := name, ""
+	if i := strings.Index(name, "?"); i >= 0 {
+		list, rawQuery = name[:i], name[i+1:]
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var urls []*url.URL
+
+	for _, s := range strings.Split(list, ",") {
+		u, err := url.Parse(strings.TrimSpace(s))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if u.Scheme == "" {
+			return nil, nil, errors.New("scheme must be set in url")
+		}
+
+		urls = append(urls, u)
+	}
+
+	primary := query.Get("primary")
+	query.Del("primary")
+
+	if primary == "" {
+		return urls, query, nil
+	}
+
+	for i, u := range urls {
+		if u.String() == primary || u.Host == primary {
+			// the primary is first
+			urls[0], urls[i] = urls[i], urls[0]
+			return urls, query, nil
+		}
+	}
+
+	return nil, nil, errors.New("primary " + primary + " is not one of the urls")
+}
+
+func (c *cluster) wrote() {
+	if c.readYourWrites > 0 {
+		atomic.StoreInt64(&c.lastWrite, time.Now().UnixNano())
+	}
+}
+
+func (c *cluster) recentlyWrote(now time.Time) bool {
+	if c.readYourWrites <= 0 {
+		return false
+	}
+
+	return now.UnixNano()-atomic.LoadInt64(&c.lastWrite) < int64(c.readYourWrites)
+}
+
+// readers returns the endpoints to try for a query, in order.
+// Healthy replicas are used in turn, then the primary.
+func (c *cluster) readers() []*endpoint {
+	now := time.Now()
+
+	if len(c.replicas) == 0 || c.recentlyWrote(now) {
+		return []*endpoint{c.primary}
+	}
+
+	start := int(atomic.AddUint32(&c.next, 1))
+
+	endpoints := make([]*endpoint, 0, len(c.replicas)+1)
+
+	for i := range c.replicas {
+		e := c.replicas[(start+i)%len(c.replicas)]
+		if e.healthy(now) {
+			endpoints = append(endpoints, e)
+		}
+	}
+
+	return append(endpoints, c.primary)
+}
+
+// read calls fn for a query that is not in a transaction. If an endpoint is
+// unavailable or busy, fn is retried on the next one. Replicas reject writes without
+// running them, so this only retries queries that did not write. A query that
+// a replica rejects as a write is sent to the primary.
+func (c *cluster) read(ctx context.Context, fn func(*endpoint) error) error {
+	var err error
+
+	for _, e := range c.readers() {
+		err = e.call(ctx, fn)
+
+		if e != c.primary && isReplicaWrite(err) {
+			if err = c.primary.call(ctx, fn); err == nil {
+				c.wrote()
+			}
+			return err
+		}
+
+		if !(unavailable(err) || busy(err)) || ctx.Err() != nil {
+			return err
+		}
+	}
+
+	return err
+}
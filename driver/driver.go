@@ -2,53 +2,153 @@ package driver
 
 import (
 	"context"
+	"crypto/x509"
 	"database/sql"
 	"database/sql/driver"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 	"reflect"
+	"strings"
 	"time"
 
+	"github.com/twitchtv/twirp"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/bakins/sqliterpc"
 )
 
 type Driver struct {
-	transport http.RoundTripper
+	transport      http.RoundTripper
+	streaming      bool
+	pageSize       int32
+	tokenSource    TokenSource
+	readYourWrites time.Duration
+	timeout        time.Duration
+	retries        int
+	compression    Compression
+	codec          Codec
+	database       string
+	rootCAs        *x509.CertPool
 }
 
-func NewDriver(transport http.RoundTripper) *Driver {
+type Option interface {
+	apply(*Driver)
+}
+
+type optionFunc func(*Driver)
+
+func (f optionFunc) apply(d *Driver) {
+	f(d)
+}
+
+// WithStreamingQueries makes queries use the streaming endpoint, so rows are
+// fetched in batches as they are read rather than in a single response.
+// The server must serve the streaming endpoint - see server.NewHandler.
+func WithStreamingQueries() Option {
+	return optionFunc(func(d *Driver) {
+		d.streaming = true
+	})
+}
+
+// WithPageSize makes queries fetch at most size rows per request.
+// More rows are fetched as they are read.
+func WithPageSize(size int) Option {
+	return optionFunc(func(d *Driver) {
+		d.pageSize = int32(size)
+	})
+}
+
+func NewDriver(transport http.RoundTripper, options ...Option) *Driver {
 	if transport == nil {
 		transport = http.DefaultTransport
 	}
 
 	d := Driver{
 		transport: transport,
+		retries:   2,
+		codec:     CodecProtobuf,
+	}
+
+	for _, o := range options {
+		o.apply(&d)
 	}
 
 	return &d
 }
 
+// OpenConnector returns a connector for the server at the URL name.
+// When the server serves multiple databases, the path selects the database,
+// such as http://localhost:8080/db/tenant42
+//
+// name may be a comma separated list of a primary and its read replicas,
+// such as http://a:8080,http://b:8080?primary=a:8080
+// The primary parameter is the url or host of the primary, and defaults to
+// the first url. Writes and transactions use the primary, and other queries
+// use healthy replicas in turn.
+//
+// Other query parameters set options, overriding those of the driver:
+//
+//	token             bearer token, see WithToken
+//	timeout           duration, such as 5s, see WithTimeout
+//	retries           number of retries, see WithRetries
+//	compression       gzip or none, see WithCompression
+//	codec             protobuf or json, see WithCodec
+//	db                database name, see WithDatabase
+//	tls_ca            file of PEM encoded certificate authorities, see WithRootCAs
+//	read_your_writes  duration, see WithReadYourWrites
+//
+// The driver is registered with database/sql as "sqliterpc", so
+// sql.Open("sqliterpc", "http://localhost:8080?timeout=5s") may be used.
 func (d *Driver) OpenConnector(name string) (driver.Connector, error) {
-	u, err := url.Parse(name)
+	urls, query, err := parseEndpoints(name)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := dsnOptions(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// the connector's options, which leave the driver unchanged.
+	cfg := *d
+	for _, o := range options {
+		o.apply(&cfg)
+	}
+
+	httpClient, err := cfg.httpClient()
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: params as options?
+	cl := cluster{
+		readYourWrites: cfg.readYourWrites,
+	}
+
+	for i, u := range urls {
+		if cfg.database != "" {
+			// see server.DatabasePathPrefix
+			u.Path = strings.TrimSuffix(u.Path, "/") + "/db/" + cfg.database
+		}
+
+		e := newEndpoint(u.String(), httpClient, &cfg)
 
-	if u.Scheme == "" {
-		return nil, errors.New("scheme must be set in url")
+		if i == 0 {
+			cl.primary = e
+		} else {
+			cl.replicas = append(cl.replicas, e)
+		}
 	}
 
 	c := connector{
-		driver:  d,
-		baseURL: u.String(),
+		driver:    d,
+		cluster:   &cl,
+		streaming: cfg.streaming,
+		pageSize:  cfg.pageSize,
 	}
+
 	return &c, nil
 }
 
@@ -62,8 +162,10 @@ func (d *Driver) Open(name string) (driver.Conn, error) {
 }
 
 type connector struct {
-	driver  *Driver
-	baseURL string
+	driver    *Driver
+	cluster   *cluster
+	streaming bool
+	pageSize  int32
 }
 
 func (c *connector) Driver() driver.Driver {
@@ -71,20 +173,24 @@ func (c *connector) Driver() driver.Driver {
 }
 
 func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
-	transport := c.driver.transport
-	if transport == nil {
-		transport = http.DefaultTransport
-	}
-
 	connection := connection{
-		client: sqliterpc.NewDatabaseServiceProtobufClient(c.baseURL, &http.Client{Transport: transport}),
+		client:    c.cluster.primary.client,
+		cluster:   c.cluster,
+		streaming: c.streaming,
+		pageSize:  c.pageSize,
 	}
 
 	return &connection, nil
 }
 
 type connection struct {
-	client sqliterpc.DatabaseService
+	// client is the primary's client. It is nil once the connection is closed.
+	client    sqliterpc.DatabaseService
+	cluster   *cluster
+	streaming bool
+	pageSize  int32
+	// transactionID is set while a transaction is in progress.
+	transactionID string
 }
 
 func (c *connection) Prepare(query string) (driver.Stmt, error) {
@@ -101,13 +207,142 @@ func (c *connection) PrepareContext(ctx context.Context, query string) (driver.S
 	s := statement{
 		connection: c,
 		query:      query,
+		ids:        make(map[*endpoint]string),
+	}
+
+	// the statement may write, so it is prepared on the primary. Queries
+	// prepare it on a replica when they are first sent to one. In a
+	// transaction, it is prepared in the transaction, as it may use tables
+	// the transaction has changed.
+	if err := s.prepare(ctx, c.cluster.primary); err != nil {
+		return nil, err
 	}
 
 	return &s, nil
 }
 
+// ExecContext runs query without preparing it first.
+func (c *connection) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.client == nil {
+		return nil, ErrConnectionClosed
+	}
+
+	values, err := namedValueToParameters(args)
+	if err != nil {
+		return nil, err
+	}
+
+	req := sqliterpc.ExecRequest{
+		Sql:        query,
+		Parameters: values,
+	}
+
+	return c.exec(ctx, &req)
+}
+
+// QueryContext runs query without preparing it first.
+func (c *connection) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.client == nil {
+		return nil, ErrConnectionClosed
+	}
+
+	values, err := namedValueToParameters(args)
+	if err != nil {
+		return nil, err
+	}
+
+	req := sqliterpc.QueryRequest{
+		Sql:        query,
+		Parameters: values,
+	}
+
+	var r driver.Rows
+
+	err = c.read(ctx, func(e *endpoint) error {
+		var err error
+		r, err = c.queryEndpoint(ctx, e, &req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (c *connection) exec(ctx context.Context, req *sqliterpc.ExecRequest) (driver.Result, error) {
+	req.TransactionId = c.transactionID
+
+	resp, err := c.client.Exec(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.transactionID == "" {
+		c.cluster.wrote()
+	}
+
+	e := execResult{
+		ExecResponse: resp,
+	}
+
+	return &e, nil
+}
+
+// read calls fn with the endpoint to query. Queries in a transaction use
+// the primary. Others may use a replica, and are retried on another endpoint
+// if one is unavailable.
+func (c *connection) read(ctx context.Context, fn func(*endpoint) error) error {
+	if c.transactionID != "" {
+		return fn(c.cluster.primary)
+	}
+
+	return c.cluster.read(ctx, fn)
+}
+
+func (c *connection) queryEndpoint(ctx context.Context, e *endpoint, req *sqliterpc.QueryRequest) (driver.Rows, error) {
+	req.TransactionId = c.transactionID
+
+	if c.streaming {
+		return c.queryStream(ctx, e, req)
+	}
+
+	req.PageSize = c.pageSize
+
+	resp, err := e.client.Query(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	r := rows{
+		columns: resp.Columns,
+		rows:    resp.Rows,
+		current: 0,
+	}
+
+	if resp.NextPageToken != "" {
+		p := pager{
+			ctx:           ctx,
+			client:        e.client,
+			transactionID: req.TransactionId,
+			token:         resp.NextPageToken,
+		}
+
+		r.more = p.next
+		r.release = p.close
+	}
+
+	return &r, nil
+}
+
 func (c *connection) Close() error {
+	if c.client != nil && c.transactionID != "" {
+		// best effort - the server will eventually reap it
+		_, _ = c.client.Rollback(context.Background(), &sqliterpc.RollbackRequest{TransactionId: c.transactionID})
+	}
+
 	c.client = nil
+	c.transactionID = ""
 	return nil
 }
 
@@ -115,24 +350,185 @@ func (c *connection) Begin() (driver.Tx, error) {
 	return c.BeginTx(context.Background(), driver.TxOptions{})
 }
 
+var ErrTransactionInProgress = errors.New("transaction already in progress")
+
+// ErrTransactionsUnsupported was returned by BeginTx before transactions
+// were supported.
+//
+// Deprecated: BeginTx no longer returns it.
 var ErrTransactionsUnsupported = errors.New("transactions are not supported")
 
 func (c *connection) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
-	return nil, ErrTransactionsUnsupported
+	if c.client == nil {
+		return nil, ErrConnectionClosed
+	}
+
+	if c.transactionID != "" {
+		return nil, ErrTransactionInProgress
+	}
+
+	mode, err := isolationToMode(sql.IsolationLevel(opts.Isolation))
+	if err != nil {
+		return nil, err
+	}
+
+	req := sqliterpc.BeginRequest{
+		Mode:     mode,
+		ReadOnly: opts.ReadOnly,
+	}
+
+	resp, err := c.client.Begin(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	c.transactionID = resp.TransactionId
+
+	t := transaction{
+		connection: c,
+		readOnly:   opts.ReadOnly,
+	}
+
+	return &t, nil
+}
+
+// sqlite only has serializable transactions, so isolation levels
+// are mapped to when locks are acquired.
+// see https://www.sqlite.org/lang_transaction.html
+func isolationToMode(level sql.IsolationLevel) (sqliterpc.TransactionMode, error) {
+	switch level {
+	case sql.LevelDefault, sql.LevelReadUncommitted, sql.LevelReadCommitted, sql.LevelSnapshot:
+		return sqliterpc.TransactionMode_TRANSACTION_MODE_DEFERRED, nil
+	case sql.LevelWriteCommitted, sql.LevelRepeatableRead:
+		return sqliterpc.TransactionMode_TRANSACTION_MODE_IMMEDIATE, nil
+	case sql.LevelSerializable, sql.LevelLinearizable:
+		return sqliterpc.TransactionMode_TRANSACTION_MODE_EXCLUSIVE, nil
+	default:
+		return sqliterpc.TransactionMode_TRANSACTION_MODE_UNSPECIFIED, fmt.Errorf("unsupported isolation level %s", level)
+	}
+}
+
+type transaction struct {
+	connection *connection
+	readOnly   bool
+}
+
+var ErrTransactionDone = errors.New("transaction has already been committed or rolled back")
+
+func (t *transaction) Commit() error {
+	if t.connection == nil {
+		return ErrTransactionDone
+	}
+
+	c := t.connection
+	t.connection = nil
+
+	req := sqliterpc.CommitRequest{
+		TransactionId: c.transactionID,
+	}
+
+	c.transactionID = ""
+
+	_, err := c.client.Commit(context.Background(), &req)
+	if err == nil && !t.readOnly {
+		c.cluster.wrote()
+	}
+
+	return err
+}
+
+func (t *transaction) Rollback() error {
+	if t.connection == nil {
+		return ErrTransactionDone
+	}
+
+	c := t.connection
+	t.connection = nil
+
+	req := sqliterpc.RollbackRequest{
+		TransactionId: c.transactionID,
+	}
+
+	c.transactionID = ""
+
+	_, err := c.client.Rollback(context.Background(), &req)
+
+	return err
 }
 
 type statement struct {
 	connection *connection
 	query      string
+	// ids are the statement ids of each endpoint the statement has been prepared on.
+	ids      map[*endpoint]string
+	numInput int
+}
+
+func (s *statement) prepare(ctx context.Context, e *endpoint) error {
+	req := sqliterpc.PrepareRequest{
+		Sql:           s.query,
+		TransactionId: s.connection.transactionID,
+	}
+
+	resp, err := e.client.Prepare(ctx, &req)
+	if err != nil {
+		return err
+	}
+
+	s.ids[e] = resp.StatementId
+	s.numInput = int(resp.NumInput)
+
+	return nil
+}
+
+// withRetry calls fn with the statement id for e, preparing the statement
+// on e if needed. If the server has closed the statement, it is prepared
+// again and fn is retried.
+func (s *statement) withRetry(ctx context.Context, e *endpoint, fn func(id string) error) error {
+	id, ok := s.ids[e]
+	if ok {
+		err := fn(id)
+		if !isStatementNotFound(err) {
+			return err
+		}
+	}
+
+	if err := s.prepare(ctx, e); err != nil {
+		return err
+	}
+
+	return fn(s.ids[e])
+}
+
+func isStatementNotFound(err error) bool {
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) {
+		return false
+	}
+
+	return twerr.Code() == twirp.NotFound && twerr.Meta("argument") == "statement_id"
 }
 
 func (s *statement) Close() error {
+	if s.connection == nil {
+		return nil
+	}
+
+	if s.connection.client != nil {
+		// best effort - the server may have already closed it
+		for e, id := range s.ids {
+			_, _ = e.client.CloseStatement(context.Background(), &sqliterpc.CloseStatementRequest{StatementId: id})
+		}
+	}
+
 	s.connection = nil
 	return nil
 }
 
+// NumInput returns the number of parameters, or -1
+// if the query has multiple statements.
 func (s *statement) NumInput() int {
-	return -1
+	return s.numInput
 }
 
 var (
@@ -155,21 +551,23 @@ func (s *statement) ExecContext(ctx context.Context, args []driver.NamedValue) (
 		return nil, err
 	}
 
-	req := sqliterpc.ExecRequest{
-		Sql:        s.query,
-		Parameters: values,
-	}
+	var result driver.Result
 
-	resp, err := s.connection.client.Exec(ctx, &req)
+	err = s.withRetry(ctx, s.connection.cluster.primary, func(id string) error {
+		req := sqliterpc.ExecRequest{
+			StatementId: id,
+			Parameters:  values,
+		}
+
+		var err error
+		result, err = s.connection.exec(ctx, &req)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	e := execResult{
-		ExecResponse: resp,
-	}
-
-	return &e, nil
+	return result, nil
 }
 
 type execResult struct {
@@ -190,7 +588,7 @@ func namedValueToParameters(args []driver.NamedValue) ([]*sqliterpc.Value, error
 
 	for _, arg := range args {
 		n := arg.Ordinal - 1
-		if n > len(args) || n < 0 {
+		if n >= len(args) || n < 0 {
 			return nil, fmt.Errorf("invalid ordinal in value: %d", arg.Ordinal)
 		}
 		switch t := arg.Value.(type) {
@@ -215,6 +613,17 @@ func namedValueToParameters(args []driver.NamedValue) ([]*sqliterpc.Value, error
 			}
 			values[n] = &v
 
+		case float64:
+			v := sqliterpc.Value{
+				Kind: &sqliterpc.Value_RealValue{
+					RealValue: &sqliterpc.RealValue{
+						Value: t,
+						Valid: true,
+					},
+				},
+			}
+			values[n] = &v
+
 		case bool:
 			v := sqliterpc.Value{
 				Kind: &sqliterpc.Value_BoolValue{
@@ -249,10 +658,18 @@ func namedValueToParameters(args []driver.NamedValue) ([]*sqliterpc.Value, error
 			values[n] = &v
 
 		case time.Time:
+			ts := timestamppb.New(t)
+
+			// the json codec cannot encode times outside of years 1 to 9999,
+			// so they are rejected whatever the codec.
+			if err := ts.CheckValid(); err != nil {
+				return nil, fmt.Errorf("invalid time %s: %w", t, err)
+			}
+
 			v := sqliterpc.Value{
 				Kind: &sqliterpc.Value_TimeValue{
 					TimeValue: &sqliterpc.TimeValue{
-						Value: timestamppb.New(t),
+						Value: ts,
 						Valid: true,
 					},
 				},
@@ -262,6 +679,8 @@ func namedValueToParameters(args []driver.NamedValue) ([]*sqliterpc.Value, error
 		default:
 			return nil, fmt.Errorf("unsupported type %T", t)
 		}
+
+		values[n].Name = arg.Name
 	}
 
 	return values, nil
@@ -281,41 +700,95 @@ func (s *statement) QueryContext(ctx context.Context, args []driver.NamedValue)
 		return nil, err
 	}
 
+	var r driver.Rows
+
+	err = s.connection.read(ctx, func(e *endpoint) error {
+		return s.withRetry(ctx, e, func(id string) error {
+			req := sqliterpc.QueryRequest{
+				StatementId: id,
+				Parameters:  values,
+			}
+
+			var err error
+			r, err = s.connection.queryEndpoint(ctx, e, &req)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// pager fetches the remaining pages of a query.
+type pager struct {
+	ctx           context.Context
+	client        sqliterpc.DatabaseService
+	transactionID string
+	token         string
+}
+
+func (p *pager) next() ([]*sqliterpc.ListValue, error) {
+	if p.token == "" {
+		return nil, io.EOF
+	}
+
 	req := sqliterpc.QueryRequest{
-		Sql:        s.query,
-		Parameters: values,
+		TransactionId: p.transactionID,
+		PageToken:     p.token,
 	}
 
-	resp, err := s.connection.client.Query(ctx, &req)
+	resp, err := p.client.Query(p.ctx, &req)
 	if err != nil {
 		return nil, err
 	}
-	_ = resp
 
-	r := rows{
-		response: resp,
-		current:  0,
+	p.token = resp.NextPageToken
+
+	return resp.Rows, nil
+}
+
+// close releases the cursor on the server if all rows were not read.
+func (p *pager) close() error {
+	if p.token == "" {
+		return nil
 	}
 
-	return &r, nil
+	req := sqliterpc.CloseCursorRequest{
+		PageToken: p.token,
+	}
+
+	p.token = ""
+
+	_, err := p.client.CloseCursor(context.Background(), &req)
+
+	return err
 }
 
 // assumes only access by one goroutin
 type rows struct {
-	response *sqliterpc.QueryResponse
-	current  int
+	columns []*sqliterpc.Column
+	rows    []*sqliterpc.ListValue
+	current int
+	// more, if set, is called to get more rows when rows are exhausted.
+	// it returns io.EOF when there are no more rows.
+	more func() ([]*sqliterpc.ListValue, error)
+	// release, if set, is called on Close
+	release func() error
+	closed  bool
 }
 
 var ErrRowsClosed = errors.New("rows closed")
 
 func (r *rows) Columns() []string {
-	if r.response == nil {
+	if r.closed {
 		return nil
 	}
 
-	out := make([]string, len(r.response.Columns))
+	out := make([]string, len(r.columns))
 
-	for i, column := range r.response.Columns {
+	for i, column := range r.columns {
 		out[i] = column.Name
 	}
 
@@ -323,7 +796,16 @@ func (r *rows) Columns() []string {
 }
 
 func (r *rows) Close() error {
-	r.response = nil
+	if r.closed {
+		return nil
+	}
+
+	r.closed = true
+	r.rows = nil
+
+	if r.release != nil {
+		return r.release()
+	}
 
 	return nil
 }
@@ -335,11 +817,11 @@ func (r *rows) ColumnTypeNullable(index int) (nullable, ok bool) {
 
 // see https://github.com/mattn/go-sqlite3/blob/v1.14.13/sqlite3_type.go#L41
 func (r *rows) ColumnTypeScanType(index int) reflect.Type {
-	if index >= len(r.response.Columns) {
+	if index >= len(r.columns) {
 		return reflect.TypeOf(new(interface{}))
 	}
 
-	switch r.response.Columns[index].Type {
+	switch r.columns[index].Type {
 	case sqliterpc.TypeCode_TYPE_CODE_INTEGER:
 		return reflect.TypeOf(sql.NullInt64{})
 	case sqliterpc.TypeCode_TYPE_CODE_TEXT:
@@ -360,11 +842,11 @@ func (r *rows) ColumnTypeScanType(index int) reflect.Type {
 }
 
 func (r *rows) RowsColumnTypeDatabaseTypeName(index int) string {
-	if index >= len(r.response.Columns) {
+	if index >= len(r.columns) {
 		return ""
 	}
 
-	switch r.response.Columns[index].Type {
+	switch r.columns[index].Type {
 	case sqliterpc.TypeCode_TYPE_CODE_INTEGER:
 		return "INTEGER"
 	case sqliterpc.TypeCode_TYPE_CODE_TEXT:
@@ -388,15 +870,28 @@ func (r *rows) RowsColumnTypeDatabaseTypeName(index int) string {
 }
 
 func (r *rows) Next(dest []driver.Value) error {
-	if r.response == nil {
+	if r.closed {
 		return ErrRowsClosed
 	}
 
-	if r.current >= len(r.response.Rows) {
-		return io.EOF
+	for r.current >= len(r.rows) {
+		if r.more == nil {
+			return io.EOF
+		}
+
+		more, err := r.more()
+		if err != nil {
+			if err == io.EOF {
+				r.more = nil
+			}
+			return err
+		}
+
+		r.rows = more
+		r.current = 0
 	}
 
-	row := r.response.Rows[r.current].Values
+	row := r.rows[r.current].Values
 
 	// does this matter?
 	// if len(dest) < len(row.Values) {
@@ -408,7 +903,16 @@ func (r *rows) Next(dest []driver.Value) error {
 	}
 
 	for i := range dest {
-		switch r.response.Columns[i].Type {
+		if r.columns[i].Dynamic {
+			v, err := dynamicValue(row[i])
+			if err != nil {
+				return fmt.Errorf("column %q: %w", r.columns[i].Name, err)
+			}
+			dest[i] = v
+			continue
+		}
+
+		switch r.columns[i].Type {
 		case sqliterpc.TypeCode_TYPE_CODE_INTEGER:
 			v := row[i].GetIntegerValue()
 			if v.GetValid() {
@@ -428,7 +932,12 @@ func (r *rows) Next(dest []driver.Value) error {
 		case sqliterpc.TypeCode_TYPE_CODE_BLOB:
 			v := row[i].GetBlobValue()
 			if v.GetValid() {
-				dest[i] = v.GetValue()
+				// empty blobs are decoded as nil, which is not NULL.
+				b := v.GetValue()
+				if b == nil {
+					b = []byte{}
+				}
+				dest[i] = b
 			} else {
 				dest[i] = nil
 			}
@@ -450,7 +959,7 @@ func (r *rows) Next(dest []driver.Value) error {
 			}
 
 		case sqliterpc.TypeCode_TYPE_CODE_BOOL:
-			v := row[i].GetNumericValue()
+			v := row[i].GetBoolValue()
 			if v.GetValid() {
 				dest[i] = v.GetValue()
 			} else {
@@ -460,7 +969,7 @@ func (r *rows) Next(dest []driver.Value) error {
 		case sqliterpc.TypeCode_TYPE_CODE_TIME:
 			v := row[i].GetTimeValue()
 			if v.GetValid() {
-				dest[i] = v.GetValue().AsTime
+				dest[i] = v.GetValue().AsTime()
 			} else {
 				dest[i] = nil
 			}
@@ -470,7 +979,7 @@ func (r *rows) Next(dest []driver.Value) error {
 
 		default:
 			// should never happen, but just in case
-			return fmt.Errorf("unsupported column type %q for %q", r.response.Columns[i].Type, r.response.Columns[i].Name)
+			return fmt.Errorf("unsupported column type %q for %q", r.columns[i].Type, r.columns[i].Name)
 		}
 	}
 
@@ -478,3 +987,43 @@ func (r *rows) Next(dest []driver.Value) error {
 
 	return nil
 }
+
+// dynamicValue converts a value from a dynamic column, which has the type
+// of the kind of the value.
+func dynamicValue(value *sqliterpc.Value) (driver.Value, error) {
+	var (
+		v     driver.Value
+		valid bool
+	)
+
+	switch kind := value.GetKind().(type) {
+	case *sqliterpc.Value_IntegerValue:
+		v, valid = kind.IntegerValue.GetValue(), kind.IntegerValue.GetValid()
+	case *sqliterpc.Value_TextValue:
+		v, valid = kind.TextValue.GetValue(), kind.TextValue.GetValid()
+	case *sqliterpc.Value_BlobValue:
+		b := kind.BlobValue.GetValue()
+		if b == nil {
+			b = []byte{}
+		}
+		v, valid = b, kind.BlobValue.GetValid()
+	case *sqliterpc.Value_RealValue:
+		v, valid = kind.RealValue.GetValue(), kind.RealValue.GetValid()
+	case *sqliterpc.Value_NumericValue:
+		v, valid = kind.NumericValue.GetValue(), kind.NumericValue.GetValid()
+	case *sqliterpc.Value_BoolValue:
+		v, valid = kind.BoolValue.GetValue(), kind.BoolValue.GetValid()
+	case *sqliterpc.Value_TimeValue:
+		v, valid = kind.TimeValue.GetValue().AsTime(), kind.TimeValue.GetValid()
+	case *sqliterpc.Value_NullValue, nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", kind)
+	}
+
+	if !valid {
+		return nil, nil
+	}
+
+	return v, nil
+}
@@ -3,19 +3,39 @@ package driver_test
 import (
 	"context"
 	"database/sql"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	sqlite3 "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/auth"
 	"github.com/bakins/sqliterpc/driver"
+	"github.com/bakins/sqliterpc/ratelimit"
 	"github.com/bakins/sqliterpc/server"
 )
 
 func TestDriver(t *testing.T) {
+	forEachCodec(t, testDriver)
+}
+
+func testDriver(t *testing.T, options ...driver.Option) {
 	file := "testing.db"
 	defer os.Remove(file)
 
@@ -55,7 +75,7 @@ func TestDriver(t *testing.T) {
 	svr := httptest.NewServer(sqliterpc.NewDatabaseServiceServer(s))
 	defer svr.Close()
 
-	connector, err := driver.NewDriver(nil).OpenConnector(svr.URL)
+	connector, err := driver.NewDriver(nil, options...).OpenConnector(svr.URL)
 	require.NoError(t, err)
 
 	db := sql.OpenDB(connector)
@@ -86,3 +106,1451 @@ func TestDriver(t *testing.T) {
 
 	require.Equal(t, 100, count)
 }
+
+func TestTransaction(t *testing.T) {
+	forEachCodec(t, testTransaction)
+}
+
+func testTransaction(t *testing.T, options ...driver.Option) {
+	file := "transaction.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file)
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	svr := httptest.NewServer(sqliterpc.NewDatabaseServiceServer(s))
+	defer svr.Close()
+
+	connector, err := driver.NewDriver(nil, options...).OpenConnector(svr.URL)
+	require.NoError(t, err)
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, `create table testing (intCol INTEGER)`)
+	require.NoError(t, err)
+
+	count := func(q interface {
+		QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	},
+	) int {
+		rows, err := q.QueryContext(ctx, `select intCol from testing`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		c := 0
+		for rows.Next() {
+			c++
+		}
+		require.NoError(t, rows.Err())
+
+		return c
+	}
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	require.NoError(t, err)
+
+	_, err = tx.ExecContext(ctx, `insert into testing (intCol) values (?)`, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, count(tx))
+
+	require.NoError(t, tx.Commit())
+	require.Equal(t, 1, count(db))
+
+	tx, err = db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+
+	_, err = tx.ExecContext(ctx, `insert into testing (intCol) values (?)`, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, count(tx))
+
+	require.NoError(t, tx.Rollback())
+	require.Equal(t, 1, count(db))
+
+	tx, err = db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	require.NoError(t, err)
+
+	_, err = tx.ExecContext(ctx, `insert into testing (intCol) values (?)`, 3)
+	require.Error(t, err)
+
+	require.NoError(t, tx.Rollback())
+
+	// statements prepared in a transaction run in it, and may use its changes.
+	tx, err = db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+
+	insert, err := tx.PrepareContext(ctx, `insert into testing (intCol) values (?)`)
+	require.NoError(t, err)
+
+	_, err = insert.ExecContext(ctx, 4)
+	require.NoError(t, err)
+	require.Equal(t, 2, count(tx))
+
+	_, err = tx.ExecContext(ctx, `create table created (intCol INTEGER)`)
+	require.NoError(t, err)
+
+	_, err = tx.ExecContext(ctx, `insert into created (intCol) values (5)`)
+	require.NoError(t, err)
+
+	query, err := tx.PrepareContext(ctx, `select intCol from created`)
+	require.NoError(t, err)
+
+	var value int64
+	require.NoError(t, query.QueryRowContext(ctx).Scan(&value))
+	require.Equal(t, int64(5), value)
+
+	require.NoError(t, tx.Commit())
+	require.Equal(t, 2, count(db))
+}
+
+func TestBatch(t *testing.T) {
+	forEachCodec(t, testBatch)
+}
+
+func testBatch(t *testing.T, options ...driver.Option) {
+	file := "batch.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file)
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	svr := httptest.NewServer(sqliterpc.NewDatabaseServiceServer(s))
+	defer svr.Close()
+
+	connector, err := driver.NewDriver(nil, options...).OpenConnector(svr.URL)
+	require.NoError(t, err)
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, `create table testing (intCol INTEGER)`)
+	require.NoError(t, err)
+
+	var req sqliterpc.BatchRequest
+
+	for i := 0; i < 100; i++ {
+		step, err := driver.ExecStep(`insert into testing (intCol) values (?)`, i)
+		require.NoError(t, err)
+
+		req.Steps = append(req.Steps, step)
+	}
+
+	step, err := driver.QueryStep(`select intCol from testing where intCol >= ?`, 50)
+	require.NoError(t, err)
+
+	req.Steps = append(req.Steps, step)
+
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	resp, err := driver.Batch(ctx, conn, &req)
+	require.NoError(t, err)
+	require.True(t, resp.Committed)
+	require.Len(t, resp.Results, 101)
+	require.Len(t, resp.Results[100].GetQuery().Rows, 50)
+}
+
+func TestStreamingQuery(t *testing.T) {
+	forEachCodec(t, testStreamingQuery)
+}
+
+func testStreamingQuery(t *testing.T, options ...driver.Option) {
+	file := "stream.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file)
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	svr := httptest.NewServer(server.NewHandler(s))
+	defer svr.Close()
+
+	connector, err := driver.NewDriver(nil, append(options, driver.WithStreamingQueries())...).OpenConnector(svr.URL)
+	require.NoError(t, err)
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, `create table testing (intCol INTEGER)`)
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `with recursive n(i) as (select 0 union all select i + 1 from n where i < 999)
+		insert into testing (intCol) select i from n`)
+	require.NoError(t, err)
+
+	rows, err := db.QueryContext(ctx, `select intCol from testing order by intCol`)
+	require.NoError(t, err)
+
+	count := 0
+	for rows.Next() {
+		var val int64
+		err := rows.Scan(&val)
+		require.NoError(t, err)
+
+		require.Equal(t, int64(count), val)
+
+		count++
+	}
+
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+	require.Equal(t, 1000, count)
+
+	// close before reading all rows
+	rows, err = db.QueryContext(ctx, `select intCol from testing`)
+	require.NoError(t, err)
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Close())
+
+	_, err = db.QueryContext(ctx, `select * from missing`)
+	require.Error(t, err)
+}
+
+func TestPaging(t *testing.T) {
+	forEachCodec(t, testPaging)
+}
+
+func testPaging(t *testing.T, options ...driver.Option) {
+	file := "paging.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file)
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	svr := httptest.NewServer(sqliterpc.NewDatabaseServiceServer(s))
+	defer svr.Close()
+
+	connector, err := driver.NewDriver(nil, append(options, driver.WithPageSize(7))...).OpenConnector(svr.URL)
+	require.NoError(t, err)
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, `create table testing (intCol INTEGER)`)
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		_, err := db.ExecContext(ctx, `insert into testing (intCol) values (?)`, i)
+		require.NoError(t, err)
+	}
+
+	rows, err := db.QueryContext(ctx, `select intCol from testing order by intCol`)
+	require.NoError(t, err)
+
+	count := 0
+	for rows.Next() {
+		var val int64
+		err := rows.Scan(&val)
+		require.NoError(t, err)
+
+		require.Equal(t, int64(count), val)
+
+		count++
+	}
+
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+	require.Equal(t, 100, count)
+
+	// close before reading all rows releases the cursor
+	for i := 0; i < 20; i++ {
+		rows, err = db.QueryContext(ctx, `select intCol from testing`)
+		require.NoError(t, err)
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Close())
+	}
+}
+
+func TestNamedParameters(t *testing.T) {
+	forEachCodec(t, testNamedParameters)
+}
+
+func testNamedParameters(t *testing.T, options ...driver.Option) {
+	file := "named.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file)
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	svr := httptest.NewServer(sqliterpc.NewDatabaseServiceServer(s))
+	defer svr.Close()
+
+	connector, err := driver.NewDriver(nil, options...).OpenConnector(svr.URL)
+	require.NoError(t, err)
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, `create table testing (intCol INTEGER, textCol TEXT)`)
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `insert into testing (intCol, textCol) values (:id, @name)`,
+		sql.Named("name", "one"), sql.Named("id", 1))
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `insert into testing (intCol, textCol) values (?, $name)`,
+		2, sql.Named("name", "two"))
+	require.NoError(t, err)
+
+	var (
+		id   int64
+		name string
+	)
+
+	err = db.QueryRowContext(ctx, `select intCol, textCol from testing where textCol = :name and intCol = ?`,
+		sql.Named("name", "two"), 2).Scan(&id, &name)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), id)
+	require.Equal(t, "two", name)
+
+	err = db.QueryRowContext(ctx, `select intCol, textCol from testing where intCol = :id`,
+		sql.Named("id", 1)).Scan(&id, &name)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), id)
+	require.Equal(t, "one", name)
+}
+
+func TestErrors(t *testing.T) {
+	forEachCodec(t, testErrors)
+}
+
+func testErrors(t *testing.T, options ...driver.Option) {
+	file := "errors.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file)
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	svr := httptest.NewServer(server.NewHandler(s))
+	defer svr.Close()
+
+	for _, streaming := range []bool{false, true} {
+		connectorOptions := options
+		if streaming {
+			connectorOptions = append(connectorOptions, driver.WithStreamingQueries())
+		}
+
+		connector, err := driver.NewDriver(nil, connectorOptions...).OpenConnector(svr.URL)
+		require.NoError(t, err)
+
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		_, err = db.ExecContext(ctx, `create table if not exists testing (intCol INTEGER UNIQUE)`)
+		require.NoError(t, err)
+
+		_, err = db.ExecContext(ctx, `insert or ignore into testing (intCol) values (?)`, 1)
+		require.NoError(t, err)
+
+		_, err = db.ExecContext(ctx, `insert into testing (intCol) values (?)`, 1)
+		require.Error(t, err)
+
+		var sqliteErr *driver.SQLiteError
+		require.True(t, errors.As(err, &sqliteErr))
+		require.Equal(t, int(sqlite3.ErrConstraint), sqliteErr.Code)
+		require.Equal(t, int(sqlite3.ErrConstraintUnique), sqliteErr.ExtendedCode)
+
+		var twerr twirp.Error
+		require.True(t, errors.As(err, &twerr))
+		require.Equal(t, twirp.AlreadyExists, twerr.Code())
+
+		_, err = db.QueryContext(ctx, `select * from missing`)
+		require.Error(t, err)
+		require.True(t, errors.As(err, &sqliteErr))
+		require.Equal(t, int(sqlite3.ErrError), sqliteErr.Code)
+	}
+}
+
+func TestMultipleDatabases(t *testing.T) {
+	forEachCodec(t, testMultipleDatabases)
+}
+
+func testMultipleDatabases(t *testing.T, options ...driver.Option) {
+	m, err := server.NewManager(t.TempDir(), server.WithCreateDatabases(true))
+	require.NoError(t, err)
+
+	defer m.Close()
+
+	svr := httptest.NewServer(m)
+	defer svr.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	open := func(name string) *sql.DB {
+		connector, err := driver.NewDriver(nil, append(options, driver.WithStreamingQueries())...).OpenConnector(svr.URL + "/db/" + name)
+		require.NoError(t, err)
+
+		return sql.OpenDB(connector)
+	}
+
+	for i, name := range []string{"tenant1", "tenant2"} {
+		db := open(name)
+
+		_, err = db.ExecContext(ctx, `create table testing (intCol INTEGER)`)
+		require.NoError(t, err)
+
+		for j := 0; j <= i; j++ {
+			_, err = db.ExecContext(ctx, `insert into testing (intCol) values (?)`, j)
+			require.NoError(t, err)
+		}
+	}
+
+	for i, name := range []string{"tenant1", "tenant2"} {
+		rows, err := open(name).QueryContext(ctx, `select intCol from testing`)
+		require.NoError(t, err)
+
+		count := 0
+		for rows.Next() {
+			count++
+		}
+		require.NoError(t, rows.Err())
+		require.NoError(t, rows.Close())
+
+		require.Equal(t, i+1, count)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	forEachCodec(t, testAuthentication)
+}
+
+func testAuthentication(t *testing.T, options ...driver.Option) {
+	file := "auth.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file)
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	tokens := auth.NewTokens(map[string]auth.Principal{
+		"secret": {Name: "tests"},
+	})
+
+	var principal *auth.Principal
+
+	handler := auth.Middleware(tokens)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal = auth.FromContext(r.Context())
+		server.NewHandler(s).ServeHTTP(w, r)
+	}))
+
+	svr := httptest.NewServer(handler)
+	defer svr.Close()
+
+	tests := []struct {
+		name    string
+		dsn     string
+		options []driver.Option
+		code    twirp.ErrorCode
+	}{
+		{
+			name: "no token",
+			dsn:  svr.URL,
+			code: twirp.Unauthenticated,
+		},
+		{
+			name: "dsn",
+			dsn:  svr.URL + "?token=secret",
+		},
+		{
+			name:    "option",
+			dsn:     svr.URL,
+			options: []driver.Option{driver.WithToken("secret")},
+		},
+		{
+			name: "token source",
+			dsn:  svr.URL,
+			options: []driver.Option{
+				driver.WithTokenSource(func(context.Context) (string, error) {
+					return "secret", nil
+				}),
+			},
+		},
+		{
+			name:    "dsn overrides option",
+			dsn:     svr.URL + "?token=wrong",
+			options: []driver.Option{driver.WithToken("secret")},
+			code:    twirp.Unauthenticated,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			principal = nil
+
+			connector, err := driver.NewDriver(nil, append(options, test.options...)...).OpenConnector(test.dsn)
+			require.NoError(t, err)
+
+			_, err = sql.OpenDB(connector).ExecContext(ctx, `select 1`)
+			if test.code == "" {
+				require.NoError(t, err)
+				require.Equal(t, "tests", principal.Name)
+				return
+			}
+
+			var twerr twirp.Error
+			require.True(t, errors.As(err, &twerr))
+			require.Equal(t, test.code, twerr.Code())
+		})
+	}
+}
+
+func TestPreparedStatements(t *testing.T) {
+	forEachCodec(t, testPreparedStatements)
+}
+
+func testPreparedStatements(t *testing.T, options ...driver.Option) {
+	file := "prepared.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file)
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	svr := httptest.NewServer(sqliterpc.NewDatabaseServiceServer(s))
+	defer svr.Close()
+
+	connector, err := driver.NewDriver(nil, options...).OpenConnector(svr.URL)
+	require.NoError(t, err)
+
+	db := sql.OpenDB(connector)
+
+	_, err = db.ExecContext(ctx, `create table testing (intCol INTEGER)`)
+	require.NoError(t, err)
+
+	_, err = db.PrepareContext(ctx, `select from`)
+	require.Error(t, err)
+
+	insert, err := db.PrepareContext(ctx, `insert into testing (intCol) values (?)`)
+	require.NoError(t, err)
+
+	defer insert.Close()
+
+	for i := 0; i < 10; i++ {
+		_, err = insert.ExecContext(ctx, i)
+		require.NoError(t, err)
+	}
+
+	// the number of parameters is checked before sending
+	_, err = insert.ExecContext(ctx, 1, 2)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "expected 1 arguments, got 2")
+
+	// closes all prepared statements on the server
+	_, err = db.ExecContext(ctx, `create index testing_int on testing (intCol)`)
+	require.NoError(t, err)
+
+	_, err = insert.ExecContext(ctx, 10)
+	require.NoError(t, err)
+
+	query, err := db.PrepareContext(ctx, `select intCol from testing where intCol >= ?`)
+	require.NoError(t, err)
+
+	defer query.Close()
+
+	rows, err := query.QueryContext(ctx, 5)
+	require.NoError(t, err)
+
+	count := 0
+	for rows.Next() {
+		count++
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+
+	require.Equal(t, 6, count)
+}
+
+func TestSubscribe(t *testing.T) {
+	forEachCodec(t, testSubscribe)
+}
+
+func testSubscribe(t *testing.T, options ...driver.Option) {
+	file := "subscribe.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file)
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	svr := httptest.NewServer(server.NewHandler(s))
+	defer svr.Close()
+
+	connector, err := driver.NewDriver(nil, options...).OpenConnector(svr.URL)
+	require.NoError(t, err)
+
+	db := sql.OpenDB(connector)
+
+	_, err = db.ExecContext(ctx, `create table testing (intCol INTEGER, textCol TEXT)`)
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `create table other (intCol INTEGER)`)
+	require.NoError(t, err)
+
+	sub, err := driver.Subscribe(ctx, db, driver.WithTables("testing"), driver.WithRows())
+	require.NoError(t, err)
+
+	defer sub.Close()
+
+	_, err = db.ExecContext(ctx, `insert into other (intCol) values (1)`)
+	require.NoError(t, err)
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+
+	_, err = tx.ExecContext(ctx, `insert into testing (intCol, textCol) values (1, 'one')`)
+	require.NoError(t, err)
+
+	_, err = tx.ExecContext(ctx, `insert into testing (intCol, textCol) values (2, 'two')`)
+	require.NoError(t, err)
+
+	require.NoError(t, tx.Commit())
+
+	// rolled back changes are not sent
+	tx, err = db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+
+	_, err = tx.ExecContext(ctx, `insert into testing (intCol, textCol) values (3, 'three')`)
+	require.NoError(t, err)
+
+	require.NoError(t, tx.Rollback())
+
+	_, err = db.ExecContext(ctx, `delete from testing where intCol = 1`)
+	require.NoError(t, err)
+
+	var changes []driver.Change
+	for len(changes) < 3 {
+		select {
+		case change, ok := <-sub.Changes():
+			require.True(t, ok, sub.Err())
+			changes = append(changes, change)
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for changes")
+		}
+	}
+
+	require.Equal(t, "testing", changes[0].Table)
+	require.Equal(t, driver.OperationInsert, changes[0].Operation)
+	require.Equal(t, changes[0].Sequence, changes[1].Sequence)
+	require.Equal(t, "two", changes[1].Row["textCol"])
+
+	require.Equal(t, driver.OperationDelete, changes[2].Operation)
+	require.Equal(t, changes[0].RowID, changes[2].RowID)
+	require.Nil(t, changes[2].Row)
+	require.Greater(t, changes[2].Sequence, changes[0].Sequence)
+
+	require.NoError(t, sub.Close())
+
+	for range sub.Changes() {
+	}
+
+	require.NoError(t, sub.Err())
+
+	// subscriptions end when the schema changes.
+	sub, err = driver.Subscribe(ctx, db)
+	require.NoError(t, err)
+
+	defer sub.Close()
+
+	_, err = db.ExecContext(ctx, `alter table testing add column realCol REAL`)
+	require.NoError(t, err)
+
+	for range sub.Changes() {
+	}
+
+	require.ErrorIs(t, sub.Err(), driver.ErrSchemaChanged)
+}
+
+func TestReplicas(t *testing.T) {
+	forEachCodec(t, testReplicas)
+}
+
+func testReplicas(t *testing.T, options ...driver.Option) {
+	dir := t.TempDir()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	// queries and prepares count the requests served by each server.
+	queries := make([]int64, 3)
+	prepares := make([]int64, 3)
+
+	newServer := func(i int, s *server.DatabaseServer) *httptest.Server {
+		handler := server.NewHandler(s)
+
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/Query") {
+				atomic.AddInt64(&queries[i], 1)
+			}
+			if strings.HasSuffix(r.URL.Path, "/Prepare") {
+				atomic.AddInt64(&prepares[i], 1)
+			}
+			handler.ServeHTTP(w, r)
+		}))
+	}
+
+	// replicas back up the primary
+	policies := server.Policies{
+		Default: &server.Policy{Admin: true},
+	}
+
+	primary, err := server.New(filepath.Join(dir, "primary.db"), server.WithPolicies(&policies))
+	require.NoError(t, err)
+
+	defer primary.Close()
+
+	primarySvr := newServer(0, primary)
+	defer primarySvr.Close()
+
+	var (
+		replicas    []*server.DatabaseServer
+		replicaSvrs []*httptest.Server
+	)
+
+	for i := 1; i <= 2; i++ {
+		replica, err := server.New(filepath.Join(dir, fmt.Sprintf("replica%d.db", i)), server.WithPrimary(primarySvr.URL, nil))
+		require.NoError(t, err)
+
+		defer replica.Close()
+
+		replicas = append(replicas, replica)
+
+		svr := newServer(i, replica)
+		defer svr.Close()
+
+		replicaSvrs = append(replicaSvrs, svr)
+	}
+
+	u, err := url.Parse(primarySvr.URL)
+	require.NoError(t, err)
+
+	t.Run("invalid primary", func(t *testing.T) {
+		_, err := driver.NewDriver(nil, options...).OpenConnector(replicaSvrs[0].URL + "," + replicaSvrs[1].URL + "?primary=" + u.Host)
+		require.Error(t, err)
+	})
+
+	// the primary is not first
+	dsn := replicaSvrs[0].URL + "," + primarySvr.URL + "," + replicaSvrs[1].URL + "?primary=" + u.Host
+
+	connector, err := driver.NewDriver(nil, options...).OpenConnector(dsn)
+	require.NoError(t, err)
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, `create table testing (intCol INTEGER)`)
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `insert into testing (intCol) values (1)`)
+	require.NoError(t, err)
+
+	sum := func(db *sql.DB) int64 {
+		rows, err := db.QueryContext(ctx, `select intCol from testing`)
+		if err != nil {
+			return -1
+		}
+
+		defer rows.Close()
+
+		var sum int64
+		for rows.Next() {
+			var val int64
+			if err := rows.Scan(&val); err != nil {
+				return -1
+			}
+			sum += val
+		}
+
+		if rows.Err() != nil {
+			return -1
+		}
+
+		return sum
+	}
+
+	// replicated returns true once every replica has copied the rows, as
+	// reads may be sent to any of them.
+	replicated := func(want int64) bool {
+		for _, replica := range replicas {
+			resp, err := replica.Query(ctx, &sqliterpc.QueryRequest{Sql: `select sum(intCol) from testing`})
+			if err != nil || len(resp.Rows) != 1 || resp.Rows[0].Values[0].GetIntegerValue().GetValue() != want {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	require.Eventually(t, func() bool {
+		return replicated(1)
+	}, time.Second*5, time.Millisecond*10)
+
+	// reads are spread over the replicas
+	for i := 0; i < 10; i++ {
+		require.Equal(t, int64(1), sum(db))
+	}
+
+	require.Greater(t, atomic.LoadInt64(&queries[1]), int64(1))
+	require.Greater(t, atomic.LoadInt64(&queries[2]), int64(1))
+	require.Zero(t, atomic.LoadInt64(&queries[0]))
+
+	// a query that writes is sent to the primary
+	var inserted int64
+	require.NoError(t, db.QueryRowContext(ctx, `insert into testing (intCol) values (2) returning intCol`).Scan(&inserted))
+	require.Equal(t, int64(2), inserted)
+	require.Equal(t, int64(1), atomic.LoadInt64(&queries[0]))
+
+	require.Eventually(t, func() bool {
+		return replicated(3)
+	}, time.Second*5, time.Millisecond*10)
+
+	t.Run("prepared statements", func(t *testing.T) {
+		connector, err := driver.NewDriver(nil, options...).OpenConnector(dsn + "&read_your_writes=1m")
+		require.NoError(t, err)
+
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		before := atomic.LoadInt64(&prepares[0])
+
+		insert, err := db.PrepareContext(ctx, `insert into testing (intCol) values (?)`)
+		require.NoError(t, err)
+
+		defer insert.Close()
+
+		query, err := db.PrepareContext(ctx, `select intCol from testing`)
+		require.NoError(t, err)
+
+		defer query.Close()
+
+		// statements are prepared on the primary, as they may write
+		require.Equal(t, before+2, atomic.LoadInt64(&prepares[0]))
+
+		// preparing is not a write, so queries still use the replicas
+		queried := atomic.LoadInt64(&queries[0])
+
+		rows, err := query.QueryContext(ctx)
+		require.NoError(t, err)
+		require.NoError(t, rows.Close())
+
+		require.Equal(t, queried, atomic.LoadInt64(&queries[0]))
+	})
+
+	t.Run("busy", func(t *testing.T) {
+		var busy int64
+
+		// the replica's database is always busy
+		busySvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt64(&busy, 1)
+
+			_ = twirp.WriteError(w, twirp.NewError(twirp.Unavailable, "database is locked").
+				WithMeta(sqliterpc.ErrorMetaCode, "5"))
+		}))
+		defer busySvr.Close()
+
+		connector, err := driver.NewDriver(nil, options...).OpenConnector(busySvr.URL + "," + primarySvr.URL + "?primary=" + u.Host)
+		require.NoError(t, err)
+
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		// queries are retried on the primary, but a busy replica is still used
+		for i := 0; i < 3; i++ {
+			require.Equal(t, int64(3), sum(db))
+		}
+
+		require.Equal(t, int64(3), atomic.LoadInt64(&busy))
+	})
+
+	// queries are retried when a replica is unavailable, and it is not used again
+	replicaSvrs[0].CloseClientConnections()
+	replicaSvrs[0].Close()
+
+	before := atomic.LoadInt64(&queries[1])
+
+	for i := 0; i < 10; i++ {
+		require.Equal(t, int64(3), sum(db))
+	}
+
+	require.Equal(t, before, atomic.LoadInt64(&queries[1]))
+
+	t.Run("read your writes", func(t *testing.T) {
+		connector, err := driver.NewDriver(nil, options...).OpenConnector(dsn + "&read_your_writes=1m")
+		require.NoError(t, err)
+
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		before := atomic.LoadInt64(&queries[0])
+
+		_, err = db.ExecContext(ctx, `insert into testing (intCol) values (3)`)
+		require.NoError(t, err)
+
+		require.Equal(t, int64(6), sum(db))
+
+		require.Equal(t, before+1, atomic.LoadInt64(&queries[0]))
+	})
+}
+
+func TestDSNParameters(t *testing.T) {
+	m, err := server.NewManager(t.TempDir(), server.WithCreateDatabases(true))
+	require.NoError(t, err)
+
+	defer m.Close()
+
+	// gzipped counts compressed requests.
+	var gzipped int64
+
+	svr := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			atomic.AddInt64(&gzipped, 1)
+		}
+
+		if (strings.HasSuffix(r.URL.Path, "/Exec") || strings.Contains(r.URL.Path, "/stream/")) && r.Header.Get("Content-Type") != "application/json" {
+			_ = twirp.WriteError(w, twirp.NewError(twirp.InvalidArgument, "expected json"))
+			return
+		}
+
+		m.ServeHTTP(w, r)
+	}))
+	defer svr.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	// the server's certificate is self signed
+	ca := filepath.Join(t.TempDir(), "ca.pem")
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: svr.Certificate().Raw})
+	require.NoError(t, os.WriteFile(ca, certificate, 0o600))
+
+	db, err := sql.Open(driver.DriverName, svr.URL+"?db=tenant1&timeout=5s&retries=1&compression=gzip&codec=json&tls_ca="+url.QueryEscape(ca))
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, `create table testing (intCol INTEGER)`)
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `insert into testing (intCol) values (?)`, 1)
+	require.NoError(t, err)
+
+	var val int64
+	require.NoError(t, db.QueryRowContext(ctx, `select intCol from testing`).Scan(&val))
+	require.Equal(t, int64(1), val)
+
+	require.Greater(t, atomic.LoadInt64(&gzipped), int64(0))
+
+	// subscriptions use the codec too.
+	sub, err := driver.Subscribe(ctx, db)
+	require.NoError(t, err)
+	require.NoError(t, sub.Close())
+
+	// the database was selected by the parameter
+	other, err := sql.Open(driver.DriverName, svr.URL+"/db/tenant1?tls_ca="+url.QueryEscape(ca))
+	require.NoError(t, err)
+
+	defer other.Close()
+
+	require.NoError(t, other.QueryRowContext(ctx, `select intCol from testing`).Scan(&val))
+	require.Equal(t, int64(1), val)
+
+	t.Run("untrusted", func(t *testing.T) {
+		db, err := sql.Open(driver.DriverName, svr.URL+"?db=tenant1")
+		require.NoError(t, err)
+
+		defer db.Close()
+
+		require.Error(t, db.QueryRowContext(ctx, `select intCol from testing`).Scan(&val))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, params := range []string{
+			"unknown=1",
+			"timeout=soon",
+			"timeout=-1s",
+			"retries=-1",
+			"retries=1&retries=2",
+			"compression=zip",
+			"codec=xml",
+			"db=a/b",
+			"tls_ca=" + url.QueryEscape(filepath.Join(t.TempDir(), "missing.pem")),
+			"read_your_writes=soon",
+		} {
+			_, err := driver.NewDriver(nil).OpenConnector(svr.URL + "?" + params)
+			require.Error(t, err, params)
+		}
+	})
+}
+
+func TestTimeout(t *testing.T) {
+	done := make(chan struct{})
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer svr.Close()
+	defer close(done)
+
+	db, err := sql.Open(driver.DriverName, svr.URL+"?timeout=50ms")
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	start := time.Now()
+
+	_, err = db.ExecContext(context.Background(), `create table testing (intCol INTEGER)`)
+	require.Error(t, err)
+	require.Less(t, time.Since(start), time.Second*2)
+}
+
+func TestRateLimit(t *testing.T) {
+	forEachCodec(t, testRateLimit)
+}
+
+func testRateLimit(t *testing.T, options ...driver.Option) {
+	s, err := server.New(filepath.Join(t.TempDir(), "ratelimit.db"))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table testing (intCol INTEGER)`})
+	require.NoError(t, err)
+
+	// open returns a database for a server that allows rate execs and queries per second.
+	open := func(t *testing.T, rate float64, options ...driver.Option) *sql.DB {
+		limiter, err := ratelimit.New(&ratelimit.Limits{
+			Methods: map[string]ratelimit.Limit{
+				"Exec":  {Rate: rate, Burst: 1},
+				"Query": {Rate: rate, Burst: 1},
+			},
+		})
+		require.NoError(t, err)
+
+		svr := httptest.NewServer(limiter.Middleware(server.NewHandler(s)))
+		t.Cleanup(svr.Close)
+
+		connector, err := driver.NewDriver(nil, options...).OpenConnector(svr.URL)
+		require.NoError(t, err)
+
+		db := sql.OpenDB(connector)
+		t.Cleanup(func() { _ = db.Close() })
+
+		return db
+	}
+
+	insert := func(db *sql.DB, ctx context.Context) error {
+		_, err := db.ExecContext(ctx, `insert into testing (intCol) values (1)`)
+		return err
+	}
+
+	t.Run("retried", func(t *testing.T) {
+		db := open(t, 10, options...)
+
+		start := time.Now()
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, insert(db, ctx))
+		}
+
+		// waited for the server rather than retrying immediately.
+		require.GreaterOrEqual(t, time.Since(start), time.Millisecond*150)
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		db := open(t, 10, append(options, driver.WithStreamingQueries())...)
+
+		for i := 0; i < 3; i++ {
+			var count int64
+			require.NoError(t, db.QueryRowContext(ctx, `select count(*) from testing`).Scan(&count))
+		}
+	})
+
+	t.Run("no retries", func(t *testing.T) {
+		db := open(t, 10, append(options, driver.WithRetries(0))...)
+
+		require.NoError(t, insert(db, ctx))
+
+		var twerr twirp.Error
+		require.True(t, errors.As(insert(db, ctx), &twerr))
+		require.Equal(t, twirp.ResourceExhausted, twerr.Code())
+	})
+
+	t.Run("after deadline", func(t *testing.T) {
+		db := open(t, 0.1, options...)
+
+		require.NoError(t, insert(db, ctx))
+
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		// the hint is after the deadline, so the error is returned without waiting.
+		var twerr twirp.Error
+		require.True(t, errors.As(insert(db, ctx), &twerr))
+		require.Equal(t, twirp.ResourceExhausted, twerr.Code())
+		require.NoError(t, ctx.Err())
+	})
+}
+
+// forEachCodec runs test with each codec.
+func forEachCodec(t *testing.T, test func(*testing.T, ...driver.Option)) {
+	for _, codec := range []driver.Codec{driver.CodecProtobuf, driver.CodecJSON} {
+		codec := codec
+
+		t.Run(string(codec), func(t *testing.T) {
+			test(t, driver.WithCodec(codec))
+		})
+	}
+}
+
+func TestValuesJSON(t *testing.T) {
+	values := []*sqliterpc.Value{
+		{Kind: &sqliterpc.Value_IntegerValue{IntegerValue: &sqliterpc.IntergerValue{Value: math.MaxInt64, Valid: true}}},
+		{Kind: &sqliterpc.Value_IntegerValue{IntegerValue: &sqliterpc.IntergerValue{Value: math.MinInt64, Valid: true}}},
+		{Kind: &sqliterpc.Value_IntegerValue{IntegerValue: &sqliterpc.IntergerValue{}}},
+		{Kind: &sqliterpc.Value_TextValue{TextValue: &sqliterpc.TextValue{Value: "héllo, 世界 \x00", Valid: true}}},
+		{Kind: &sqliterpc.Value_TextValue{TextValue: &sqliterpc.TextValue{}}},
+		{Kind: &sqliterpc.Value_BlobValue{BlobValue: &sqliterpc.BlobValue{Value: []byte{0, 1, 0x7f, 0x80, 0xff}, Valid: true}}},
+		{Kind: &sqliterpc.Value_BlobValue{BlobValue: &sqliterpc.BlobValue{}}},
+		{Kind: &sqliterpc.Value_RealValue{RealValue: &sqliterpc.RealValue{Value: math.SmallestNonzeroFloat64, Valid: true}}},
+		{Kind: &sqliterpc.Value_RealValue{RealValue: &sqliterpc.RealValue{Value: math.Inf(1), Valid: true}}},
+		{Kind: &sqliterpc.Value_RealValue{RealValue: &sqliterpc.RealValue{Value: math.Inf(-1), Valid: true}}},
+		{Kind: &sqliterpc.Value_NumericValue{NumericValue: &sqliterpc.NumericValue{Value: -1.5, Valid: true}}},
+		{Kind: &sqliterpc.Value_BoolValue{BoolValue: &sqliterpc.BoolValue{Value: true, Valid: true}}},
+		{Kind: &sqliterpc.Value_BoolValue{BoolValue: &sqliterpc.BoolValue{}}},
+		{Kind: &sqliterpc.Value_TimeValue{TimeValue: &sqliterpc.TimeValue{Value: timestamppb.New(time.Date(2022, 6, 1, 12, 30, 15, 123456789, time.UTC)), Valid: true}}},
+		{Kind: &sqliterpc.Value_TimeValue{TimeValue: &sqliterpc.TimeValue{Value: timestamppb.New(time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)), Valid: true}}},
+		{Kind: &sqliterpc.Value_TimeValue{TimeValue: &sqliterpc.TimeValue{}}},
+		{Kind: &sqliterpc.Value_NullValue{NullValue: &sqliterpc.NullValue{}}},
+		{Kind: &sqliterpc.Value_IntegerValue{IntegerValue: &sqliterpc.IntergerValue{Value: 1, Valid: true}}, Name: ":named"},
+	}
+
+	// the options used by the twirp json client and server
+	for _, marshal := range []protojson.MarshalOptions{
+		{UseProtoNames: true},
+		{UseProtoNames: true, EmitUnpopulated: true},
+	} {
+		for _, value := range values {
+			data, err := marshal.Marshal(value)
+			require.NoError(t, err)
+
+			var decoded sqliterpc.Value
+			require.NoError(t, protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, &decoded))
+			require.True(t, proto.Equal(value, &decoded), "%s", data)
+		}
+	}
+
+	// int64 is encoded as a string, so is not rounded by javascript clients.
+	data, err := protojson.Marshal(values[0])
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"9223372036854775807"`)
+
+	t.Run("NaN", func(t *testing.T) {
+		value := sqliterpc.Value{Kind: &sqliterpc.Value_RealValue{RealValue: &sqliterpc.RealValue{Value: math.NaN(), Valid: true}}}
+
+		data, err := protojson.Marshal(&value)
+		require.NoError(t, err)
+
+		var decoded sqliterpc.Value
+		require.NoError(t, protojson.Unmarshal(data, &decoded))
+		require.True(t, math.IsNaN(decoded.GetRealValue().GetValue()))
+	})
+
+	t.Run("time out of range", func(t *testing.T) {
+		db, err := sql.Open(driver.DriverName, "http://127.0.0.1:1?retries=0")
+		require.NoError(t, err)
+
+		defer db.Close()
+
+		_, err = db.Exec(`select ?`, time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC))
+		require.ErrorContains(t, err, "invalid time")
+	})
+}
+
+func TestRoundTrip(t *testing.T) {
+	forEachCodec(t, testRoundTrip)
+}
+
+func testRoundTrip(t *testing.T, options ...driver.Option) {
+	s, err := server.New(filepath.Join(t.TempDir(), "roundtrip.db"))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	svr := httptest.NewServer(server.NewHandler(s))
+	defer svr.Close()
+
+	_, err = s.Exec(
+		ctx,
+		&sqliterpc.ExecRequest{
+			Sql: `create table testing (
+				id INTEGER PRIMARY KEY,
+				intCol INTEGER,
+				textCol TEXT,
+				blobCol BLOB,
+				realCol REAL,
+				numericCol NUMERIC,
+				boolCol BOOLEAN,
+				timeCol TIMESTAMP
+			)`,
+		},
+	)
+	require.NoError(t, err)
+
+	zone := time.FixedZone("IST", 5*60*60+30*60)
+	now := time.Date(2022, time.March, 4, 5, 6, 7, 123456789, zone)
+
+	tests := []struct {
+		name   string
+		column string
+		value  interface{}
+		// expected is the value returned by the driver.
+		expected interface{}
+	}{
+		{name: "integer", column: "intCol", value: int64(42), expected: int64(42)},
+		{name: "max integer", column: "intCol", value: int64(math.MaxInt64), expected: int64(math.MaxInt64)},
+		{name: "min integer", column: "intCol", value: int64(math.MinInt64), expected: int64(math.MinInt64)},
+		{name: "int", column: "intCol", value: 7, expected: int64(7)},
+		{name: "null integer", column: "intCol", value: nil, expected: nil},
+		{name: "text", column: "textCol", value: "hello", expected: "hello"},
+		{name: "empty text", column: "textCol", value: "", expected: ""},
+		{name: "unicode text", column: "textCol", value: "héllo, 世界 🎉", expected: "héllo, 世界 🎉"},
+		{name: "null text", column: "textCol", value: nil, expected: nil},
+		{name: "blob", column: "blobCol", value: []byte{0, 1, 255}, expected: []byte{0, 1, 255}},
+		{name: "empty blob", column: "blobCol", value: []byte{}, expected: []byte{}},
+		{name: "null blob", column: "blobCol", value: nil, expected: nil},
+		{name: "real", column: "realCol", value: 1.5, expected: 1.5},
+		{name: "float32", column: "realCol", value: float32(0.25), expected: 0.25},
+		{name: "positive infinity", column: "realCol", value: math.Inf(1), expected: math.Inf(1)},
+		{name: "negative infinity", column: "realCol", value: math.Inf(-1), expected: math.Inf(-1)},
+		// sqlite stores NaN as NULL
+		{name: "nan", column: "realCol", value: math.NaN(), expected: nil},
+		{name: "null real", column: "realCol", value: nil, expected: nil},
+		{name: "numeric", column: "numericCol", value: 10.25, expected: 10.25},
+		{name: "numeric integer", column: "numericCol", value: int64(10), expected: float64(10)},
+		{name: "null numeric", column: "numericCol", value: nil, expected: nil},
+		{name: "true", column: "boolCol", value: true, expected: true},
+		{name: "false", column: "boolCol", value: false, expected: false},
+		{name: "null bool", column: "boolCol", value: nil, expected: nil},
+		// times are returned in UTC
+		{name: "time", column: "timeCol", value: now, expected: now.UTC()},
+		{name: "utc time", column: "timeCol", value: now.UTC(), expected: now.UTC()},
+		{name: "null time", column: "timeCol", value: nil, expected: nil},
+	}
+
+	for _, streaming := range []bool{false, true} {
+		opts := options
+		if streaming {
+			opts = append(opts, driver.WithStreamingQueries())
+		}
+
+		connector, err := driver.NewDriver(nil, opts...).OpenConnector(svr.URL)
+		require.NoError(t, err)
+
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		for _, test := range tests {
+			test := test
+
+			t.Run(fmt.Sprintf("%s/streaming=%t", test.name, streaming), func(t *testing.T) {
+				result, err := db.ExecContext(ctx, `insert into testing (`+test.column+`) values (?)`, test.value)
+				require.NoError(t, err)
+
+				id, err := result.LastInsertId()
+				require.NoError(t, err)
+
+				var actual interface{}
+				err = db.QueryRowContext(ctx, `select `+test.column+` from testing where id = ?`, id).Scan(&actual)
+				require.NoError(t, err)
+
+				require.IsType(t, test.expected, actual)
+
+				if expected, ok := test.expected.(time.Time); ok {
+					require.True(t, expected.Equal(actual.(time.Time)), "expected %s, got %s", expected, actual)
+					require.Equal(t, time.UTC, actual.(time.Time).Location())
+					return
+				}
+
+				require.Equal(t, test.expected, actual)
+			})
+		}
+	}
+}
+
+func TestDynamicColumns(t *testing.T) {
+	forEachCodec(t, testDynamicColumns)
+}
+
+func testDynamicColumns(t *testing.T, options ...driver.Option) {
+	s, err := server.New(filepath.Join(t.TempDir(), "dynamic.db"))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	svr := httptest.NewServer(server.NewHandler(s))
+	defer svr.Close()
+
+	connector, err := driver.NewDriver(nil, options...).OpenConnector(svr.URL)
+	require.NoError(t, err)
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, `create table testing (id INTEGER PRIMARY KEY, untyped)`)
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `insert into testing (untyped) values (?), (?), (?), (?), (?)`, 1, 2.5, "three", []byte{}, nil)
+	require.NoError(t, err)
+
+	var count int64
+	err = db.QueryRowContext(ctx, `select count(*) from testing`).Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), count)
+
+	var (
+		sum   float64
+		label string
+	)
+	err = db.QueryRowContext(ctx, `select sum(untyped), 'total: ' || count(untyped) from testing`).Scan(&sum, &label)
+	require.NoError(t, err)
+	require.Equal(t, 3.5, sum)
+	require.Equal(t, "total: 4", label)
+
+	rows, err := db.QueryContext(ctx, `select untyped from testing order by id`)
+	require.NoError(t, err)
+
+	var values []interface{}
+	for rows.Next() {
+		var v interface{}
+		require.NoError(t, rows.Scan(&v))
+		values = append(values, v)
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+
+	require.Equal(t, []interface{}{int64(1), 2.5, "three", []byte{}, nil}, values)
+}
+
+func TestExecScript(t *testing.T) {
+	forEachCodec(t, testExecScript)
+}
+
+func testExecScript(t *testing.T, options ...driver.Option) {
+	s, err := server.New(filepath.Join(t.TempDir(), "script.db"))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	svr := httptest.NewServer(server.NewHandler(s))
+	defer svr.Close()
+
+	connector, err := driver.NewDriver(nil, options...).OpenConnector(svr.URL)
+	require.NoError(t, err)
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	name, err := driver.Parameters(sql.Named("name", "one"))
+	require.NoError(t, err)
+
+	resp, err := driver.ExecScript(ctx, conn, &sqliterpc.ExecScriptRequest{
+		Sql: `create table testing (id INTEGER PRIMARY KEY, name TEXT UNIQUE);
+			insert into testing (name) values (:name);`,
+		Parameters: name,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+	require.Equal(t, int64(1), resp.Results[1].RowsAffected)
+
+	_, err = driver.ExecScript(ctx, conn, &sqliterpc.ExecScriptRequest{
+		Sql:         `insert into testing (name) values ('two'); insert into testing (name) values ('one')`,
+		Transaction: true,
+	})
+
+	var scriptErr *driver.ScriptError
+	require.ErrorAs(t, err, &scriptErr)
+	require.Equal(t, 1, scriptErr.Statement)
+
+	var sqliteErr *driver.SQLiteError
+	require.ErrorAs(t, err, &sqliteErr)
+	require.Equal(t, int(sqlite3.ErrConstraint), sqliteErr.Code)
+
+	count := func() int {
+		var count int
+		err := db.QueryRowContext(ctx, `select count(*) from testing`).Scan(&count)
+		require.NoError(t, err)
+		return count
+	}
+
+	require.Equal(t, 1, count())
+
+	// scripts run in the transaction of the connection
+	tx, err := conn.BeginTx(ctx, nil)
+	require.NoError(t, err)
+
+	_, err = driver.ExecScript(ctx, conn, &sqliterpc.ExecScriptRequest{
+		Sql: `insert into testing (name) values ('two'); insert into testing (name) values ('three')`,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, tx.Rollback())
+	require.Equal(t, 1, count())
+}
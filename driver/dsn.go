@@ -0,0 +1,361 @@
+package driver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+)
+
+// DriverName is the name the driver is registered with database/sql.
+const DriverName = "sqliterpc"
+
+func init() {
+	sql.Register(DriverName, NewDriver(nil))
+}
+
+// Compression is how request and response bodies are compressed.
+type Compression string
+
+const (
+	// CompressionNone asks for responses to not be compressed.
+	CompressionNone = Compression("none")
+	// CompressionGzip compresses requests with gzip, and asks for
+	// compressed responses.
+	CompressionGzip = Compression("gzip")
+)
+
+// Codec is how requests and responses are encoded.
+type Codec string
+
+const (
+	CodecProtobuf = Codec("protobuf")
+	CodecJSON     = Codec("json")
+)
+
+const retryBackoff = 100 * time.Millisecond
+
+// WithTimeout sets the maximum duration of each request.
+// It does not apply to streaming queries or subscriptions.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(d *Driver) {
+		d.timeout = timeout
+	})
+}
+
+// WithRetries sets how many times a request is retried when it cannot connect to
+// the server, or is rejected by the server's rate limits. Rejected requests are
+// retried after the time the server asks for, unless that is after the deadline
+// of the context. The default is 2. Queries are also retried on other replicas.
+func WithRetries(retries int) Option {
+	return optionFunc(func(d *Driver) {
+		d.retries = retries
+	})
+}
+
+// WithCompression sets how bodies are compressed. By default, requests are
+// not compressed, and responses are compressed if the server supports it.
+// Servers created with server.NewHandler accept compressed requests.
+func WithCompression(compression Compression) Option {
+	return optionFunc(func(d *Driver) {
+		d.compression = compression
+	})
+}
+
+// WithCodec sets how requests and responses are encoded. The default is protobuf.
+// JSON is larger and slower, but readable when debugging through a proxy.
+// Streaming queries and subscriptions use the same codec, so need a server
+// created with server.NewHandler that accepts JSON streams.
+func WithCodec(codec Codec) Option {
+	return optionFunc(func(d *Driver) {
+		d.codec = codec
+	})
+}
+
+// WithDatabase selects a database on a server serving multiple databases.
+// It is the same as adding /db/{name} to the path of the url.
+func WithDatabase(name string) Option {
+	return optionFunc(func(d *Driver) {
+		d.database = name
+	})
+}
+
+// WithRootCAs sets the certificate authorities used to verify servers.
+// The transport must be an *http.Transport.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return optionFunc(func(d *Driver) {
+		d.rootCAs = pool
+	})
+}
+
+// dsnOptions returns the options set by query parameters.
+// see Driver.OpenConnector
+func dsnOptions(query url.Values) ([]Option, error) {
+	var options []Option
+
+	for name, values := range query {
+		if len(values) != 1 {
+			return nil, fmt.Errorf("parameter %s must be set once", name)
+		}
+
+		value := values[0]
+
+		switch name {
+		case "token":
+			options = append(options, WithToken(value))
+		case "timeout":
+			timeout, err := parseDuration(name, value)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, WithTimeout(timeout))
+		case "retries":
+			retries, err := strconv.Atoi(value)
+			if err != nil || retries < 0 {
+				return nil, fmt.Errorf("invalid %s %q: must be a number that is not negative", name, value)
+			}
+			options = append(options, WithRetries(retries))
+		case "compression":
+			compression := Compression(strings.ToLower(value))
+			if compression != CompressionGzip && compression != CompressionNone {
+				return nil, fmt.Errorf("invalid %s %q: must be one of gzip, none", name, value)
+			}
+			options = append(options, WithCompression(compression))
+		case "codec":
+			codec := Codec(strings.ToLower(value))
+			if codec != CodecProtobuf && codec != CodecJSON {
+				return nil, fmt.Errorf("invalid %s %q: must be one of protobuf, json", name, value)
+			}
+			options = append(options, WithCodec(codec))
+		case "db":
+			if value == "" || strings.Contains(value, "/") {
+				return nil, fmt.Errorf("invalid %s %q", name, value)
+			}
+			options = append(options, WithDatabase(value))
+		case "tls_ca":
+			pem, err := os.ReadFile(value)
+			if err != nil {
+				return nil, err
+			}
+
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, errors.New("no certificates found in " + value)
+			}
+			options = append(options, WithRootCAs(pool))
+		case "read_your_writes":
+			duration, err := parseDuration(name, value)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, WithReadYourWrites(duration))
+		default:
+			return nil, fmt.Errorf("unknown parameter %s", name)
+		}
+	}
+
+	return options, nil
+}
+
+func parseDuration(name string, value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a duration that is not negative, such as 5s", name, value)
+	}
+
+	return d, nil
+}
+
+// httpClient returns the client for requests to servers.
+func (d *Driver) httpClient() (*http.Client, error) {
+	transport := d.transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if d.rootCAs != nil {
+		t, ok := transport.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("root CAs require an *http.Transport, not %T", transport)
+		}
+
+		t = t.Clone()
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+		t.TLSClientConfig.RootCAs = d.rootCAs
+
+		transport = t
+	}
+
+	if d.compression != "" {
+		transport = &compressionTransport{
+			next:        transport,
+			compression: d.compression,
+		}
+	}
+
+	if d.tokenSource != nil {
+		transport = &bearerTransport{
+			next:   transport,
+			source: d.tokenSource,
+		}
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// compressionTransport compresses requests and sets the encoding accepted for responses.
+type compressionTransport struct {
+	next        http.RoundTripper
+	compression Compression
+}
+
+func (t *compressionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// a RoundTripper must not modify the request
+	req = req.Clone(req.Context())
+
+	if t.compression == CompressionNone {
+		req.Header.Set("Accept-Encoding", "identity")
+		return t.next.RoundTrip(req)
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return t.next.RoundTrip(req)
+	}
+
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+
+	_, err := io.Copy(gz, req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	data := buf.Bytes()
+
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	req.ContentLength = int64(len(data))
+	req.Header.Set("Content-Encoding", "gzip")
+
+	return t.next.RoundTrip(req)
+}
+
+// timeoutInterceptor limits the duration of each call.
+func timeoutInterceptor(timeout time.Duration) twirp.Interceptor {
+	return func(next twirp.Method) twirp.Method {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			return next(ctx, req)
+		}
+	}
+}
+
+// retryInterceptor retries calls that could not connect to the server or were rate limited.
+func retryInterceptor(retries int) twirp.Interceptor {
+	return func(next twirp.Method) twirp.Method {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			for attempt := 0; ; attempt++ {
+				resp, err := next(ctx, req)
+				if err == nil || attempt >= retries {
+					return resp, err
+				}
+
+				wait, ok := retryDelay(ctx, err, attempt)
+				if !ok {
+					return resp, err
+				}
+
+				if err := sleep(ctx, wait); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+}
+
+// retryDelay returns how long to wait before retrying a request that failed with err,
+// or false if it should not be retried.
+func retryDelay(ctx context.Context, err error, attempt int) (time.Duration, bool) {
+	if notSent(err) {
+		return retryBackoff << attempt, true
+	}
+
+	wait, ok := retryAfter(err)
+	if !ok {
+		return 0, false
+	}
+
+	// fail now rather than after waiting for a retry that cannot be made.
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+		return 0, false
+	}
+
+	return wait, true
+}
+
+// retryAfter returns how long the server asked to wait before retrying a
+// request it rejected because of a rate limit.
+func retryAfter(err error) (time.Duration, bool) {
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) || twerr.Code() != twirp.ResourceExhausted {
+		return 0, false
+	}
+
+	ms, cerr := strconv.ParseInt(twerr.Meta(sqliterpc.ErrorMetaRetryAfter), 10, 64)
+	if cerr != nil || ms < 0 {
+		return 0, false
+	}
+
+	return time.Duration(ms) * time.Millisecond, true
+}
+
+// notSent returns true if err means the request was not sent, so retrying
+// it cannot repeat a write.
+func notSent(err error) bool {
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+
+	return opErr.Op == "dial"
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,79 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+)
+
+// SQLiteError is returned when the server reports an error from sqlite.
+// see https://www.sqlite.org/rescode.html
+type SQLiteError struct {
+	// Code is the primary result code, such as 19 for SQLITE_CONSTRAINT
+	Code int
+	// ExtendedCode is the extended result code, such as 2067 for SQLITE_CONSTRAINT_UNIQUE
+	ExtendedCode int
+	Message      string
+	err          twirp.Error
+}
+
+func (e *SQLiteError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying twirp.Error
+func (e *SQLiteError) Unwrap() error {
+	return e.err
+}
+
+// convertError returns a *SQLiteError if err is a twirp error for a sqlite error.
+// Otherwise, err is returned unchanged.
+func convertError(err error) error {
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) {
+		return err
+	}
+
+	code, cerr := strconv.Atoi(twerr.Meta(sqliterpc.ErrorMetaCode))
+	if cerr != nil {
+		return err
+	}
+
+	// should always be set with the code, but be lenient
+	extended, _ := strconv.Atoi(twerr.Meta(sqliterpc.ErrorMetaExtendedCode))
+
+	e := SQLiteError{
+		Code:         code,
+		ExtendedCode: extended,
+		Message:      twerr.Msg(),
+		err:          twerr,
+	}
+
+	return &e
+}
+
+// errorInterceptor converts errors returned by the twirp client.
+func errorInterceptor(next twirp.Method) twirp.Method {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		resp, err := next(ctx, req)
+		if err != nil {
+			return resp, convertError(err)
+		}
+
+		return resp, nil
+	}
+}
+
+// streamError returns the error for an error frame.
+func streamError(e *sqliterpc.StreamError) error {
+	twerr := twirp.NewError(twirp.ErrorCode(e.Code), e.Message)
+	for k, v := range e.Meta {
+		twerr = twerr.WithMeta(k, v)
+	}
+
+	return convertError(twerr)
+}
@@ -0,0 +1,125 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bakins/sqliterpc"
+)
+
+// ScriptError is returned when a statement of a script fails.
+type ScriptError struct {
+	// Statement is the index of the statement that failed, counting from zero.
+	// Statements before it have run.
+	Statement int
+	err       error
+}
+
+func (e *ScriptError) Error() string {
+	return "statement " + strconv.Itoa(e.Statement) + ": " + e.err.Error()
+}
+
+// Unwrap returns the error of the statement, which may be a *SQLiteError.
+func (e *ScriptError) Unwrap() error {
+	return e.err
+}
+
+// ExecScript runs sql holding many statements separated by semicolons.
+// Parameters must be named. If conn is in a transaction, the script runs in it.
+// conn must be from a database opened with this driver.
+func ExecScript(ctx context.Context, conn *sql.Conn, req *sqliterpc.ExecScriptRequest) (*sqliterpc.ExecScriptResponse, error) {
+	var resp *sqliterpc.ExecScriptResponse
+
+	err := conn.Raw(func(driverConn interface{}) error {
+		c, ok := driverConn.(*connection)
+		if !ok {
+			return ErrNotConnection
+		}
+
+		if c.client == nil {
+			return ErrConnectionClosed
+		}
+
+		if c.transactionID != "" {
+			if req.Transaction {
+				return ErrTransactionInProgress
+			}
+
+			req = proto.Clone(req).(*sqliterpc.ExecScriptRequest)
+			req.TransactionId = c.transactionID
+		}
+
+		var err error
+		resp, err = c.client.ExecScript(ctx, req)
+		if err != nil {
+			return scriptError(err)
+		}
+
+		c.cluster.wrote()
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Query runs req on the server and returns the response as is.
+// If conn is in a transaction, the query runs in it.
+// conn must be from a database opened with this driver.
+func Query(ctx context.Context, conn *sql.Conn, req *sqliterpc.QueryRequest) (*sqliterpc.QueryResponse, error) {
+	var resp *sqliterpc.QueryResponse
+
+	err := conn.Raw(func(driverConn interface{}) error {
+		c, ok := driverConn.(*connection)
+		if !ok {
+			return ErrNotConnection
+		}
+
+		if c.client == nil {
+			return ErrConnectionClosed
+		}
+
+		if c.transactionID != "" {
+			req = proto.Clone(req).(*sqliterpc.QueryRequest)
+			req.TransactionId = c.transactionID
+		}
+
+		var err error
+		resp, err = c.client.Query(ctx, req)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// scriptError returns a *ScriptError if err has the index of the statement that failed.
+func scriptError(err error) error {
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) {
+		return err
+	}
+
+	index, cerr := strconv.Atoi(twerr.Meta(sqliterpc.ErrorMetaStatement))
+	if cerr != nil {
+		return err
+	}
+
+	e := ScriptError{
+		Statement: index,
+		err:       err,
+	}
+
+	return &e
+}
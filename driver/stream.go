@@ -0,0 +1,205 @@
+package driver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bakins/sqliterpc"
+)
+
+// postStream sends req to the streaming endpoint at path, encoded with the
+// codec of e. On success, the caller must close the response body.
+func (c *connection) postStream(ctx context.Context, e *endpoint, path string, req proto.Message) (*http.Response, error) {
+	var body []byte
+	var err error
+
+	contentType := sqliterpc.ContentTypeProtobuf
+
+	if e.codec == CodecJSON {
+		contentType = sqliterpc.ContentTypeJSON
+		body, err = protojson.Marshal(req)
+	} else {
+		body, err = proto.Marshal(req)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(e.url, "/")+path, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+
+		httpReq.Header.Set("Content-Type", contentType)
+
+		resp, err := e.httpClient.Do(httpReq)
+		if err == nil && resp.StatusCode != http.StatusOK {
+			err = errorFromResponse(resp)
+			_ = resp.Body.Close()
+		}
+
+		if err == nil {
+			return resp, nil
+		}
+
+		if attempt >= e.retries {
+			return nil, convertError(err)
+		}
+
+		wait, ok := retryDelay(ctx, err, attempt)
+		if !ok {
+			return nil, convertError(err)
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *connection) queryStream(ctx context.Context, e *endpoint, req *sqliterpc.QueryRequest) (driver.Rows, error) {
+	// cancelled when rows are closed, which stops the query on the server.
+	ctx, cancel := context.WithCancel(ctx)
+
+	resp, err := c.postStream(ctx, e, sqliterpc.QueryStreamPath, &sqliterpc.QueryStreamRequest{Query: req})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	s := newQueryStream(resp, e, cancel)
+
+	frame, err := s.read()
+	if err != nil {
+		_ = s.close()
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	header := frame.GetColumns()
+	if header == nil {
+		_ = s.close()
+		return nil, fmt.Errorf("unexpected first frame %T", frame.Frame)
+	}
+
+	r := rows{
+		columns: header.Columns,
+		more:    s.next,
+		release: s.close,
+	}
+
+	return &r, nil
+}
+
+type queryStream struct {
+	body   io.ReadCloser
+	cancel context.CancelFunc
+	// json reads the body when the stream is encoded as JSON.
+	json *bufio.Reader
+}
+
+// newQueryStream returns a stream reading the body of resp, which was
+// requested from e.
+func newQueryStream(resp *http.Response, e *endpoint, cancel context.CancelFunc) queryStream {
+	s := queryStream{
+		body:   resp.Body,
+		cancel: cancel,
+	}
+
+	if e.codec == CodecJSON {
+		s.json = bufio.NewReader(resp.Body)
+	}
+
+	return s
+}
+
+// readMessage reads the next message of the stream into m.
+func (s *queryStream) readMessage(m proto.Message) error {
+	if s.json != nil {
+		return sqliterpc.ReadJSONMessage(s.json, m)
+	}
+
+	return sqliterpc.ReadMessage(s.body, m)
+}
+
+// read returns the next frame. io.EOF is returned after the done frame.
+func (s *queryStream) read() (*sqliterpc.QueryStreamFrame, error) {
+	var frame sqliterpc.QueryStreamFrame
+
+	if err := s.readMessage(&frame); err != nil {
+		if err == io.EOF {
+			// the stream should always end with a done or error frame
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	if frame.GetDone() != nil {
+		return nil, io.EOF
+	}
+
+	if e := frame.GetError(); e != nil {
+		return nil, streamError(e)
+	}
+
+	return &frame, nil
+}
+
+func (s *queryStream) next() ([]*sqliterpc.ListValue, error) {
+	frame, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	batch := frame.GetRows()
+	if batch == nil {
+		return nil, fmt.Errorf("unexpected frame %T", frame.Frame)
+	}
+
+	return batch.Rows, nil
+}
+
+func (s *queryStream) close() error {
+	s.cancel()
+	return s.body.Close()
+}
+
+// errorFromResponse decodes a twirp error from a non-200 response.
+func errorFromResponse(resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return twirp.InternalErrorWith(err)
+	}
+
+	var e struct {
+		Code string            `json:"code"`
+		Msg  string            `json:"msg"`
+		Meta map[string]string `json:"meta"`
+	}
+
+	if err := json.Unmarshal(body, &e); err != nil || !twirp.IsValidErrorCode(twirp.ErrorCode(e.Code)) {
+		return twirp.InternalErrorf("unexpected HTTP status code %d", resp.StatusCode).WithMeta("body", string(body))
+	}
+
+	twerr := twirp.NewError(twirp.ErrorCode(e.Code), e.Msg)
+	for k, v := range e.Meta {
+		twerr = twerr.WithMeta(k, v)
+	}
+
+	return twerr
+}
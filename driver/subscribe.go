@@ -0,0 +1,267 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/bakins/sqliterpc"
+)
+
+// Operation is how a row was changed.
+type Operation int
+
+const (
+	OperationInsert Operation = iota + 1
+	OperationUpdate
+	OperationDelete
+)
+
+func (o Operation) String() string {
+	switch o {
+	case OperationInsert:
+		return "INSERT"
+	case OperationUpdate:
+		return "UPDATE"
+	case OperationDelete:
+		return "DELETE"
+	default:
+		return fmt.Sprintf("Operation(%d)", int(o))
+	}
+}
+
+var operations = map[sqliterpc.ChangeOperation]Operation{
+	sqliterpc.ChangeOperation_CHANGE_OPERATION_INSERT: OperationInsert,
+	sqliterpc.ChangeOperation_CHANGE_OPERATION_UPDATE: OperationUpdate,
+	sqliterpc.ChangeOperation_CHANGE_OPERATION_DELETE: OperationDelete,
+}
+
+// Change is a committed change to a single row.
+type Change struct {
+	// Sequence identifies the transaction that made the change.
+	// Changes made by the same transaction have the same sequence.
+	Sequence  uint64
+	Table     string
+	Operation Operation
+	RowID     int64
+	// Row is the row keyed by column when WithRows is used, as it was when
+	// the change was made. It is nil for deletes.
+	Row map[string]interface{}
+}
+
+type SubscribeOption interface {
+	apply(*sqliterpc.SubscribeRequest)
+}
+
+type subscribeOptionFunc func(*sqliterpc.SubscribeRequest)
+
+func (f subscribeOptionFunc) apply(r *sqliterpc.SubscribeRequest) {
+	f(r)
+}
+
+// WithTables only includes changes to tables.
+func WithTables(tables ...string) SubscribeOption {
+	return subscribeOptionFunc(func(r *sqliterpc.SubscribeRequest) {
+		r.Tables = append(r.Tables, tables...)
+	})
+}
+
+// WithRows sets the row of each change.
+func WithRows() SubscribeOption {
+	return subscribeOptionFunc(func(r *sqliterpc.SubscribeRequest) {
+		r.IncludeRows = true
+	})
+}
+
+// ErrSchemaChanged ends a subscription when the schema may have changed, or
+// changes may be missing. Read the tables again and subscribe again to continue.
+var ErrSchemaChanged = errors.New("schema may have changed")
+
+// Subscription receives changes committed on the server.
+type Subscription struct {
+	changes chan Change
+	stream  *subscribeStream
+	err     error
+	once    sync.Once
+}
+
+// Subscribe streams changes committed on the server db is connected to.
+// Changes committed after Subscribe returns are sent to the subscription
+// until it is closed, ctx is done, or an error occurs. A subscriber that
+// falls too far behind is disconnected by the server.
+func Subscribe(ctx context.Context, db *sql.DB, options ...SubscribeOption) (*Subscription, error) {
+	var req sqliterpc.SubscribeRequest
+
+	for _, o := range options {
+		o.apply(&req)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	var c *connection
+
+	err = conn.Raw(func(dc interface{}) error {
+		var ok bool
+		if c, ok = dc.(*connection); !ok {
+			return errors.New("not a sqliterpc connection")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// cancelled when the subscription is closed.
+	ctx, cancel := context.WithCancel(ctx)
+
+	resp, err := c.postStream(ctx, c.cluster.primary, sqliterpc.SubscribePath, &req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	stream := subscribeStream{
+		queryStream: newQueryStream(resp, c.cluster.primary, cancel),
+	}
+
+	frame, err := stream.read()
+	if err != nil {
+		_ = stream.close()
+		return nil, err
+	}
+
+	if frame.GetStarted() == nil {
+		_ = stream.close()
+		return nil, fmt.Errorf("unexpected first frame %T", frame.Frame)
+	}
+
+	s := Subscription{
+		changes: make(chan Change),
+		stream:  &stream,
+	}
+
+	go s.run(ctx)
+
+	return &s, nil
+}
+
+// Changes returns the channel of changes. It is closed when the
+// subscription ends, after which Err returns the reason. The changes of a
+// transaction that may have changed the schema are not sent, and the
+// subscription ends with ErrSchemaChanged.
+func (s *Subscription) Changes() <-chan Change {
+	return s.changes
+}
+
+// Err returns the error that ended the subscription, or nil if it was closed.
+// It should only be called after the channel returned by Changes is closed.
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+// Close ends the subscription.
+func (s *Subscription) Close() error {
+	s.once.Do(func() {
+		_ = s.stream.close()
+	})
+
+	return nil
+}
+
+func (s *Subscription) run(ctx context.Context) {
+	defer close(s.changes)
+	defer s.Close()
+
+	for {
+		frame, err := s.stream.read()
+		if err != nil {
+			if ctx.Err() == nil {
+				s.err = err
+			}
+			return
+		}
+
+		set := frame.GetChanges()
+		if set == nil {
+			s.err = fmt.Errorf("unexpected frame %T", frame.Frame)
+			return
+		}
+
+		if set.SchemaChanged {
+			s.err = ErrSchemaChanged
+			return
+		}
+
+		for _, c := range set.Changes {
+			change, err := newChange(set.Sequence, c)
+			if err != nil {
+				s.err = err
+				return
+			}
+
+			select {
+			case s.changes <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func newChange(sequence uint64, c *sqliterpc.Change) (Change, error) {
+	change := Change{
+		Sequence:  sequence,
+		Table:     c.Table,
+		Operation: operations[c.Operation],
+		RowID:     c.Rowid,
+	}
+
+	if c.Row == nil {
+		return change, nil
+	}
+
+	r := rows{
+		columns: c.Columns,
+		rows:    []*sqliterpc.ListValue{c.Row},
+	}
+
+	values := make([]driver.Value, len(c.Columns))
+	if err := r.Next(values); err != nil {
+		return change, err
+	}
+
+	change.Row = make(map[string]interface{}, len(values))
+	for i, column := range c.Columns {
+		change.Row[column.Name] = values[i]
+	}
+
+	return change, nil
+}
+
+type subscribeStream struct {
+	queryStream
+}
+
+// read returns the next frame. The stream does not end with a done frame,
+// so io.EOF is returned if the server closes it without an error.
+func (s *subscribeStream) read() (*sqliterpc.SubscribeFrame, error) {
+	var frame sqliterpc.SubscribeFrame
+
+	if err := s.readMessage(&frame); err != nil {
+		return nil, err
+	}
+
+	if e := frame.GetError(); e != nil {
+		return nil, streamError(e)
+	}
+
+	return &frame, nil
+}
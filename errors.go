@@ -0,0 +1,20 @@
+package sqliterpc
+
+// Twirp error meta keys set by the server for sqlite errors.
+// see https://www.sqlite.org/rescode.html
+const (
+	ErrorMetaCode         = "sqlite_code"
+	ErrorMetaExtendedCode = "sqlite_extended_code"
+)
+
+// ErrorMetaPrimary is the Twirp error meta key set by a replica when it
+// rejects a request that writes. It is the base URL of the primary.
+const ErrorMetaPrimary = "primary"
+
+// ErrorMetaStatement is the Twirp error meta key set when a statement of a
+// script fails. It is the index of the statement, counting from zero.
+const ErrorMetaStatement = "statement"
+
+// ErrorMetaRetryAfter is the Twirp error meta key set when a request is
+// rejected by a rate limit. It is how long to wait before retrying, in milliseconds.
+const ErrorMetaRetryAfter = "retry_after_ms"
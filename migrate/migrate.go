@@ -0,0 +1,340 @@
+// Package migrate applies versioned schema migrations through the server.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/driver"
+)
+
+// Table records the applied migrations.
+const Table = "schema_migrations"
+
+// pageSize is the number of applied migrations read in each request, so
+// reading them is within the server's response limits.
+const pageSize = 100
+
+const createTable = `CREATE TABLE IF NOT EXISTS ` + Table + ` (
+	version INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	checksum TEXT NOT NULL,
+	applied_at TIMESTAMP NOT NULL
+)`
+
+// Database is where migrations are applied. *server.DatabaseServer and
+// sqliterpc.DatabaseService clients implement it. Use Conn for a connection
+// from a database opened with the driver.
+type Database interface {
+	ExecScript(ctx context.Context, req *sqliterpc.ExecScriptRequest) (*sqliterpc.ExecScriptResponse, error)
+	Query(ctx context.Context, req *sqliterpc.QueryRequest) (*sqliterpc.QueryResponse, error)
+}
+
+// Conn returns a Database for conn, which must be from a database opened with the driver.
+func Conn(conn *sql.Conn) Database {
+	return &driverConn{conn: conn}
+}
+
+type driverConn struct {
+	conn *sql.Conn
+}
+
+func (c *driverConn) ExecScript(ctx context.Context, req *sqliterpc.ExecScriptRequest) (*sqliterpc.ExecScriptResponse, error) {
+	return driver.ExecScript(ctx, c.conn, req)
+}
+
+func (c *driverConn) Query(ctx context.Context, req *sqliterpc.QueryRequest) (*sqliterpc.QueryResponse, error) {
+	return driver.Query(ctx, c.conn, req)
+}
+
+// Runner applies and reverts migrations. Each migration is run in a
+// transaction with the change to the table of applied migrations, so
+// migrations must not begin or commit transactions themselves.
+type Runner struct {
+	db         Database
+	migrations []*Migration
+}
+
+// New creates a runner for migrations, which are usually from Load.
+func New(db Database, migrations []*Migration) *Runner {
+	list := make([]*Migration, len(migrations))
+	copy(list, migrations)
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+
+	r := Runner{
+		db:         db,
+		migrations: list,
+	}
+
+	return &r
+}
+
+// Status is the state of a migration.
+type Status struct {
+	Version int64
+	Name    string
+	Applied bool
+	// AppliedAt is when the migration was applied.
+	AppliedAt time.Time
+	// Modified is set if the up sql has changed since the migration was applied.
+	Modified bool
+	// Missing is set if the migration was applied, but is not one of the runner's migrations.
+	Missing bool
+}
+
+// record is a row of the table of applied migrations.
+type record struct {
+	version   int64
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// Status returns the state of every migration, and of applied migrations
+// that are missing, in order of version. It does not create the table of
+// applied migrations.
+func (r *Runner) Status(ctx context.Context) ([]*Status, error) {
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*Status
+
+	for _, m := range r.migrations {
+		s := Status{
+			Version: m.Version,
+			Name:    m.Name,
+		}
+
+		if rec, ok := applied[m.Version]; ok {
+			s.Applied = true
+			s.AppliedAt = rec.appliedAt
+			s.Modified = rec.checksum != m.Checksum()
+
+			delete(applied, m.Version)
+		}
+
+		list = append(list, &s)
+	}
+
+	for _, rec := range applied {
+		s := Status{
+			Version:   rec.version,
+			Name:      rec.name,
+			Applied:   true,
+			AppliedAt: rec.appliedAt,
+			Missing:   true,
+		}
+
+		list = append(list, &s)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+
+	return list, nil
+}
+
+// Up applies the migrations that have not been applied, in order of version.
+// It returns the migrations that were applied, which are all that were
+// applied before an error. Runners may apply migrations concurrently, as
+// each migration is recorded before it is run, so only one runner runs it.
+func (r *Runner) Up(ctx context.Context) ([]*Migration, error) {
+	if _, err := r.db.ExecScript(ctx, &sqliterpc.ExecScriptRequest{Sql: createTable}); err != nil {
+		return nil, err
+	}
+
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*Migration
+
+	for _, m := range r.migrations {
+		rec, ok := applied[m.Version]
+		if !ok {
+			pending = append(pending, m)
+			continue
+		}
+
+		if rec.checksum != m.Checksum() {
+			return nil, fmt.Errorf("migration %d_%s has changed since it was applied", m.Version, m.Name)
+		}
+	}
+
+	var done []*Migration
+
+	for _, m := range pending {
+		// the version is the primary key, so if another runner has applied
+		// the migration since it was read, the insert fails before it is run.
+		script := "INSERT INTO " + Table + " (version, name, checksum, applied_at) VALUES (:version, :name, :checksum, :applied_at);\n" + m.Up
+
+		parameters, err := driver.Parameters(
+			sql.Named("version", m.Version),
+			sql.Named("name", m.Name),
+			sql.Named("checksum", m.Checksum()),
+			sql.Named("applied_at", time.Now().UTC()),
+		)
+		if err != nil {
+			return done, err
+		}
+
+		if err := r.run(ctx, m, script, parameters); err != nil {
+			if appliedConcurrently(err) {
+				continue
+			}
+			return done, err
+		}
+
+		done = append(done, m)
+	}
+
+	return done, nil
+}
+
+// appliedConcurrently returns true if err is from recording a migration that
+// another runner has applied.
+func appliedConcurrently(err error) bool {
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) {
+		return false
+	}
+
+	return twerr.Code() == twirp.AlreadyExists && twerr.Meta(sqliterpc.ErrorMetaStatement) == "0"
+}
+
+// Down reverts up to steps of the most recently applied migrations, in
+// reverse order of version. It returns the migrations that were reverted.
+func (r *Runner) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	if steps < 0 {
+		return nil, errors.New("steps must not be negative")
+	}
+
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]int64, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i] > versions[j]
+	})
+
+	if steps < len(versions) {
+		versions = versions[:steps]
+	}
+
+	migrations := make(map[int64]*Migration, len(r.migrations))
+	for _, m := range r.migrations {
+		migrations[m.Version] = m
+	}
+
+	var done []*Migration
+
+	for _, version := range versions {
+		m, ok := migrations[version]
+		if !ok {
+			return done, fmt.Errorf("applied migration %d_%s is missing", version, applied[version].name)
+		}
+
+		if applied[version].checksum != m.Checksum() {
+			return done, fmt.Errorf("migration %d_%s has changed since it was applied", m.Version, m.Name)
+		}
+
+		if m.Down == "" {
+			return done, fmt.Errorf("migration %d_%s has no down sql", m.Version, m.Name)
+		}
+
+		script := m.Down + "\n;\nDELETE FROM " + Table + " WHERE version = :version"
+
+		parameters, err := driver.Parameters(sql.Named("version", m.Version))
+		if err != nil {
+			return done, err
+		}
+
+		if err := r.run(ctx, m, script, parameters); err != nil {
+			return done, err
+		}
+
+		done = append(done, m)
+	}
+
+	return done, nil
+}
+
+func (r *Runner) run(ctx context.Context, m *Migration, script string, parameters []*sqliterpc.Value) error {
+	_, err := r.db.ExecScript(ctx, &sqliterpc.ExecScriptRequest{
+		Sql:         script,
+		Parameters:  parameters,
+		Transaction: true,
+	})
+	if err != nil {
+		return fmt.Errorf("migration %d_%s: %w", m.Version, m.Name, err)
+	}
+
+	return nil
+}
+
+// applied returns the applied migrations by version.
+func (r *Runner) applied(ctx context.Context) (map[int64]*record, error) {
+	resp, err := r.db.Query(ctx, &sqliterpc.QueryRequest{
+		Sql: `SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = '` + Table + `'`,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := map[int64]*record{}
+
+	if resp.Rows[0].Values[0].GetIntegerValue().GetValue() == 0 {
+		return records, nil
+	}
+
+	req := &sqliterpc.QueryRequest{
+		Sql:      `SELECT version, name, checksum, applied_at FROM ` + Table + ` ORDER BY version`,
+		PageSize: pageSize,
+	}
+
+	for {
+		resp, err = r.db.Query(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range resp.Rows {
+			rec := record{
+				version:   row.Values[0].GetIntegerValue().GetValue(),
+				name:      row.Values[1].GetTextValue().GetValue(),
+				checksum:  row.Values[2].GetTextValue().GetValue(),
+				appliedAt: row.Values[3].GetTimeValue().GetValue().AsTime(),
+			}
+
+			records[rec.version] = &rec
+		}
+
+		if resp.NextPageToken == "" {
+			return records, nil
+		}
+
+		req = &sqliterpc.QueryRequest{
+			PageToken: resp.NextPageToken,
+		}
+	}
+}
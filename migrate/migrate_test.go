@@ -0,0 +1,309 @@
+package migrate_test
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"io/fs"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/driver"
+	"github.com/bakins/sqliterpc/migrate"
+	"github.com/bakins/sqliterpc/server"
+)
+
+//go:embed testdata/*.sql
+var testdata embed.FS
+
+func TestLoad(t *testing.T) {
+	fsys, err := fs.Sub(testdata, "testdata")
+	require.NoError(t, err)
+
+	migrations, err := migrate.Load(fsys)
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+
+	for i, name := range []string{"create_users", "add_email", "create_groups"} {
+		require.Equal(t, int64(i+1), migrations[i].Version)
+		require.Equal(t, name, migrations[i].Name)
+		require.NotEmpty(t, migrations[i].Up)
+	}
+
+	require.NotEmpty(t, migrations[0].Down)
+	require.Empty(t, migrations[2].Down)
+
+	dir, err := migrate.LoadDir("testdata")
+	require.NoError(t, err)
+	require.Equal(t, migrations, dir)
+
+	tests := map[string]fstest.MapFS{
+		"invalid name":      {"init.up.sql": {Data: []byte("select 1")}},
+		"no version":        {"init_users.up.sql": {Data: []byte("select 1")}},
+		"no direction":      {"1_init.sql": {Data: []byte("select 1")}},
+		"duplicate version": {"1_one.up.sql": {Data: []byte("select 1")}, "01_two.up.sql": {Data: []byte("select 2")}},
+		"down only":         {"1_one.down.sql": {Data: []byte("select 1")}},
+	}
+
+	for name, fsys := range tests {
+		fsys := fsys
+
+		t.Run(name, func(t *testing.T) {
+			_, err := migrate.Load(fsys)
+			require.Error(t, err)
+		})
+	}
+
+	// other files are ignored
+	migrations, err = migrate.Load(fstest.MapFS{
+		"README.md":    {Data: []byte("migrations")},
+		"1_one.up.sql": {Data: []byte("select 1")},
+	})
+	require.NoError(t, err)
+	require.Len(t, migrations, 1)
+}
+
+func TestRunner(t *testing.T) {
+	t.Run("server", func(t *testing.T) {
+		s, err := server.New(filepath.Join(t.TempDir(), "migrate.db"))
+		require.NoError(t, err)
+
+		defer s.Close()
+
+		testRunner(t, s)
+	})
+
+	t.Run("driver", func(t *testing.T) {
+		s, err := server.New(filepath.Join(t.TempDir(), "migrate.db"))
+		require.NoError(t, err)
+
+		defer s.Close()
+
+		svr := httptest.NewServer(server.NewHandler(s))
+		defer svr.Close()
+
+		db, err := sql.Open(driver.DriverName, svr.URL)
+		require.NoError(t, err)
+
+		defer db.Close()
+
+		conn, err := db.Conn(context.Background())
+		require.NoError(t, err)
+
+		defer conn.Close()
+
+		testRunner(t, migrate.Conn(conn))
+	})
+}
+
+func testRunner(t *testing.T, db migrate.Database) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	migrations, err := migrate.LoadDir("testdata")
+	require.NoError(t, err)
+
+	versions := func(migrations []*migrate.Migration) []int64 {
+		var versions []int64
+		for _, m := range migrations {
+			versions = append(versions, m.Version)
+		}
+		return versions
+	}
+
+	applied := func(runner *migrate.Runner) []int64 {
+		status, err := runner.Status(ctx)
+		require.NoError(t, err)
+
+		var versions []int64
+		for _, s := range status {
+			if s.Applied {
+				versions = append(versions, s.Version)
+			}
+		}
+		return versions
+	}
+
+	tableExists := func(name string) bool {
+		resp, err := db.Query(ctx, &sqliterpc.QueryRequest{
+			Sql: `SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = '` + name + `'`,
+		})
+		require.NoError(t, err)
+		return resp.Rows[0].Values[0].GetIntegerValue().GetValue() == 1
+	}
+
+	runner := migrate.New(db, migrations[:2])
+
+	// status does not create the table
+	status, err := runner.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, status, 2)
+	require.False(t, status[0].Applied)
+	require.False(t, tableExists(migrate.Table))
+
+	done, err := runner.Up(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2}, versions(done))
+
+	status, err = runner.Status(ctx)
+	require.NoError(t, err)
+	require.True(t, status[1].Applied)
+	require.WithinDuration(t, time.Now(), status[1].AppliedAt, time.Minute)
+
+	done, err = runner.Up(ctx)
+	require.NoError(t, err)
+	require.Empty(t, done)
+
+	// later migrations are applied
+	runner = migrate.New(db, migrations)
+
+	done, err = runner.Up(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []int64{3}, versions(done))
+	require.True(t, tableExists("groups"))
+
+	// failed migrations are rolled back
+	failing := &migrate.Migration{
+		Version: 4,
+		Name:    "failing",
+		Up:      `CREATE TABLE roles (id INTEGER PRIMARY KEY); INSERT INTO missing VALUES (1)`,
+	}
+
+	runner = migrate.New(db, append(migrations, failing))
+
+	done, err = runner.Up(ctx)
+	require.Error(t, err)
+	require.Empty(t, done)
+	require.False(t, tableExists("roles"))
+	require.Equal(t, []int64{1, 2, 3}, applied(runner))
+
+	// migration 3 cannot be reverted
+	runner = migrate.New(db, migrations)
+
+	done, err = runner.Down(ctx, 1)
+	require.Error(t, err)
+	require.Empty(t, done)
+	require.Equal(t, []int64{1, 2, 3}, applied(runner))
+
+	withDown := *migrations[2]
+	withDown.Down = `DROP TABLE groups`
+
+	runner = migrate.New(db, []*migrate.Migration{migrations[0], migrations[1], &withDown})
+
+	done, err = runner.Down(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, []int64{3, 2}, versions(done))
+	require.False(t, tableExists("groups"))
+	require.Equal(t, []int64{1}, applied(runner))
+
+	// changes to applied migrations are detected
+	modified := *migrations[0]
+	modified.Up += "\nINSERT INTO users (name) VALUES ('other');"
+
+	runner = migrate.New(db, []*migrate.Migration{&modified, migrations[1]})
+
+	status, err = runner.Status(ctx)
+	require.NoError(t, err)
+	require.True(t, status[0].Modified)
+	require.False(t, status[1].Applied)
+
+	_, err = runner.Up(ctx)
+	require.Error(t, err)
+	require.Equal(t, []int64{1}, applied(runner))
+
+	// applied migrations may be missing
+	runner = migrate.New(db, nil)
+
+	status, err = runner.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, status, 1)
+	require.True(t, status[0].Missing)
+
+	_, err = runner.Down(ctx, 1)
+	require.Error(t, err)
+
+	runner = migrate.New(db, migrations)
+
+	done, err = runner.Down(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, versions(done))
+	require.False(t, tableExists("users"))
+	require.Empty(t, applied(runner))
+}
+
+// barrier makes runners wait for each other before running migrations, so
+// each reads the applied migrations before any are applied.
+type barrier struct {
+	migrate.Database
+	once sync.Once
+	wg   *sync.WaitGroup
+}
+
+func (b *barrier) ExecScript(ctx context.Context, req *sqliterpc.ExecScriptRequest) (*sqliterpc.ExecScriptResponse, error) {
+	if req.Transaction {
+		b.once.Do(b.wg.Done)
+		b.wg.Wait()
+	}
+
+	return b.Database.ExecScript(ctx, req)
+}
+
+func TestRunnerConcurrent(t *testing.T) {
+	// applied migrations are read in pages within the response limits
+	s, err := server.New(filepath.Join(t.TempDir(), "migrate.db"), server.WithMaxRows(2))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	migrations, err := migrate.LoadDir("testdata")
+	require.NoError(t, err)
+
+	var (
+		wg   sync.WaitGroup
+		lock sync.Mutex
+		done []int64
+	)
+
+	wg.Add(2)
+
+	errs := make(chan error, 2)
+
+	for i := 0; i < 2; i++ {
+		go func() {
+			applied, err := migrate.New(&barrier{Database: s, wg: &wg}, migrations).Up(ctx)
+
+			lock.Lock()
+			for _, m := range applied {
+				done = append(done, m.Version)
+			}
+			lock.Unlock()
+
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	// each migration is applied by one of the runners
+	require.ElementsMatch(t, []int64{1, 2, 3}, done)
+
+	status, err := migrate.New(s, migrations).Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, status, 3)
+
+	for _, st := range status {
+		require.True(t, st.Applied)
+	}
+}
@@ -0,0 +1,106 @@
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Migration is a numbered change to the schema.
+type Migration struct {
+	Version int64
+	Name    string
+	// Up applies the migration.
+	Up string
+	// Down reverts the migration. It is empty if the migration cannot be reverted.
+	Down string
+}
+
+// Checksum identifies the up sql, so changes to applied migrations are detected.
+func (m *Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(sum[:])
+}
+
+// files are named {version}_{name}.up.sql and {version}_{name}.down.sql
+var fileName = regexp.MustCompile(`^(\d+)_([^.]+)\.(up|down)\.sql$`)
+
+// LoadDir reads migrations from the files in dir.
+func LoadDir(dir string) ([]*Migration, error) {
+	return Load(os.DirFS(dir))
+}
+
+// Load reads migrations from the files at the root of fsys, such as an embed.FS.
+// Use fs.Sub for files in a directory of fsys. Files are named
+// {version}_{name}.up.sql, and optionally {version}_{name}.down.sql.
+// Migrations are returned in order of version.
+func Load(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := map[int64]*Migration{}
+
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q: must be {version}_{name}.up.sql or {version}_{name}.down.sql", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version in migration file name %q: %w", entry.Name(), err)
+		}
+
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := migrations[version]
+		if !ok {
+			m = &Migration{
+				Version: version,
+				Name:    match[2],
+			}
+			migrations[version] = m
+		}
+
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migrations %q and %q have the same version %d", m.Name, match[2], version)
+		}
+
+		if match[3] == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	list := make([]*Migration, 0, len(migrations))
+
+	for _, m := range migrations {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up sql", m.Version, m.Name)
+		}
+
+		list = append(list, m)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+
+	return list, nil
+}
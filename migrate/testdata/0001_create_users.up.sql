@@ -0,0 +1,6 @@
+CREATE TABLE users (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL
+);
+
+INSERT INTO users (name) VALUES ('admin');
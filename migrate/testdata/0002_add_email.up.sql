@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN email TEXT;
+CREATE UNIQUE INDEX users_email ON users (email);
+-- existing users have no email
@@ -0,0 +1 @@
+CREATE TABLE groups (id INTEGER PRIMARY KEY, name TEXT NOT NULL)
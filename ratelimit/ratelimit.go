@@ -0,0 +1,337 @@
+// Package ratelimit limits the rate and concurrency of requests from each
+// principal or client address.
+package ratelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/twitchtv/twirp"
+	"go.uber.org/zap"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/auth"
+	"github.com/bakins/sqliterpc/internal/logging"
+)
+
+// Limit limits the requests to a method from a single principal or client address.
+type Limit struct {
+	// Rate is how many requests may be made per second. Zero is no limit.
+	Rate float64 `json:"rate"`
+	// Burst is how many requests may be made at once after being idle.
+	// It defaults to the rate, and is at least one.
+	Burst int `json:"burst"`
+	// MaxInFlight is how many requests may run at once. Zero is no limit.
+	// Streaming queries and subscriptions are in flight until they end.
+	MaxInFlight int `json:"max_in_flight"`
+}
+
+// Limits are the limits for each method. Methods are named without their
+// service, such as Exec or Query, so streaming queries are limited as Query,
+// and streaming backups as Backup. Default applies to methods that do not
+// have limits of their own, and to requests for paths that are not a method,
+// which share a single limit. Each principal has its own limits, and requests
+// that are not authenticated are limited by the client's IP address.
+//
+//	{
+//	  "methods": {
+//	    "Exec": {"rate": 10, "burst": 20, "max_in_flight": 2},
+//	    "Query": {"rate": 100, "max_in_flight": 8}
+//	  },
+//	  "default": {"rate": 50}
+//	}
+type Limits struct {
+	// Methods are keyed by method name.
+	Methods map[string]Limit `json:"methods"`
+	Default *Limit           `json:"default"`
+}
+
+// Load reads limits from a JSON file.
+func Load(filename string) (*Limits, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var l Limits
+	if err := decoder.Decode(&l); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	if err := l.validate(); err != nil {
+		return nil, fmt.Errorf("invalid limits in %s: %w", filename, err)
+	}
+
+	return &l, nil
+}
+
+func (l *Limits) validate() error {
+	for method, limit := range l.Methods {
+		if !methodNames[method] {
+			return fmt.Errorf("unknown method %s", method)
+		}
+
+		if err := limit.validate(); err != nil {
+			return fmt.Errorf("method %s: %w", method, err)
+		}
+	}
+
+	if l.Default != nil {
+		if err := l.Default.validate(); err != nil {
+			return fmt.Errorf("default: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (l Limit) validate() error {
+	switch {
+	case l.Rate < 0 || math.IsNaN(l.Rate) || math.IsInf(l.Rate, 0):
+		return errors.New("rate must be a number that is not negative")
+	case l.Burst < 0:
+		return errors.New("burst must not be negative")
+	case l.MaxInFlight < 0:
+		return errors.New("max in flight must not be negative")
+	}
+
+	return nil
+}
+
+// methods maps the service and method at the end of the path of each Twirp
+// method and stream to the name of the method. Requests are only limited by
+// these names, so the number of limits held for each client is bounded.
+var methods = knownMethods()
+
+// methodNames are the names of the methods that may be limited.
+var methodNames = func() map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range methods {
+		names[name] = true
+	}
+
+	return names
+}()
+
+func knownMethods() map[string]string {
+	m := make(map[string]string)
+
+	services := sqliterpc.File_sqlite_proto.Services()
+	for i := 0; i < services.Len(); i++ {
+		service := services.Get(i)
+
+		for j := 0; j < service.Methods().Len(); j++ {
+			name := string(service.Methods().Get(j).Name())
+			m[string(service.FullName())+"/"+name] = name
+		}
+	}
+
+	for _, p := range []string{sqliterpc.QueryStreamPath, sqliterpc.SubscribePath, sqliterpc.BackupStreamPath} {
+		m[path.Base(path.Dir(p))+"/"+path.Base(p)] = path.Base(p)
+	}
+
+	return m
+}
+
+// method returns the name of the method r is for, or an empty string if it is
+// not for a method. Paths may have a prefix, such as the database served by a
+// Manager.
+func method(r *http.Request) string {
+	p := r.URL.Path
+	return methods[path.Base(path.Dir(p))+"/"+path.Base(p)]
+}
+
+// limit returns the limit for method, or nil if it is not limited.
+func (l *Limits) limit(method string) *Limit {
+	if limit, ok := l.Methods[method]; ok {
+		return &limit
+	}
+
+	return l.Default
+}
+
+func (l Limit) burst() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+
+	return math.Max(1, math.Ceil(l.Rate))
+}
+
+// inFlightRetryAfter is the hint for requests rejected for having too many
+// requests in flight, as it is not known when one will finish.
+const inFlightRetryAfter = 100 * time.Millisecond
+
+// sweepInterval is how often the state of idle clients is removed.
+const sweepInterval = time.Minute
+
+// Limiter enforces limits. Requests over a limit are rejected with a Twirp
+// resource exhausted error. How long to wait before retrying is set in the
+// sqliterpc.ErrorMetaRetryAfter meta and the Retry-After header.
+type Limiter struct {
+	limits *Limits
+
+	lock    sync.Mutex
+	clients map[key]*state
+	swept   time.Time
+}
+
+// key identifies the requests to a method from a principal or address.
+type key struct {
+	client string
+	method string
+}
+
+// state is a token bucket and a count of requests in flight.
+type state struct {
+	tokens   float64
+	updated  time.Time
+	inFlight int
+}
+
+// New creates a limiter that enforces limits.
+func New(limits *Limits) (*Limiter, error) {
+	if err := limits.validate(); err != nil {
+		return nil, err
+	}
+
+	l := Limiter{
+		limits:  limits,
+		clients: make(map[key]*state),
+		swept:   time.Now(),
+	}
+
+	return &l, nil
+}
+
+// Middleware rejects requests that exceed the limits. It must follow the
+// auth middleware for requests to be limited by principal.
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := method(r)
+
+		limit := l.limits.limit(method)
+		if limit == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		k := key{
+			client: client(r),
+			method: method,
+		}
+
+		if wait, err := l.acquire(k, limit, time.Now()); err != nil {
+			logging.Info(r.Context(), "request limited", zap.String("path", r.URL.Path), zap.Error(err))
+
+			// the header is in seconds, so is rounded up.
+			w.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(wait.Seconds())), 10))
+			_ = twirp.WriteError(w, err.WithMeta(sqliterpc.ErrorMetaRetryAfter, strconv.FormatInt(millis(wait), 10)))
+			return
+		}
+
+		defer l.release(k)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// client returns the principal of the request, or the address of the client if
+// the request is not authenticated.
+func client(r *http.Request) string {
+	if principal := auth.FromContext(r.Context()); principal != nil {
+		return "principal:" + principal.Name
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "address:" + host
+}
+
+// millis returns d in milliseconds, rounded up.
+func millis(d time.Duration) int64 {
+	return int64((d + time.Millisecond - 1) / time.Millisecond)
+}
+
+// acquire takes a token and counts the request as in flight. If the request is
+// over a limit, it returns how long to wait before retrying.
+func (l *Limiter) acquire(k key, limit *Limit, now time.Time) (time.Duration, twirp.Error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if now.Sub(l.swept) >= sweepInterval {
+		l.sweep(now)
+	}
+
+	s, ok := l.clients[k]
+	if !ok {
+		s = &state{
+			tokens:  limit.burst(),
+			updated: now,
+		}
+		l.clients[k] = s
+	}
+
+	// rejected requests do not take a token.
+	if limit.MaxInFlight > 0 && s.inFlight >= limit.MaxInFlight {
+		return inFlightRetryAfter, twirp.NewError(twirp.ResourceExhausted, "too many requests in flight")
+	}
+
+	if limit.Rate > 0 {
+		s.tokens = math.Min(limit.burst(), s.tokens+now.Sub(s.updated).Seconds()*limit.Rate)
+		s.updated = now
+
+		if s.tokens < 1 {
+			wait := time.Duration((1 - s.tokens) / limit.Rate * float64(time.Second))
+			return wait, twirp.NewError(twirp.ResourceExhausted, "rate limit exceeded")
+		}
+
+		s.tokens--
+	}
+
+	s.inFlight++
+
+	return 0, nil
+}
+
+func (l *Limiter) release(k key) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if s, ok := l.clients[k]; ok {
+		s.inFlight--
+	}
+}
+
+// sweep removes clients that are idle and have a full bucket, as their
+// state is the same as a new client's. lock must be held.
+func (l *Limiter) sweep(now time.Time) {
+	l.swept = now
+
+	for k, s := range l.clients {
+		if s.inFlight > 0 {
+			continue
+		}
+
+		limit := l.limits.limit(k.method)
+		if limit == nil || limit.Rate == 0 || s.tokens+now.Sub(s.updated).Seconds()*limit.Rate >= limit.burst() {
+			delete(l.clients, k)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package ratelimit_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/auth"
+	"github.com/bakins/sqliterpc/ratelimit"
+)
+
+const methodPath = "/twirp/sqlite.rpc.v0.DatabaseService/"
+
+type response struct {
+	status     int
+	retryAfter string
+	code       twirp.ErrorCode
+	meta       map[string]string
+}
+
+func serve(t *testing.T, handler http.Handler, method string, principal string, addr string) response {
+	t.Helper()
+
+	return servePath(t, handler, methodPath+method, principal, addr)
+}
+
+func servePath(t *testing.T, handler http.Handler, path string, principal string, addr string) response {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodPost, path, nil)
+	r.RemoteAddr = addr
+
+	if principal != "" {
+		r = r.WithContext(auth.ToContext(r.Context(), &auth.Principal{Name: principal}))
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	resp := response{
+		status:     w.Code,
+		retryAfter: w.Header().Get("Retry-After"),
+	}
+
+	if w.Code != http.StatusOK {
+		var e struct {
+			Code string            `json:"code"`
+			Meta map[string]string `json:"meta"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &e))
+
+		resp.code = twirp.ErrorCode(e.Code)
+		resp.meta = e.Meta
+	}
+
+	return resp
+}
+
+func TestRate(t *testing.T) {
+	limiter, err := ratelimit.New(&ratelimit.Limits{
+		Methods: map[string]ratelimit.Limit{
+			"Exec": {Rate: 1, Burst: 2},
+		},
+	})
+	require.NoError(t, err)
+
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for i := 0; i < 2; i++ {
+		require.Equal(t, http.StatusOK, serve(t, handler, "Exec", "", "192.0.2.1:1234").status)
+	}
+
+	resp := serve(t, handler, "Exec", "", "192.0.2.1:5678")
+	require.Equal(t, http.StatusTooManyRequests, resp.status)
+	require.Equal(t, twirp.ResourceExhausted, resp.code)
+	require.Equal(t, "1", resp.retryAfter)
+
+	ms, err := strconv.Atoi(resp.meta[sqliterpc.ErrorMetaRetryAfter])
+	require.NoError(t, err)
+	require.Greater(t, ms, 0)
+	require.LessOrEqual(t, ms, 1000)
+
+	// other methods, addresses, and principals have their own limits.
+	require.Equal(t, http.StatusOK, serve(t, handler, "Query", "", "192.0.2.1:1234").status)
+	require.Equal(t, http.StatusOK, serve(t, handler, "Exec", "", "192.0.2.2:1234").status)
+	require.Equal(t, http.StatusOK, serve(t, handler, "Exec", "tests", "192.0.2.1:1234").status)
+}
+
+func TestDefault(t *testing.T) {
+	limiter, err := ratelimit.New(&ratelimit.Limits{
+		Methods: map[string]ratelimit.Limit{
+			"Query": {},
+		},
+		Default: &ratelimit.Limit{Rate: 0.5},
+	})
+	require.NoError(t, err)
+
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	require.Equal(t, http.StatusOK, serve(t, handler, "Exec", "tests", "192.0.2.1:1234").status)
+
+	resp := serve(t, handler, "Exec", "tests", "192.0.2.1:1234")
+	require.Equal(t, twirp.ResourceExhausted, resp.code)
+	require.Equal(t, "2", resp.retryAfter)
+
+	// a method's own limits replace the default.
+	for i := 0; i < 5; i++ {
+		require.Equal(t, http.StatusOK, serve(t, handler, "Query", "tests", "192.0.2.1:1234").status)
+	}
+}
+
+func TestPaths(t *testing.T) {
+	limiter, err := ratelimit.New(&ratelimit.Limits{
+		Methods: map[string]ratelimit.Limit{
+			"Exec":   {Rate: 0.5},
+			"Backup": {Rate: 0.5},
+		},
+		Default: &ratelimit.Limit{Rate: 0.5},
+	})
+	require.NoError(t, err)
+
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	// methods of a database served by a manager are limited by method name.
+	require.Equal(t, http.StatusOK, servePath(t, handler, "/db/testing"+methodPath+"Exec", "tests", "192.0.2.1:1234").status)
+	require.Equal(t, twirp.ResourceExhausted, serve(t, handler, "Exec", "tests", "192.0.2.1:1234").code)
+
+	// streaming and unary backups share a limit.
+	require.Equal(t, http.StatusOK, servePath(t, handler, sqliterpc.BackupStreamPath, "tests", "192.0.2.1:1234").status)
+	require.Equal(t, twirp.ResourceExhausted, servePath(t, handler, "/twirp/sqlite.rpc.v0.AdminService/Backup", "tests", "192.0.2.1:1234").code)
+
+	// paths that are not a method share the default limit.
+	require.Equal(t, http.StatusOK, servePath(t, handler, "/unknown/one", "tests", "192.0.2.1:1234").status)
+	require.Equal(t, twirp.ResourceExhausted, servePath(t, handler, "/unknown/two", "tests", "192.0.2.1:1234").code)
+	require.Equal(t, twirp.ResourceExhausted, serve(t, handler, "Unknown", "tests", "192.0.2.1:1234").code)
+}
+
+func TestMaxInFlight(t *testing.T) {
+	limiter, err := ratelimit.New(&ratelimit.Limits{
+		Default: &ratelimit.Limit{MaxInFlight: 1},
+	})
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	done := make(chan struct{})
+
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Block") != "" {
+			close(started)
+			<-done
+		}
+	}))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		r := httptest.NewRequest(http.MethodPost, methodPath+"Query", nil)
+		r.Header.Set("X-Block", "true")
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+	}()
+
+	<-started
+
+	resp := serve(t, handler, "Query", "", "192.0.2.1:1234")
+	require.Equal(t, twirp.ResourceExhausted, resp.code)
+	require.NotEmpty(t, resp.meta[sqliterpc.ErrorMetaRetryAfter])
+
+	close(done)
+	wg.Wait()
+
+	require.Equal(t, http.StatusOK, serve(t, handler, "Query", "", "192.0.2.1:1234").status)
+}
+
+func TestLoad(t *testing.T) {
+	write := func(t *testing.T, data string) string {
+		filename := filepath.Join(t.TempDir(), "limits.json")
+		require.NoError(t, os.WriteFile(filename, []byte(data), 0o600))
+		return filename
+	}
+
+	limits, err := ratelimit.Load(write(t, `{
+		"methods": {"Exec": {"rate": 10, "burst": 20, "max_in_flight": 2}},
+		"default": {"rate": 50}
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, ratelimit.Limit{Rate: 10, Burst: 20, MaxInFlight: 2}, limits.Methods["Exec"])
+	require.Equal(t, float64(50), limits.Default.Rate)
+
+	invalid := map[string]string{
+		"unknown field":  `{"methods": {"Exec": {"rps": 10}}}`,
+		"unknown method": `{"methods": {"Execute": {"rate": 10}}}`,
+		"negative rate":  `{"methods": {"Exec": {"rate": -1}}}`,
+		"burst":          `{"default": {"burst": -1}}`,
+		"in flight":      `{"default": {"max_in_flight": -1}}`,
+	}
+
+	for name, data := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := ratelimit.Load(write(t, data))
+			require.Error(t, err)
+		})
+	}
+}
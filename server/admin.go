@@ -0,0 +1,407 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/auth"
+)
+
+var _ sqliterpc.AdminService = &DatabaseServer{}
+
+// see https://www.sqlite.org/fileformat.html#the_database_header
+var sqliteHeader = []byte("SQLite format 3\x00")
+
+// backupChunkSize is the size of each chunk of a streamed backup.
+const backupChunkSize = 1 << 20
+
+// Backup copies the database to a temporary file using the backup API
+// and returns its contents. The copy is consistent, and only blocks
+// writers while it is taken. Databases larger than a stream message fail
+// with a ResourceExhausted error, and should be backed up with the stream.
+func (s *DatabaseServer) Backup(ctx context.Context, req *sqliterpc.BackupRequest) (*sqliterpc.BackupResponse, error) {
+	if err := s.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	filename, err := s.backup(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.Remove(filename)
+
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	if info.Size() > sqliterpc.MaxMessageSize {
+		return nil, twirp.NewError(twirp.ResourceExhausted,
+			fmt.Sprintf("database is larger than %d bytes, use the backup stream at %s", sqliterpc.MaxMessageSize, sqliterpc.BackupStreamPath))
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := sqliterpc.BackupResponse{
+		Database: data,
+	}
+
+	return &resp, nil
+}
+
+// serveBackupStream sends a backup of the database in chunks, so it is not
+// limited in size.
+func (s *DatabaseServer) serveBackupStream(ctx context.Context, sw *streamWriter, _ proto.Message) error {
+	if err := s.checkAdmin(ctx); err != nil {
+		return err
+	}
+
+	filename, err := s.backup(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(filename)
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return twirp.InternalErrorWith(err)
+	}
+
+	defer f.Close()
+
+	sw.start()
+
+	chunk := make([]byte, backupChunkSize)
+
+	for {
+		n, err := io.ReadFull(f, chunk)
+		if n > 0 {
+			frame := sqliterpc.BackupFrame{
+				Frame: &sqliterpc.BackupFrame_Chunk{
+					Chunk: chunk[:n],
+				},
+			}
+
+			if err := sw.write(&frame); err != nil {
+				return nil
+			}
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+
+		if err != nil {
+			err = twirp.InternalErrorWith(err)
+			writeBackupError(sw, err)
+			return err
+		}
+	}
+
+	done := sqliterpc.BackupFrame{
+		Frame: &sqliterpc.BackupFrame_Done{
+			Done: &sqliterpc.BackupDone{},
+		},
+	}
+
+	_ = sw.write(&done)
+
+	return nil
+}
+
+func writeBackupError(sw *streamWriter, err error) {
+	twerr := wrapError(err)
+
+	frame := sqliterpc.BackupFrame{
+		Frame: &sqliterpc.BackupFrame_Error{
+			Error: &sqliterpc.StreamError{
+				Code:    string(twerr.Code()),
+				Message: twerr.Msg(),
+				Meta:    twerr.MetaMap(),
+			},
+		},
+	}
+
+	_ = sw.write(&frame)
+}
+
+// backup copies the database to a temporary file, which the caller removes.
+func (s *DatabaseServer) backup(ctx context.Context) (string, error) {
+	filename, err := tempFile("sqliterpc-backup-*.db")
+	if err != nil {
+		return "", twirp.InternalErrorWith(err)
+	}
+
+	if err := s.backupTo(ctx, filename); err != nil {
+		_ = os.Remove(filename)
+		return "", err
+	}
+
+	return filename, nil
+}
+
+// backupTo copies the database to the database file filename.
+func (s *DatabaseServer) backupTo(ctx context.Context, filename string) error {
+	dest, err := openFile(filename)
+	if err != nil {
+		return twirp.InternalErrorWith(err)
+	}
+
+	defer dest.Close()
+
+	conn, err := s.readers.Conn(ctx)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	defer conn.Close()
+
+	err = conn.Raw(func(dc interface{}) error {
+		sc, ok := dc.(*sqliteConn)
+		if !ok {
+			return errNotSQLite
+		}
+
+		return copyDatabase(dest, sc.SQLiteConn)
+	})
+	if err != nil {
+		return wrapError(err)
+	}
+
+	if err := dest.Close(); err != nil {
+		return twirp.InternalErrorWith(err)
+	}
+
+	return nil
+}
+
+// FetchBackup writes a backup of the database of the sqliterpc server at url
+// to w. The backup is streamed, so it is not limited in size. client should
+// add any credentials the server requires. If client is nil,
+// http.DefaultClient is used.
+func FetchBackup(ctx context.Context, client *http.Client, url string, w io.Writer) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := proto.Marshal(&sqliterpc.BackupRequest{})
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(url, "/")+sqliterpc.BackupStreamPath, bytes.NewReader(req))
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Set("Content-Type", sqliterpc.ContentTypeProtobuf)
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("backup failed with status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	for {
+		var frame sqliterpc.BackupFrame
+
+		if err := sqliterpc.ReadMessage(resp.Body, &frame); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+
+		switch f := frame.Frame.(type) {
+		case *sqliterpc.BackupFrame_Chunk:
+			if _, err := w.Write(f.Chunk); err != nil {
+				return err
+			}
+		case *sqliterpc.BackupFrame_Error:
+			twerr := twirp.NewError(twirp.ErrorCode(f.Error.Code), f.Error.Message)
+			for k, v := range f.Error.Meta {
+				twerr = twerr.WithMeta(k, v)
+			}
+			return twerr
+		case *sqliterpc.BackupFrame_Done:
+			return nil
+		default:
+			return fmt.Errorf("unexpected frame %T", frame.Frame)
+		}
+	}
+}
+
+// Restore replaces the contents of the database using the backup API.
+// The copy is made while holding a writer connection, so it waits for
+// open write transactions. Readers see either the old or the new database.
+func (s *DatabaseServer) Restore(ctx context.Context, req *sqliterpc.RestoreRequest) (*sqliterpc.RestoreResponse, error) {
+	if err := s.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	if !bytes.HasPrefix(req.Database, sqliteHeader) {
+		return nil, twirp.InvalidArgumentError("database", "is not a sqlite database")
+	}
+
+	filename, err := tempFile("sqliterpc-restore-*.db")
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	defer os.Remove(filename)
+
+	if err := os.WriteFile(filename, req.Database, 0o600); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	if err := s.restore(ctx, filename); err != nil {
+		return nil, err
+	}
+
+	return &sqliterpc.RestoreResponse{}, nil
+}
+
+// restore replaces the contents of the database with the sqlite database
+// file filename.
+func (s *DatabaseServer) restore(ctx context.Context, filename string) error {
+	src, err := openFile(filename)
+	if err != nil {
+		return twirp.InternalErrorWith(err)
+	}
+
+	defer src.Close()
+
+	if err := quickCheck(src); err != nil {
+		return twirp.InvalidArgumentError("database", err.Error())
+	}
+
+	conn, err := s.writer.Conn(ctx)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	defer conn.Close()
+
+	err = conn.Raw(func(dc interface{}) error {
+		sc, ok := dc.(*sqliteConn)
+		if !ok {
+			return errNotSQLite
+		}
+
+		err := copyDatabase(sc.SQLiteConn, src)
+
+		// later commits are compared to the restored schema version.
+		sc.state.checkSchema()
+
+		return err
+	})
+
+	// the schema may have changed even if the copy failed part way.
+	s.connector.invalidate()
+
+	if err != nil {
+		return wrapError(err)
+	}
+
+	return nil
+}
+
+// checkAdmin only allows principals with an admin policy, or any principal if
+// there are no policies, as backup and restore read and write every table.
+func (s *DatabaseServer) checkAdmin(ctx context.Context) error {
+	if !s.policies.admin(auth.FromContext(ctx)) {
+		return twirp.NewError(twirp.PermissionDenied, "requires an admin policy")
+	}
+
+	return nil
+}
+
+func tempFile(pattern string) (string, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// openFile opens a connection to a database file outside of the server's pools.
+func openFile(filename string) (*sqlite3.SQLiteConn, error) {
+	d := sqlite3.SQLiteDriver{}
+
+	conn, err := d.Open("file:" + filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return conn.(*sqlite3.SQLiteConn), nil
+}
+
+// copyDatabase copies the main database of src to dest in a single step.
+// see https://www.sqlite.org/backup.html
+func copyDatabase(dest, src *sqlite3.SQLiteConn) error {
+	backup, err := dest.Backup("main", src, "main")
+	if err != nil {
+		return err
+	}
+
+	if _, err := backup.Step(-1); err != nil {
+		_ = backup.Finish()
+		return err
+	}
+
+	return backup.Finish()
+}
+
+// quickCheck returns an error if the database is corrupt.
+// see https://www.sqlite.org/pragma.html#pragma_quick_check
+func quickCheck(conn *sqlite3.SQLiteConn) error {
+	rows, err := conn.Query("PRAGMA quick_check", nil)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil {
+		return err
+	}
+
+	if result := fmt.Sprintf("%s", dest[0]); result != "ok" {
+		return fmt.Errorf("integrity check failed: %s", result)
+	}
+
+	return nil
+}
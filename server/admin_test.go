@@ -0,0 +1,177 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/auth"
+	"github.com/bakins/sqliterpc/server"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	policies := server.Policies{
+		Roles: map[string]server.Policy{
+			"readonly": {ReadOnly: true},
+			"operator": {Admin: true},
+			"limited":  {Admin: true, DenyTables: []string{"other"}},
+		},
+	}
+
+	s, err := server.New(filepath.Join(t.TempDir(), "backup.db"), server.WithPolicies(&policies))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	ctx = auth.ToContext(ctx, &auth.Principal{Name: "operator", Roles: []string{"operator"}})
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table testing (intCol INTEGER, textCol TEXT)`})
+	require.NoError(t, err)
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `insert into testing (intCol, textCol) values (1, 'one'), (2, 'two')`})
+	require.NoError(t, err)
+
+	backup, err := s.Backup(ctx, &sqliterpc.BackupRequest{})
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(backup.Database, []byte("SQLite format 3")))
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `drop table testing`})
+	require.NoError(t, err)
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table other (id INTEGER)`})
+	require.NoError(t, err)
+
+	prepared, err := s.Prepare(ctx, &sqliterpc.PrepareRequest{Sql: `select id from other`})
+	require.NoError(t, err)
+
+	_, err = s.Restore(ctx, &sqliterpc.RestoreRequest{Database: backup.Database})
+	require.NoError(t, err)
+
+	resp, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select intCol from testing`})
+	require.NoError(t, err)
+	require.Len(t, resp.Rows, 2)
+
+	_, err = s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select id from other`})
+	requireCode(t, err, twirp.InvalidArgument)
+
+	// statements are prepared again after a restore
+	_, err = s.Query(ctx, &sqliterpc.QueryRequest{StatementId: prepared.StatementId})
+	requireCode(t, err, twirp.NotFound)
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := s.Restore(ctx, &sqliterpc.RestoreRequest{Database: []byte("not a database")})
+		requireCode(t, err, twirp.InvalidArgument)
+
+		truncated := backup.Database[:len(backup.Database)/2]
+		_, err = s.Restore(ctx, &sqliterpc.RestoreRequest{Database: truncated})
+		require.Error(t, err)
+
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select intCol from testing`})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 2)
+	})
+
+	t.Run("restricted", func(t *testing.T) {
+		principals := map[string]*auth.Principal{
+			"unauthenticated": nil,
+			"readonly":        {Name: "reader", Roles: []string{"readonly"}},
+			"restricted":      {Name: "limited", Roles: []string{"operator", "limited"}},
+		}
+
+		for name, principal := range principals {
+			c := auth.ToContext(context.Background(), principal)
+
+			t.Run(name, func(t *testing.T) {
+				_, err := s.Backup(c, &sqliterpc.BackupRequest{})
+				requireCode(t, err, twirp.PermissionDenied)
+
+				_, err = s.Restore(c, &sqliterpc.RestoreRequest{Database: backup.Database})
+				requireCode(t, err, twirp.PermissionDenied)
+			})
+		}
+	})
+
+	t.Run("no policies", func(t *testing.T) {
+		other, err := server.New(filepath.Join(t.TempDir(), "other.db"))
+		require.NoError(t, err)
+
+		defer other.Close()
+
+		// principals are not restricted without policies.
+		_, err = other.Restore(context.Background(), &sqliterpc.RestoreRequest{Database: backup.Database})
+		require.NoError(t, err)
+
+		resp, err := other.Backup(context.Background(), &sqliterpc.BackupRequest{})
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(resp.Database, []byte("SQLite format 3")))
+
+		rows, err := other.Query(context.Background(), &sqliterpc.QueryRequest{Sql: `select intCol from testing`})
+		require.NoError(t, err)
+		require.Len(t, rows.Rows, 2)
+	})
+}
+
+func TestBackupStream(t *testing.T) {
+	policies := server.Policies{
+		Roles: map[string]server.Policy{
+			"operator": {Admin: true},
+		},
+	}
+
+	s, err := server.New(filepath.Join(t.TempDir(), "stream.db"), server.WithPolicies(&policies))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	var principal *auth.Principal
+
+	handler := server.NewHandler(s)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(auth.ToContext(r.Context(), principal)))
+	}))
+	defer svr.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table testing (data BLOB)`})
+	require.NoError(t, err)
+
+	// larger than a single chunk
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `insert into testing (data) values (randomblob(3000000))`})
+	require.NoError(t, err)
+
+	var backup bytes.Buffer
+
+	err = server.FetchBackup(ctx, nil, svr.URL, &backup)
+	require.Error(t, err)
+
+	principal = &auth.Principal{Name: "operator", Roles: []string{"operator"}}
+
+	require.NoError(t, server.FetchBackup(ctx, nil, svr.URL, &backup))
+	require.True(t, bytes.HasPrefix(backup.Bytes(), []byte("SQLite format 3")))
+
+	other, err := server.New(filepath.Join(t.TempDir(), "other.db"))
+	require.NoError(t, err)
+
+	defer other.Close()
+
+	_, err = other.Restore(ctx, &sqliterpc.RestoreRequest{Database: backup.Bytes()})
+	require.NoError(t, err)
+
+	resp, err := other.Query(ctx, &sqliterpc.QueryRequest{Sql: `select length(data) from testing`})
+	require.NoError(t, err)
+	require.Len(t, resp.Rows, 1)
+	require.Equal(t, int64(3000000), resp.Rows[0].Values[0].GetIntegerValue().GetValue())
+}
@@ -0,0 +1,42 @@
+#include <stdint.h>
+
+#include "_cgo_export.h"
+
+// defined by the sqlite library go-sqlite3 is built with, which does not
+// export its header.
+typedef struct sqlite3 sqlite3;
+typedef struct sqlite3_context sqlite3_context;
+typedef struct sqlite3_value sqlite3_value;
+
+int sqlite3_auto_extension(void (*entry)(void));
+int sqlite3_create_function(sqlite3 *db, const char *name, int args, int encoding, void *data,
+	void (*fn)(sqlite3_context *, int, sqlite3_value **),
+	void (*step)(sqlite3_context *, int, sqlite3_value **),
+	void (*final)(sqlite3_context *));
+sqlite3 *sqlite3_context_db_handle(sqlite3_context *ctx);
+void sqlite3_result_int64(sqlite3_context *ctx, long long value);
+int sqlite3_set_authorizer(sqlite3 *db,
+	int (*authorize)(void *, int, const char *, const char *, const char *, const char *),
+	void *data);
+
+#define SQLITE_UTF8 1
+
+static void connection(sqlite3_context *ctx, int argc, sqlite3_value **argv) {
+	sqlite3_result_int64(ctx, (long long)(uintptr_t)sqlite3_context_db_handle(ctx));
+}
+
+static int connection_init(sqlite3 *db, char **err, const void *api) {
+	return sqlite3_create_function(db, "sqliterpc_connection", 0, SQLITE_UTF8, 0, connection, 0, 0);
+}
+
+void sqliterpc_auto_extension(void) {
+	sqlite3_auto_extension((void (*)(void))connection_init);
+}
+
+static int authorize(void *state, int action, const char *arg1, const char *arg2, const char *database, const char *trigger) {
+	return sqliterpcAuthorize((uintptr_t)state, action, (char *)arg1, (char *)arg2, (char *)database, (char *)trigger);
+}
+
+int sqliterpc_set_authorizer(long long db, uintptr_t state) {
+	return sqlite3_set_authorizer((sqlite3 *)(uintptr_t)db, authorize, (void *)state);
+}
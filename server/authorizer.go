@@ -0,0 +1,73 @@
+package server
+
+// #include <stdint.h>
+//
+// // defined in authorizer.c
+// void sqliterpc_auto_extension(void);
+// int sqliterpc_set_authorizer(long long db, uintptr_t state);
+import "C"
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"runtime/cgo"
+	"sync"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+var autoExtension sync.Once
+
+// registerAutoExtension gives each connection opened after it is called a
+// function, sqliterpc_connection, that returns the connection's handle.
+// see https://www.sqlite.org/c3ref/auto_extension.html
+func registerAutoExtension() {
+	autoExtension.Do(func() {
+		C.sqliterpc_auto_extension()
+	})
+}
+
+// setAuthorizer sets the authorizer of conn to s.authorize. The authorizer
+// registered by go-sqlite3 is not told which trigger an action is in, which
+// is needed to tell writes by the capture triggers from writes by a principal,
+// so it is set directly on the connection's handle.
+// see https://www.sqlite.org/c3ref/set_authorizer.html
+func (s *connState) setAuthorizer(conn *sqlite3.SQLiteConn) error {
+	rows, err := conn.Query("SELECT sqliterpc_connection()", nil)
+	if err != nil {
+		return err
+	}
+
+	values := make([]driver.Value, 1)
+	err = rows.Next(values)
+	_ = rows.Close()
+
+	if err != nil {
+		return err
+	}
+
+	db, ok := values[0].(int64)
+	if !ok || db == 0 {
+		return fmt.Errorf("unexpected connection handle %v", values[0])
+	}
+
+	s.handle = cgo.NewHandle(s)
+	C.sqliterpc_set_authorizer(C.longlong(db), C.uintptr_t(s.handle))
+
+	return nil
+}
+
+// release frees the handle used by the authorizer. It is called once the
+// connection is closed.
+func (s *connState) release() {
+	if s.handle != 0 {
+		s.handle.Delete()
+		s.handle = 0
+	}
+}
+
+//export sqliterpcAuthorize
+func sqliterpcAuthorize(handle C.uintptr_t, action C.int, arg1, arg2, database, trigger *C.char) C.int {
+	s := cgo.Handle(handle).Value().(*connState)
+	return C.int(s.authorize(int(action), C.GoString(arg1), C.GoString(arg2), C.GoString(database), C.GoString(trigger)))
+}
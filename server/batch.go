@@ -0,0 +1,239 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/auth"
+)
+
+func (s *DatabaseServer) Batch(ctx context.Context, req *sqliterpc.BatchRequest) (*sqliterpc.BatchResponse, error) {
+	// batches run in a write transaction.
+	if err := s.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	steps := make([]*sqliterpc.BatchStep, len(req.Steps))
+
+	for i, step := range req.Steps {
+		switch st := step.Step.(type) {
+		case *sqliterpc.BatchStep_Exec:
+			if st.Exec.TransactionId != "" {
+				return nil, twirp.InvalidArgumentError("transaction_id", "must not be set in batch steps")
+			}
+
+			if st.Exec.StatementId != "" {
+				query, err := s.statements.resolve(principalName(ctx), st.Exec.StatementId)
+				if err != nil {
+					return nil, err
+				}
+
+				e := proto.Clone(st.Exec).(*sqliterpc.ExecRequest)
+				e.Sql = query
+				step = &sqliterpc.BatchStep{Step: &sqliterpc.BatchStep_Exec{Exec: e}}
+			}
+		case *sqliterpc.BatchStep_Query:
+			if st.Query.TransactionId != "" {
+				return nil, twirp.InvalidArgumentError("transaction_id", "must not be set in batch steps")
+			}
+
+			if st.Query.StatementId != "" {
+				query, err := s.statements.resolve(principalName(ctx), st.Query.StatementId)
+				if err != nil {
+					return nil, err
+				}
+
+				q := proto.Clone(st.Query).(*sqliterpc.QueryRequest)
+				q.Sql = query
+				step = &sqliterpc.BatchStep{Step: &sqliterpc.BatchStep_Query{Query: q}}
+			}
+		default:
+			return nil, twirp.InvalidArgumentError("step", "must be exec or query")
+		}
+
+		steps[i] = step
+	}
+
+	if err := s.holds.check(s.writer); err != nil {
+		return nil, err
+	}
+
+	conn, err := s.writer.Conn(ctx)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return nil, wrapError(err)
+	}
+
+	if p := s.policies.policy(auth.FromContext(ctx)); p != nil {
+		if err := setPolicy(conn, p); err != nil {
+			_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+			return nil, wrapError(err)
+		}
+
+		defer func() {
+			_ = setPolicy(conn, nil)
+		}()
+	}
+
+	resp := sqliterpc.BatchResponse{
+		Results: make([]*sqliterpc.BatchResult, 0, len(req.Steps)),
+	}
+
+	// size is the encoded size of the response, which is limited like a
+	// query response.
+	size := int64(proto.Size(&sqliterpc.BatchResponse{Committed: true}))
+
+	for _, step := range steps {
+		var left int64
+		if s.limits.maxBytes > 0 {
+			left = s.limits.maxBytes - size - batchResultOverhead
+			if left <= 0 {
+				left = 1
+			}
+		}
+
+		result, err := s.batchStep(ctx, conn, step, req.ContinueOnError, left)
+		if err != nil {
+			_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+			return nil, err
+		}
+
+		size += int64(protowire.SizeTag(1) + protowire.SizeBytes(proto.Size(result)))
+
+		if s.limits.maxBytes > 0 && size > s.limits.maxBytes {
+			_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+			return nil, twirp.NewError(twirp.ResourceExhausted,
+				fmt.Sprintf("batch response is larger than %d bytes", s.limits.maxBytes))
+		}
+
+		resp.Results = append(resp.Results, result)
+
+		if result.GetError() != nil && !req.ContinueOnError {
+			if _, err := conn.ExecContext(ctx, "ROLLBACK"); err != nil {
+				return nil, wrapError(err)
+			}
+
+			return &resp, nil
+		}
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+		return nil, wrapError(err)
+	}
+
+	resp.Committed = true
+
+	return &resp, nil
+}
+
+// batchResultOverhead is the most a result adds to the encoded size of a
+// batch response, beyond the size of the query response it holds.
+const batchResultOverhead = 2 * (1 + binary.MaxVarintLen32)
+
+// batchStep runs a single step. Failures of the step itself are returned in the result.
+// When savepoint is true, the step is wrapped in a savepoint so a failure only
+// undoes the step rather than the entire batch. Each step has its own timeout.
+// A query's response is limited to maxBytes, unless it is zero.
+func (s *DatabaseServer) batchStep(ctx context.Context, conn *sql.Conn, step *sqliterpc.BatchStep, savepoint bool, maxBytes int64) (*sqliterpc.BatchResult, error) {
+	if savepoint {
+		if _, err := conn.ExecContext(ctx, "SAVEPOINT batch_step"); err != nil {
+			return nil, wrapError(err)
+		}
+	}
+
+	var (
+		result sqliterpc.BatchResult
+		err    error
+	)
+
+	switch st := step.Step.(type) {
+	case *sqliterpc.BatchStep_Exec:
+		var resp *sqliterpc.ExecResponse
+		resp, err = s.execStep(ctx, conn, st.Exec)
+		if err == nil {
+			result.Result = &sqliterpc.BatchResult_Exec{Exec: resp}
+		}
+
+	case *sqliterpc.BatchStep_Query:
+		var resp *sqliterpc.QueryResponse
+		resp, err = s.queryStep(ctx, conn, st.Query, maxBytes)
+		if err == nil {
+			result.Result = &sqliterpc.BatchResult_Query{Query: resp}
+		}
+	}
+
+	if err != nil {
+		result.Result = &sqliterpc.BatchResult_Error{Error: batchError(err)}
+
+		if savepoint {
+			if _, err := conn.ExecContext(ctx, "ROLLBACK TO batch_step"); err != nil {
+				return nil, wrapError(err)
+			}
+		}
+	}
+
+	if savepoint {
+		if _, err := conn.ExecContext(ctx, "RELEASE batch_step"); err != nil {
+			return nil, wrapError(err)
+		}
+	}
+
+	return &result, nil
+}
+
+func (s *DatabaseServer) execStep(ctx context.Context, conn *sql.Conn, req *sqliterpc.ExecRequest) (*sqliterpc.ExecResponse, error) {
+	timeout, err := s.limits.requestTimeout(req.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+
+	return exec(ctx, conn, req)
+}
+
+func (s *DatabaseServer) queryStep(ctx context.Context, conn *sql.Conn, req *sqliterpc.QueryRequest, maxBytes int64) (*sqliterpc.QueryResponse, error) {
+	timeout, err := s.limits.requestTimeout(req.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := s.limits.rowLimits(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// the response is part of the batch response.
+	limits.maxBytes = tighter(limits.maxBytes, maxBytes)
+
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+
+	return query(ctx, conn, req, limits)
+}
+
+func batchError(err error) *sqliterpc.BatchError {
+	twerr := wrapError(err)
+
+	e := sqliterpc.BatchError{
+		Code:    string(twerr.Code()),
+		Message: twerr.Msg(),
+		Meta:    twerr.MetaMap(),
+	}
+
+	return &e
+}
@@ -0,0 +1,117 @@
+package server_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/server"
+)
+
+func TestBatch(t *testing.T) {
+	file := "batch.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file)
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = s.Exec(
+		ctx,
+		&sqliterpc.ExecRequest{
+			Sql: `create table testing (intCol INTEGER PRIMARY KEY)`,
+		},
+	)
+	require.NoError(t, err)
+
+	insert := func(i int64) *sqliterpc.BatchStep {
+		return &sqliterpc.BatchStep{
+			Step: &sqliterpc.BatchStep_Exec{
+				Exec: &sqliterpc.ExecRequest{
+					Sql: `insert into testing (intCol) values(?)`,
+					Parameters: []*sqliterpc.Value{
+						{
+							Kind: &sqliterpc.Value_IntegerValue{
+								IntegerValue: &sqliterpc.IntergerValue{
+									Value: i,
+									Valid: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	selectAll := &sqliterpc.BatchStep{
+		Step: &sqliterpc.BatchStep_Query{
+			Query: &sqliterpc.QueryRequest{
+				Sql: `select intCol from testing`,
+			},
+		},
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		resp, err := s.Batch(ctx, &sqliterpc.BatchRequest{
+			Steps: []*sqliterpc.BatchStep{insert(1), insert(2), selectAll},
+		})
+		require.NoError(t, err)
+		require.True(t, resp.Committed)
+		require.Len(t, resp.Results, 3)
+
+		require.Equal(t, int64(1), resp.Results[0].GetExec().RowsAffected)
+		require.Equal(t, int64(1), resp.Results[1].GetExec().RowsAffected)
+		require.Len(t, resp.Results[2].GetQuery().Rows, 2)
+	})
+
+	t.Run("stop on error", func(t *testing.T) {
+		resp, err := s.Batch(ctx, &sqliterpc.BatchRequest{
+			// 1 is a duplicate
+			Steps: []*sqliterpc.BatchStep{insert(3), insert(1), insert(4)},
+		})
+		require.NoError(t, err)
+		require.False(t, resp.Committed)
+		require.Len(t, resp.Results, 2)
+		require.NotNil(t, resp.Results[1].GetError())
+
+		query, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select intCol from testing`})
+		require.NoError(t, err)
+		require.Len(t, query.Rows, 2)
+	})
+
+	t.Run("continue on error", func(t *testing.T) {
+		resp, err := s.Batch(ctx, &sqliterpc.BatchRequest{
+			Steps:           []*sqliterpc.BatchStep{insert(3), insert(1), insert(4), selectAll},
+			ContinueOnError: true,
+		})
+		require.NoError(t, err)
+		require.True(t, resp.Committed)
+		require.Len(t, resp.Results, 4)
+
+		require.NotNil(t, resp.Results[0].GetExec())
+		require.NotNil(t, resp.Results[1].GetError())
+		require.NotNil(t, resp.Results[2].GetExec())
+		require.Len(t, resp.Results[3].GetQuery().Rows, 4)
+	})
+
+	t.Run("transaction id", func(t *testing.T) {
+		step := insert(5)
+		step.GetExec().TransactionId = "nope"
+
+		_, err := s.Batch(ctx, &sqliterpc.BatchRequest{
+			Steps: []*sqliterpc.BatchStep{step},
+		})
+		require.Error(t, err)
+		require.Equal(t, twirp.InvalidArgument, err.(twirp.Error).Code())
+	})
+}
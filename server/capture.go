@@ -0,0 +1,433 @@
+package server
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+
+	"github.com/bakins/sqliterpc"
+)
+
+// Changes are captured by temporary triggers on each table, which copy the
+// changed row into a temporary table. Temporary objects are private to
+// the connection, and writes to them are part of the transaction that made
+// the change, so changes undone by a failed statement, a rollback to a
+// savepoint, or a rollback are never published. Rows are copied when the
+// trigger runs, so each change has the row as it was written, not as it is
+// when the change is sent.
+//
+// The update hook is not used, as it is called as rows change, and is not
+// told when the changes are undone. The trade off is that rows written by the
+// triggers are counted by total_changes(), though not by changes() or
+// last_insert_rowid(), which do not count changes made by triggers. Scripts
+// only use total_changes() to tell if a statement changed rows, which the
+// triggers only write to when it did, but principals that call
+// total_changes() see them. Principals may not use the table, triggers, or
+// functions that capture changes.
+// see https://www.sqlite.org/c3ref/update_hook.html
+// see https://www.sqlite.org/c3ref/total_changes.html
+//
+// Triggers are created for tables with rowids. Tables without rowids,
+// tables with a column named rowid, and virtual tables are not captured.
+// see https://www.sqlite.org/lang_createtrigger.html
+const captureSchema = `CREATE TEMP TABLE IF NOT EXISTS sqliterpc_changes (
+	id INTEGER PRIMARY KEY,
+	tbl INTEGER NOT NULL,
+	op INTEGER NOT NULL,
+	rid INTEGER NOT NULL,
+	idx INTEGER NOT NULL,
+	value
+)`
+
+// captureTable is a table whose changes are captured. Triggers identify
+// the table by its index.
+type captureTable struct {
+	name    string
+	columns []*sqliterpc.Column
+}
+
+// registerChangeHooks prepares conn to capture changes. Hooks are called by
+// the goroutine using the connection, so the state does not need a lock.
+// see https://www.sqlite.org/c3ref/commit_hook.html
+func (s *connState) registerChangeHooks(conn *sqlite3.SQLiteConn) error {
+	if err := conn.RegisterFunc("sqliterpc_capturing", s.capturing, false); err != nil {
+		return err
+	}
+
+	if _, err := conn.Exec(captureSchema, nil); err != nil {
+		return err
+	}
+
+	// the commit hook is called before the commit is visible to other
+	// connections, so changes are published once the statement completes.
+	conn.RegisterCommitHook(func() int {
+		s.commits = true
+
+		// zero allows the commit
+		return 0
+	})
+
+	return nil
+}
+
+// capturing is called by the triggers for each changed row.
+func (s *connState) capturing() bool {
+	if !s.changes.capturing() {
+		return false
+	}
+
+	s.captured = true
+
+	return true
+}
+
+// beforeStatement makes sure changes made by query are captured. Triggers
+// are created again in autocommit mode after the schema changes. If they
+// are out of date in a transaction, or cannot be created, changes made by
+// the transaction may not be captured, so when it commits subscribers are
+// told the schema may have changed instead.
+func (s *connState) beforeStatement(query string) {
+	s.dropping = ""
+
+	if s.changes == nil {
+		return
+	}
+
+	if s.altersTable(query) {
+		s.uncaptured = true
+
+		if err := s.withoutPolicy(s.dropTriggers); err != nil {
+			return
+		}
+
+		s.triggersReady = false
+
+		return
+	}
+
+	generation := atomic.LoadUint64(&s.schema.generation)
+	if s.triggersReady && s.triggers == generation {
+		return
+	}
+
+	if !s.conn.AutoCommit() {
+		s.uncaptured = true
+		return
+	}
+
+	// creating triggers commits, which is not a change to publish.
+	commits := s.commits
+
+	err := s.withoutPolicy(func() error {
+		return s.createTriggers(generation)
+	})
+
+	s.commits = commits
+
+	if err != nil {
+		s.uncaptured = true
+	}
+}
+
+// altersTable returns true if query alters a table in main. sqlite checks
+// that triggers are still valid after a column is dropped, so the capture
+// triggers must be dropped first. The authorizer is told of each ALTER TABLE
+// as it is prepared, so sql that mentions alter is prepared to find out,
+// rather than matched, as alter may be in strings or names. Statements that
+// cannot be prepared fail when run, or use tables created by earlier
+// statements, which do not have triggers yet.
+// see https://www.sqlite.org/lang_altertable.html#alter_table_drop_column
+func (s *connState) altersTable(query string) bool {
+	if !strings.Contains(strings.ToLower(query), "alter") {
+		return false
+	}
+
+	defer func() {
+		s.altered = false
+	}()
+
+	for rest := query; !emptyStatement(rest); {
+		var statement string
+		statement, rest = splitStatement(rest)
+
+		if emptyStatement(statement) {
+			continue
+		}
+
+		s.altered = false
+
+		stmt, err := s.conn.Prepare(statement)
+		if err != nil {
+			continue
+		}
+
+		_ = stmt.Close()
+
+		if s.altered {
+			return true
+		}
+	}
+
+	return false
+}
+
+// createTriggers replaces the triggers with triggers for the tables in main.
+func (s *connState) createTriggers(generation uint64) error {
+	tables, err := s.captureTables()
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.conn.Exec("SAVEPOINT sqliterpc_triggers", nil); err != nil {
+		return err
+	}
+
+	err = s.dropTriggers()
+
+	for i := 0; err == nil && i < len(tables); i++ {
+		for _, trigger := range captureTriggers(i, tables[i]) {
+			if _, err = s.conn.Exec(trigger, nil); err != nil {
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		_, _ = s.conn.Exec("ROLLBACK TO sqliterpc_triggers", nil)
+		_, _ = s.conn.Exec("RELEASE sqliterpc_triggers", nil)
+		return err
+	}
+
+	if _, err := s.conn.Exec("RELEASE sqliterpc_triggers", nil); err != nil {
+		return err
+	}
+
+	s.tables = tables
+	s.triggers = generation
+	s.triggersReady = true
+
+	return nil
+}
+
+// dropTriggers drops the capture triggers.
+func (s *connState) dropTriggers() error {
+	names, err := s.strings(`SELECT name FROM sqlite_temp_master WHERE type = 'trigger' AND name LIKE 'sqliterpc\_%' ESCAPE '\'`)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if _, err := s.conn.Exec("DROP TRIGGER temp."+quoteIdentifier(name), nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// captureTables returns the tables in main that can be captured.
+// see https://www.sqlite.org/pragma.html#pragma_table_list
+func (s *connState) captureTables() ([]captureTable, error) {
+	names, err := s.strings(`SELECT name FROM pragma_table_list WHERE schema = 'main' AND type = 'table' AND wr = 0
+		AND name NOT LIKE 'sqlite\_%' ESCAPE '\' ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []captureTable
+
+	for _, name := range names {
+		// generated columns are computed by the reader, and hidden columns
+		// only exist in virtual tables.
+		// see https://www.sqlite.org/pragma.html#pragma_table_xinfo
+		columns, err := s.strings(`SELECT name FROM pragma_table_xinfo(?) WHERE hidden = 0 ORDER BY cid`, name)
+		if err != nil {
+			return nil, err
+		}
+
+		table := captureTable{
+			name: name,
+		}
+
+		for _, column := range columns {
+			if strings.EqualFold(column, "rowid") {
+				table.columns = nil
+				break
+			}
+
+			// values are copied as they are stored.
+			table.columns = append(table.columns, &sqliterpc.Column{
+				Name:    column,
+				Dynamic: true,
+			})
+		}
+
+		if len(table.columns) > 0 {
+			tables = append(tables, table)
+		}
+	}
+
+	return tables, nil
+}
+
+// captureTriggers returns the statements that create the triggers for the
+// table with index. Inserts and updates copy each value of the row into a
+// row of sqliterpc_changes. The row is read from the table, rather than
+// new, so it includes changes made by other triggers that have already run.
+func captureTriggers(index int, table captureTable) []string {
+	name := quoteIdentifier(table.name)
+	tbl := strconv.Itoa(index)
+
+	cases := make([]string, len(table.columns))
+	indexes := make([]string, len(table.columns))
+
+	for i, column := range table.columns {
+		cases[i] = fmt.Sprintf("WHEN %d THEN r.%s", i, quoteIdentifier(column.Name))
+		indexes[i] = fmt.Sprintf("(%d)", i)
+	}
+
+	copyRow := func(op sqliterpc.ChangeOperation) string {
+		return `INSERT INTO sqliterpc_changes (tbl, op, rid, idx, value)
+			SELECT ` + tbl + `, ` + strconv.Itoa(int(op)) + `, r.rowid, c.column1, CASE c.column1 ` + strings.Join(cases, " ") + ` END
+			FROM main.` + name + ` AS r, (VALUES ` + strings.Join(indexes, ", ") + `) AS c
+			WHERE r.rowid = new.rowid ORDER BY c.column1;`
+	}
+
+	deleteRow := func(where string) string {
+		return `INSERT INTO sqliterpc_changes (tbl, op, rid, idx)
+			SELECT ` + tbl + `, ` + strconv.Itoa(int(sqliterpc.ChangeOperation_CHANGE_OPERATION_DELETE)) + `, old.rowid, 0` + where + `;`
+	}
+
+	trigger := func(event string, body string) string {
+		return `CREATE TEMP TRIGGER ` + quoteIdentifier("sqliterpc_"+tbl+"_"+strings.ToLower(event)) +
+			` AFTER ` + event + ` ON main.` + name + ` WHEN sqliterpc_capturing() BEGIN ` + body + ` END`
+	}
+
+	return []string{
+		trigger("INSERT", copyRow(sqliterpc.ChangeOperation_CHANGE_OPERATION_INSERT)),
+		// an update that changes the rowid moves the row.
+		trigger("UPDATE", deleteRow(" WHERE old.rowid IS NOT new.rowid")+copyRow(sqliterpc.ChangeOperation_CHANGE_OPERATION_UPDATE)),
+		trigger("DELETE", deleteRow("")),
+	}
+}
+
+// readChanges reads and removes the captured changes, in the order they were made.
+func (s *connState) readChanges() ([]*sqliterpc.Change, error) {
+	rows, err := s.conn.Query("SELECT id, tbl, op, rid, idx, value FROM sqliterpc_changes ORDER BY id", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		changes []*sqliterpc.Change
+		last    int64
+	)
+
+	values := make([]driver.Value, 6)
+
+	for {
+		if err := rows.Next(values); err != nil {
+			_ = rows.Close()
+
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		last, _ = values[0].(int64)
+		tbl, _ := values[1].(int64)
+		op, _ := values[2].(int64)
+		rowid, _ := values[3].(int64)
+		idx, _ := values[4].(int64)
+
+		if idx == 0 {
+			if tbl < 0 || tbl >= int64(len(s.tables)) {
+				_ = rows.Close()
+				return nil, fmt.Errorf("change to unknown table %d", tbl)
+			}
+
+			change := sqliterpc.Change{
+				Table:     s.tables[tbl].name,
+				Operation: sqliterpc.ChangeOperation(op),
+				Rowid:     rowid,
+			}
+
+			if change.Operation != sqliterpc.ChangeOperation_CHANGE_OPERATION_DELETE {
+				change.Columns = s.tables[tbl].columns
+				change.Row = &sqliterpc.ListValue{}
+			}
+
+			changes = append(changes, &change)
+		}
+
+		if change := changes[len(changes)-1]; change.Row != nil {
+			value, err := dynamicValue(values[5])
+			if err != nil {
+				_ = rows.Close()
+				return nil, err
+			}
+
+			change.Row.Values = append(change.Row.Values, value)
+		}
+	}
+
+	if last == 0 {
+		return nil, nil
+	}
+
+	result, err := s.conn.Exec("DELETE FROM sqliterpc_changes WHERE id <= ?", []driver.Value{last})
+	if err != nil {
+		return nil, err
+	}
+
+	// the rows removed are not changes made by the connection's user.
+	removed, _ := result.RowsAffected()
+	s.internalChanges += removed
+
+	return changes, nil
+}
+
+// strings runs query and returns the first column of each row.
+func (s *connState) strings(query string, args ...driver.Value) ([]string, error) {
+	rows, err := s.conn.Query(query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var names []string
+
+	values := make([]driver.Value, len(rows.Columns()))
+
+	for {
+		if err := rows.Next(values); err != nil {
+			if err == io.EOF {
+				return names, nil
+			}
+			return nil, err
+		}
+
+		name, _ := values[0].(string)
+		names = append(names, name)
+	}
+}
+
+// withoutPolicy calls fn with the policy unset, for statements run by the
+// server rather than a principal.
+func (s *connState) withoutPolicy(fn func() error) error {
+	p, internal := s.policy, s.internal
+	s.policy, s.internal = nil, true
+
+	defer func() {
+		s.policy, s.internal = p, internal
+	}()
+
+	return fn()
+}
@@ -0,0 +1,310 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/auth"
+)
+
+// subscriberBuffer is the number of change sets buffered for each subscriber.
+// Subscribers that fall further behind are disconnected rather than slowing writes.
+const subscriberBuffer = 1024
+
+// changeFeed publishes the changes of committed transactions to subscribers.
+type changeFeed struct {
+	lock        sync.Mutex
+	sequence    uint64
+	generation  uint64
+	subscribers map[*subscriber]struct{}
+	// active is the number of subscribers. Changes are only captured while it is not zero.
+	active int32
+	closed bool
+}
+
+type subscriber struct {
+	// tables is empty to receive changes to all tables that are not denied.
+	tables map[string]struct{}
+	deny   map[string]struct{}
+	sets   chan *sqliterpc.ChangeSet
+	// done is closed when the subscriber is removed.
+	done chan struct{}
+	err  error
+}
+
+func newChangeFeed(generation uint64) *changeFeed {
+	f := changeFeed{
+		subscribers: make(map[*subscriber]struct{}),
+		generation:  generation,
+	}
+
+	return &f
+}
+
+func (f *changeFeed) capturing() bool {
+	return atomic.LoadInt32(&f.active) > 0
+}
+
+func (f *changeFeed) len() int {
+	return int(atomic.LoadInt32(&f.active))
+}
+
+func (f *changeFeed) subscribe(tables []string, deny map[string]struct{}) (*subscriber, error) {
+	sub := subscriber{
+		tables: make(map[string]struct{}, len(tables)),
+		deny:   deny,
+		sets:   make(chan *sqliterpc.ChangeSet, subscriberBuffer),
+		done:   make(chan struct{}),
+	}
+
+	for _, table := range tables {
+		sub.tables[strings.ToLower(table)] = struct{}{}
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.closed {
+		return nil, twirp.NewError(twirp.Unavailable, "server is closed")
+	}
+
+	f.subscribers[&sub] = struct{}{}
+	atomic.AddInt32(&f.active, 1)
+
+	return &sub, nil
+}
+
+// remove removes sub. lock must be held.
+func (f *changeFeed) remove(sub *subscriber, err error) {
+	if _, ok := f.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(f.subscribers, sub)
+	atomic.AddInt32(&f.active, -1)
+
+	sub.err = err
+	close(sub.done)
+}
+
+func (f *changeFeed) unsubscribe(sub *subscriber) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.remove(sub, nil)
+}
+
+// publish sends the changes of a committed transaction to subscribers.
+// generation is the schema generation after the commit. Schema changes are
+// not captured, so subscribers are told the schema may have changed instead.
+// It does not block.
+func (f *changeFeed) publish(changes []*sqliterpc.Change, generation uint64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	schemaChanged := generation != f.generation
+	f.generation = generation
+
+	if len(changes) == 0 && !schemaChanged {
+		return
+	}
+
+	f.sequence++
+
+	for sub := range f.subscribers {
+		matched := sub.filter(changes)
+		if len(matched) == 0 && !schemaChanged {
+			continue
+		}
+
+		set := sqliterpc.ChangeSet{
+			Sequence:      f.sequence,
+			Changes:       matched,
+			SchemaChanged: schemaChanged,
+		}
+
+		select {
+		case sub.sets <- &set:
+		default:
+			f.remove(sub, twirp.NewError(twirp.ResourceExhausted, "subscriber fell too far behind"))
+		}
+	}
+}
+
+func (f *changeFeed) close() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.closed = true
+
+	for sub := range f.subscribers {
+		f.remove(sub, twirp.NewError(twirp.Unavailable, "server is closed"))
+	}
+}
+
+func (s *subscriber) filter(changes []*sqliterpc.Change) []*sqliterpc.Change {
+	var matched []*sqliterpc.Change
+
+	for _, change := range changes {
+		table := strings.ToLower(change.Table)
+
+		if _, ok := s.deny[table]; ok {
+			continue
+		}
+
+		if len(s.tables) > 0 {
+			if _, ok := s.tables[table]; !ok {
+				continue
+			}
+		}
+
+		matched = append(matched, change)
+	}
+
+	return matched
+}
+
+// publishChanges publishes committed changes. It is called after each
+// statement, and only publishes once the connection is not in a transaction,
+// as a single call may run statements after a commit.
+func (s *connState) publishChanges() {
+	if !s.commits || !s.conn.AutoCommit() {
+		return
+	}
+
+	var changes []*sqliterpc.Change
+
+	if s.captured {
+		err := s.withoutPolicy(func() error {
+			var err error
+			changes, err = s.readChanges()
+			return err
+		})
+		if err != nil {
+			s.uncaptured = true
+		}
+	}
+
+	s.checkSchema()
+
+	// subscribers are told the schema may have changed when changes may be
+	// missing, so replicas copy the database again.
+	if s.uncaptured {
+		atomic.AddUint64(&s.schema.generation, 1)
+	}
+
+	s.changes.publish(changes, atomic.LoadUint64(&s.schema.generation))
+
+	// reading the changes commits.
+	s.commits = false
+	s.captured = false
+	s.uncaptured = false
+}
+
+func (s *DatabaseServer) serveSubscribe(ctx context.Context, sw *streamWriter, m proto.Message) error {
+	req := m.(*sqliterpc.SubscribeRequest)
+
+	var deny map[string]struct{}
+
+	if p := s.policies.policy(auth.FromContext(ctx)); p != nil {
+		deny = p.denyTables
+
+		for _, table := range req.Tables {
+			if _, ok := deny[strings.ToLower(table)]; ok {
+				return twirp.NewError(twirp.PermissionDenied, "not allowed by policy").WithMeta("table", table)
+			}
+		}
+	}
+
+	sub, err := s.changes.subscribe(req.Tables, deny)
+	if err != nil {
+		return err
+	}
+
+	defer s.changes.unsubscribe(sub)
+
+	sw.start()
+
+	started := sqliterpc.SubscribeFrame{
+		Frame: &sqliterpc.SubscribeFrame_Started{
+			Started: &sqliterpc.SubscribeStarted{},
+		},
+	}
+
+	if err := sw.write(&started); err != nil {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case set := <-sub.sets:
+			if !req.IncludeRows {
+				set = withoutRows(set)
+			}
+
+			frame := sqliterpc.SubscribeFrame{
+				Frame: &sqliterpc.SubscribeFrame_Changes{
+					Changes: set,
+				},
+			}
+
+			if err := sw.write(&frame); err != nil {
+				return nil
+			}
+		case <-sub.done:
+			if sub.err != nil {
+				writeSubscribeError(sw, sub.err)
+			}
+			return sub.err
+		}
+	}
+}
+
+// withoutRows returns a copy of set without the rows of its changes,
+// which are shared by subscribers.
+func withoutRows(set *sqliterpc.ChangeSet) *sqliterpc.ChangeSet {
+	changes := make([]*sqliterpc.Change, len(set.Changes))
+
+	for i, change := range set.Changes {
+		changes[i] = &sqliterpc.Change{
+			Table:     change.Table,
+			Operation: change.Operation,
+			Rowid:     change.Rowid,
+		}
+	}
+
+	return &sqliterpc.ChangeSet{
+		Sequence:      set.Sequence,
+		Changes:       changes,
+		SchemaChanged: set.SchemaChanged,
+	}
+}
+
+func writeSubscribeError(sw *streamWriter, err error) {
+	twerr := wrapError(err)
+
+	frame := sqliterpc.SubscribeFrame{
+		Frame: &sqliterpc.SubscribeFrame_Error{
+			Error: &sqliterpc.StreamError{
+				Code:    string(twerr.Code()),
+				Message: twerr.Msg(),
+				Meta:    twerr.MetaMap(),
+			},
+		},
+	}
+
+	_ = sw.write(&frame)
+}
+
+// see https://www.sqlite.org/lang_keywords.html
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
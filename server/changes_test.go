@@ -0,0 +1,297 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/auth"
+	"github.com/bakins/sqliterpc/server"
+)
+
+func TestSubscribe(t *testing.T) {
+	policies := server.Policies{
+		Roles: map[string]server.Policy{
+			"analytics": {DenyTables: []string{"users"}},
+		},
+	}
+
+	s, err := server.New(filepath.Join(t.TempDir(), "subscribe.db"), server.WithPolicies(&policies))
+	require.NoError(t, err)
+
+	handler := server.NewHandler(s)
+	analyst := &auth.Principal{Name: "analyst", Roles: []string{"analytics"}}
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(auth.ToContext(r.Context(), analyst)))
+	}))
+	defer svr.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	for _, statement := range []string{
+		`create table users (id INTEGER PRIMARY KEY, name TEXT)`,
+		`create table orders (id INTEGER PRIMARY KEY, user_id INTEGER)`,
+	} {
+		_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: statement})
+		require.NoError(t, err)
+	}
+
+	subscribe := func(req *sqliterpc.SubscribeRequest) *http.Response {
+		body, err := proto.Marshal(req)
+		require.NoError(t, err)
+
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, svr.URL+sqliterpc.SubscribePath, bytes.NewReader(body))
+		require.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(httpReq)
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	t.Run("denied table", func(t *testing.T) {
+		resp := subscribe(&sqliterpc.SubscribeRequest{Tables: []string{"Users"}})
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	resp := subscribe(&sqliterpc.SubscribeRequest{})
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var frame sqliterpc.SubscribeFrame
+
+	require.NoError(t, sqliterpc.ReadMessage(resp.Body, &frame))
+	require.NotNil(t, frame.GetStarted())
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `insert into users (id, name) values (1, 'one')`})
+	require.NoError(t, err)
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `insert into orders (id, user_id) values (1, 1)`})
+	require.NoError(t, err)
+
+	// changes to denied tables are not sent
+	require.NoError(t, sqliterpc.ReadMessage(resp.Body, &frame))
+	changes := frame.GetChanges()
+	require.NotNil(t, changes)
+	require.Len(t, changes.Changes, 1)
+	require.Equal(t, "orders", changes.Changes[0].Table)
+	require.Equal(t, int64(1), changes.Changes[0].Rowid)
+	require.Nil(t, changes.Changes[0].Row)
+
+	// subscriptions end when the server is closed
+	require.NoError(t, s.Close())
+
+	require.NoError(t, sqliterpc.ReadMessage(resp.Body, &frame))
+	require.NotNil(t, frame.GetError())
+	require.Equal(t, string(twirp.Unavailable), frame.GetError().Code)
+}
+
+func TestSubscribeRows(t *testing.T) {
+	s, err := server.New(filepath.Join(t.TempDir(), "rows.db"))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	svr := httptest.NewServer(server.NewHandler(s))
+	defer svr.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	exec := func(sql string) {
+		_, err := s.Exec(ctx, &sqliterpc.ExecRequest{Sql: sql})
+		require.NoError(t, err)
+	}
+
+	exec(`create table users (id INTEGER PRIMARY KEY, name TEXT, avatar BLOB)`)
+
+	resp := startSubscription(ctx, t, svr.URL, &sqliterpc.SubscribeRequest{IncludeRows: true})
+	defer resp.Body.Close()
+
+	var frame sqliterpc.SubscribeFrame
+
+	exec(`insert into users (id, name, avatar) values (1, 'one', x'01')`)
+	exec(`update users set name = 'uno', avatar = NULL where id = 1`)
+	exec(`delete from users where id = 1`)
+
+	// each change has the row as it was committed, not as it is when sent.
+	var changes []*sqliterpc.Change
+
+	for len(changes) < 3 {
+		require.NoError(t, sqliterpc.ReadMessage(resp.Body, &frame))
+		require.NotNil(t, frame.GetChanges())
+		changes = append(changes, frame.GetChanges().Changes...)
+	}
+
+	require.Equal(t, sqliterpc.ChangeOperation_CHANGE_OPERATION_INSERT, changes[0].Operation)
+	require.Equal(t, []string{"id", "name", "avatar"}, []string{changes[0].Columns[0].Name, changes[0].Columns[1].Name, changes[0].Columns[2].Name})
+	require.Equal(t, int64(1), changes[0].Row.Values[0].GetIntegerValue().GetValue())
+	require.Equal(t, "one", changes[0].Row.Values[1].GetTextValue().GetValue())
+	require.Equal(t, []byte{1}, changes[0].Row.Values[2].GetBlobValue().GetValue())
+
+	require.Equal(t, sqliterpc.ChangeOperation_CHANGE_OPERATION_UPDATE, changes[1].Operation)
+	require.Equal(t, "uno", changes[1].Row.Values[1].GetTextValue().GetValue())
+	require.NotNil(t, changes[1].Row.Values[2].GetNullValue())
+
+	require.Equal(t, sqliterpc.ChangeOperation_CHANGE_OPERATION_DELETE, changes[2].Operation)
+	require.Equal(t, int64(1), changes[2].Rowid)
+	require.Nil(t, changes[2].Row)
+
+	// sql that only mentions altering and dropping is captured.
+	exec(`insert into users (id, name) values (3, 'please alter the plan and drop it')`)
+
+	require.NoError(t, sqliterpc.ReadMessage(resp.Body, &frame))
+	require.False(t, frame.GetChanges().SchemaChanged)
+	require.Len(t, frame.GetChanges().Changes, 1)
+	require.Equal(t, int64(3), frame.GetChanges().Changes[0].Rowid)
+
+	// the capture triggers do not prevent columns from being dropped.
+	exec(`alter table users drop column avatar`)
+	exec(`insert into users (id, name) values (2, 'two')`)
+
+	require.NoError(t, sqliterpc.ReadMessage(resp.Body, &frame))
+	require.True(t, frame.GetChanges().SchemaChanged)
+
+	if len(frame.GetChanges().Changes) == 0 {
+		require.NoError(t, sqliterpc.ReadMessage(resp.Body, &frame))
+	}
+
+	changes = frame.GetChanges().Changes
+	require.Len(t, changes, 1)
+	require.Len(t, changes[0].Columns, 2)
+	require.Equal(t, "two", changes[0].Row.Values[1].GetTextValue().GetValue())
+}
+
+func TestSubscribeFailedStatement(t *testing.T) {
+	s, err := server.New(filepath.Join(t.TempDir(), "failed.db"))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	svr := httptest.NewServer(server.NewHandler(s))
+	defer svr.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table users (id INTEGER PRIMARY KEY, name TEXT UNIQUE)`})
+	require.NoError(t, err)
+
+	resp := startSubscription(ctx, t, svr.URL, &sqliterpc.SubscribeRequest{})
+	defer resp.Body.Close()
+
+	begin, err := s.Begin(ctx, &sqliterpc.BeginRequest{})
+	require.NoError(t, err)
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `insert into users (id, name) values (1, 'one')`, TransactionId: begin.TransactionId})
+	require.NoError(t, err)
+
+	// the second row conflicts, so sqlite undoes the first before the statement fails.
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `insert into users (id, name) values (2, 'two'), (3, 'one')`, TransactionId: begin.TransactionId})
+	requireCode(t, err, twirp.AlreadyExists)
+
+	_, err = s.Commit(ctx, &sqliterpc.CommitRequest{TransactionId: begin.TransactionId})
+	require.NoError(t, err)
+
+	var frame sqliterpc.SubscribeFrame
+
+	require.NoError(t, sqliterpc.ReadMessage(resp.Body, &frame))
+	require.NotNil(t, frame.GetChanges())
+	require.Len(t, frame.GetChanges().Changes, 1)
+	require.Equal(t, int64(1), frame.GetChanges().Changes[0].Rowid)
+}
+
+func TestSubscribeCaptureDenied(t *testing.T) {
+	s, err := server.New(filepath.Join(t.TempDir(), "capture.db"))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	svr := httptest.NewServer(server.NewHandler(s))
+	defer svr.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	exec := func(sql string) error {
+		_, err := s.Exec(ctx, &sqliterpc.ExecRequest{Sql: sql})
+		return err
+	}
+
+	require.NoError(t, exec(`create table users (id INTEGER PRIMARY KEY, name TEXT)`))
+	require.NoError(t, exec(`create table orders (id INTEGER PRIMARY KEY, user_id INTEGER)`))
+
+	resp := startSubscription(ctx, t, svr.URL, &sqliterpc.SubscribeRequest{})
+	defer resp.Body.Close()
+
+	var frame sqliterpc.SubscribeFrame
+
+	require.NoError(t, exec(`insert into users (id, name) values (1, 'one')`))
+	require.NoError(t, sqliterpc.ReadMessage(resp.Body, &frame))
+	require.Len(t, frame.GetChanges().Changes, 1)
+
+	// changes cannot be forged, read, or stopped from being captured.
+	for _, statement := range []string{
+		`insert into temp.sqliterpc_changes (tbl, op, rid, idx) values (0, 3, 7, 0)`,
+		`select * from sqliterpc_changes`,
+		`delete from temp.sqliterpc_changes`,
+		`drop table temp.sqliterpc_changes`,
+		`drop trigger temp.sqliterpc_1_insert`,
+		`create temp trigger sqliterpc_forge after insert on orders begin select 1; end`,
+		`create temp trigger forge after insert on sqliterpc_changes begin select 1; end`,
+	} {
+		requireCode(t, exec(statement), twirp.PermissionDenied)
+	}
+
+	require.Error(t, exec(`select sqliterpc_capturing()`))
+
+	// the triggers of a table are dropped with it.
+	require.NoError(t, exec(`drop table orders`))
+	require.NoError(t, exec(`insert into users (id, name) values (2, 'two')`))
+
+	var changes []*sqliterpc.Change
+
+	for len(changes) == 0 {
+		require.NoError(t, sqliterpc.ReadMessage(resp.Body, &frame))
+		changes = frame.GetChanges().Changes
+	}
+
+	require.Len(t, changes, 1)
+	require.Equal(t, "users", changes[0].Table)
+	require.Equal(t, int64(2), changes[0].Rowid)
+}
+
+// startSubscription subscribes to the server at url and waits for the subscription to start.
+func startSubscription(ctx context.Context, t *testing.T, url string, req *sqliterpc.SubscribeRequest) *http.Response {
+	t.Helper()
+
+	body, err := proto.Marshal(req)
+	require.NoError(t, err)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url+sqliterpc.SubscribePath, bytes.NewReader(body))
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	require.NoError(t, err)
+
+	var frame sqliterpc.SubscribeFrame
+
+	require.NoError(t, sqliterpc.ReadMessage(resp.Body, &frame))
+	require.NotNil(t, frame.GetStarted())
+
+	return resp
+}
@@ -0,0 +1,315 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"runtime/cgo"
+	"sync/atomic"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+// connector opens sqlite connections that track per connection state.
+type connector struct {
+	dsn string
+	// pragmas are run on each new connection.
+	pragmas []string
+	driver  *sqlite3.SQLiteDriver
+	// changes is nil for connections that do not write.
+	changes *changeFeed
+	// schema is shared by the connectors for the readers and the writer.
+	schema *schemaState
+}
+
+// schemaState tracks changes to the schema of the database.
+type schemaState struct {
+	// generation is incremented when a commit changes the schema.
+	generation uint64
+	// version is the schema version after the last commit.
+	// see https://www.sqlite.org/pragma.html#pragma_schema_version
+	version int64
+}
+
+var _ driver.Connector = &connector{}
+
+func newConnector(dsn string, pragmas []string, changes *changeFeed, schema *schemaState) *connector {
+	registerAutoExtension()
+
+	c := connector{
+		dsn:     dsn,
+		pragmas: pragmas,
+		changes: changes,
+		driver:  &sqlite3.SQLiteDriver{},
+		schema:  schema,
+	}
+
+	return &c
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	state := connState{
+		schema:  c.schema,
+		changes: c.changes,
+	}
+
+	// a driver per connection so the hook can capture the state.
+	d := sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			for _, pragma := range c.pragmas {
+				if _, err := conn.Exec(pragma, nil); err != nil {
+					return err
+				}
+			}
+
+			state.conn = conn
+
+			if c.changes != nil {
+				if err := state.registerChangeHooks(conn); err != nil {
+					return err
+				}
+			}
+
+			return state.setAuthorizer(conn)
+		},
+	}
+
+	conn, err := d.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := sqliteConn{
+		SQLiteConn: conn.(*sqlite3.SQLiteConn),
+		state:      &state,
+	}
+
+	return &sc, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// sqliteConn embeds the sqlite connection, so all of its methods, and
+// therefore the optional database/sql/driver interfaces, are available.
+// Methods that run statements are wrapped to capture changes, and to
+// publish committed changes once the statement completes.
+type sqliteConn struct {
+	*sqlite3.SQLiteConn
+	state *connState
+}
+
+func (c *sqliteConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.state.beforeStatement(query)
+	defer c.state.publishChanges()
+	return c.SQLiteConn.ExecContext(ctx, query, args)
+}
+
+func (c *sqliteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.state.beforeStatement(query)
+
+	rows, err := c.SQLiteConn.QueryContext(ctx, query, args)
+	if err != nil {
+		c.state.publishChanges()
+		return nil, err
+	}
+
+	return &sqliteRows{SQLiteRows: rows.(*sqlite3.SQLiteRows), state: c.state}, nil
+}
+
+func (c *sqliteConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.SQLiteConn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteStmt{SQLiteStmt: stmt.(*sqlite3.SQLiteStmt), state: c.state, query: query}, nil
+}
+
+func (c *sqliteConn) Close() error {
+	defer c.state.release()
+	return c.SQLiteConn.Close()
+}
+
+func (c *sqliteConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.state.beforeStatement("")
+	return c.SQLiteConn.BeginTx(ctx, opts)
+}
+
+type sqliteStmt struct {
+	*sqlite3.SQLiteStmt
+	state *connState
+	query string
+}
+
+func (s *sqliteStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	s.state.beforeStatement(s.query)
+	defer s.state.publishChanges()
+	return s.SQLiteStmt.ExecContext(ctx, args)
+}
+
+func (s *sqliteStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	s.state.beforeStatement(s.query)
+
+	rows, err := s.SQLiteStmt.QueryContext(ctx, args)
+	if err != nil {
+		s.state.publishChanges()
+		return nil, err
+	}
+
+	return &sqliteRows{SQLiteRows: rows.(*sqlite3.SQLiteRows), state: s.state}, nil
+}
+
+// sqliteRows publishes changes when closed, as a statement that
+// returns rows is not complete until then.
+type sqliteRows struct {
+	*sqlite3.SQLiteRows
+	state *connState
+}
+
+func (r *sqliteRows) Close() error {
+	defer r.state.publishChanges()
+	return r.SQLiteRows.Close()
+}
+
+// connState is the state of a single connection.
+type connState struct {
+	conn *sqlite3.SQLiteConn
+	// policy is set while statements are prepared for a restricted principal
+	policy *policy
+	// internal is set while the server runs its own statements.
+	internal bool
+	// handle refers to the state in the authorizer.
+	handle cgo.Handle
+	schema *schemaState
+	// changes is nil if changes are not captured.
+	changes *changeFeed
+	// tables are the tables captured by the triggers, which were created
+	// for the schema generation triggers if triggersReady is set.
+	tables        []captureTable
+	triggers      uint64
+	triggersReady bool
+	// captured is set when a change may have been captured since changes were last read.
+	captured bool
+	// uncaptured is set when changes may have been made without being captured.
+	uncaptured bool
+	// commits is set when a transaction has been committed since changes were last published.
+	commits bool
+	// internalChanges is the number of rows changed by the server's own
+	// statements, rather than the user's.
+	internalChanges int64
+	// altered is set by the authorizer when an ALTER TABLE of a table in main is prepared.
+	altered bool
+	// dropping is the table in main of the last DROP TABLE prepared, whose
+	// capture triggers are dropped with it.
+	dropping string
+}
+
+func (c *connector) generation() uint64 {
+	return atomic.LoadUint64(&c.schema.generation)
+}
+
+// invalidate marks the schema as changed, so prepared statements are removed.
+func (c *connector) invalidate() {
+	atomic.AddUint64(&c.schema.generation, 1)
+}
+
+// checkSchema records the schema version after a commit, and increments the
+// schema generation if it changed. Statements that only prepare, fail, or are
+// rolled back do not change the version.
+func (s *connState) checkSchema() {
+	version, err := s.schemaVersion()
+	if err != nil || atomic.SwapInt64(&s.schema.version, version) != version {
+		// if the version cannot be read, assume the schema changed.
+		atomic.AddUint64(&s.schema.generation, 1)
+	}
+}
+
+// schemaVersion reads the schema version of the connection's database.
+func (s *connState) schemaVersion() (int64, error) {
+	values := make([]driver.Value, 1)
+
+	err := s.withoutPolicy(func() error {
+		rows, err := s.conn.Query("PRAGMA schema_version", nil)
+		if err != nil {
+			return err
+		}
+
+		defer rows.Close()
+
+		return rows.Next(values)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	version, ok := values[0].(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected schema version %v", values[0])
+	}
+
+	return version, nil
+}
+
+var errNotSQLite = errors.New("not a sqlite connection")
+
+// withConnState calls fn with the state of the connection.
+func withConnState(conn *sql.Conn, fn func(*connState)) error {
+	return conn.Raw(func(dc interface{}) error {
+		sc, ok := dc.(*sqliteConn)
+		if !ok {
+			return errNotSQLite
+		}
+
+		fn(sc.state)
+
+		return nil
+	})
+}
+
+// statementInfo describes sql without running it.
+type statementInfo struct {
+	// numInput is the number of parameters, or -1 if the sql has multiple statements.
+	numInput int
+	// readOnly is set if the sql is a single statement that does not write.
+	// see https://www.sqlite.org/c3ref/stmt_readonly.html
+	readOnly bool
+}
+
+// inspect prepares query on conn to describe it. Only the first statement
+// is prepared, as later statements may depend on earlier ones, so sql with
+// multiple statements is never read only.
+func inspect(conn *sql.Conn, query string) (statementInfo, error) {
+	var info statementInfo
+
+	err := conn.Raw(func(dc interface{}) error {
+		sc, ok := dc.(*sqliteConn)
+		if !ok {
+			return errNotSQLite
+		}
+
+		ds, err := sc.Prepare(query)
+		if err != nil {
+			return err
+		}
+
+		defer ds.Close()
+
+		stmt := ds.(*sqlite3.SQLiteStmt)
+
+		info.numInput = stmt.NumInput()
+		info.readOnly = stmt.Readonly()
+
+		if _, tail := splitStatement(query); !emptyStatement(tail) {
+			info.numInput = -1
+			info.readOnly = false
+		}
+
+		return nil
+	})
+
+	return info, err
+}
@@ -0,0 +1,420 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+)
+
+// cursor holds the rows of a paged query between requests.
+type cursor struct {
+	lock          sync.Mutex
+	rows          *sql.Rows
+	cancel        context.CancelFunc
+	columns       []*sqliterpc.Column
+	transactionID string
+	// conn is set when the cursor owns a connection pinned for a policy.
+	conn     *sql.Conn
+	pageSize int
+	// release is called when the cursor is closed, if it holds a writer connection.
+	release func()
+	// timeout limits reading each page.
+	timeout time.Duration
+	limits  rowLimits
+	// pending is true when rows is positioned on a row that has not been returned.
+	pending  bool
+	lastUsed time.Time
+}
+
+type cursors struct {
+	lock    sync.Mutex
+	cursors map[string]*cursor
+	timeout time.Duration
+	max     int
+	holds   *writerHolds
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newCursors(timeout time.Duration, max int, holds *writerHolds) *cursors {
+	c := cursors{
+		cursors: make(map[string]*cursor),
+		timeout: timeout,
+		max:     max,
+		holds:   holds,
+		stop:    make(chan struct{}),
+	}
+
+	if timeout > 0 {
+		c.wg.Add(1)
+		go c.reap()
+	}
+
+	return &c
+}
+
+// query runs a query and returns the first page. If there are more rows,
+// the cursor is kept open. The timeout limits running the query and reading
+// the first page, and then reading each page. db is the pool q uses a
+// connection of, or nil if q is a transaction's connection.
+func (c *cursors) query(q queryer, db *sql.DB, req *sqliterpc.QueryRequest, timeout time.Duration, limits rowLimits) (*sqliterpc.QueryResponse, error) {
+	// the rows outlive the request, so are not tied to its context.
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// expired stops the timer, and returns true if it already canceled the query.
+	expired := func() bool {
+		return false
+	}
+
+	if timeout > 0 {
+		timer := time.AfterFunc(timeout, cancel)
+
+		expired = func() bool {
+			return !timer.Stop()
+		}
+	}
+
+	rows, columns, err := startQuery(ctx, q, req)
+	if err != nil {
+		cancel()
+
+		if expired() {
+			return nil, errStatementTimeout()
+		}
+		return nil, err
+	}
+
+	cur := cursor{
+		rows:          rows,
+		cancel:        cancel,
+		columns:       columns,
+		transactionID: req.TransactionId,
+		pageSize:      int(req.PageSize),
+		timeout:       timeout,
+		limits:        limits,
+		lastUsed:      time.Now(),
+	}
+
+	// the rows hold the connection until the cursor is closed.
+	if pc, ok := q.(*policyConn); ok {
+		pc.claimed = true
+		cur.conn = pc.Conn
+	}
+
+	resp, more, err := cur.page(cur.pageSize)
+	if expired() {
+		cur.close()
+		return nil, errStatementTimeout()
+	}
+
+	if err != nil || !more {
+		cur.close()
+		return resp, err
+	}
+
+	id, err := newID()
+	if err != nil {
+		cur.close()
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(c.cursors) >= c.max {
+		cur.close()
+		return nil, twirp.NewError(twirp.ResourceExhausted, "too many open cursors")
+	}
+
+	cur.release = c.holds.hold(db)
+	c.cursors[id] = &cur
+	resp.NextPageToken = id
+
+	return resp, nil
+}
+
+func (c *cursors) get(id string) (*cursor, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	cur, ok := c.cursors[id]
+	return cur, ok
+}
+
+func (c *cursors) remove(id string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.cursors, id)
+}
+
+func (c *cursors) len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return len(c.cursors)
+}
+
+// read returns the next page, canceling the rows if reading it takes
+// longer than the timeout. lock must be held if the cursor has been shared.
+func (c *cursor) read(size int) (*sqliterpc.QueryResponse, bool, error) {
+	if c.timeout <= 0 {
+		return c.page(size)
+	}
+
+	timer := time.AfterFunc(c.timeout, c.cancel)
+
+	resp, more, err := c.page(size)
+	if !timer.Stop() {
+		// the rows were canceled, so the cursor cannot be read again.
+		return nil, false, errStatementTimeout()
+	}
+
+	return resp, more, err
+}
+
+func errStatementTimeout() error {
+	return twirp.NewError(twirp.DeadlineExceeded, "statement timeout exceeded")
+}
+
+// page returns up to size rows and whether there are more rows. The page
+// has fewer rows if it would exceed the limits.
+// lock must be held if the cursor has been shared.
+func (c *cursor) page(size int) (*sqliterpc.QueryResponse, bool, error) {
+	resp := sqliterpc.QueryResponse{
+		Columns: c.columns,
+	}
+
+	size = c.limits.pageSize(size)
+	bytes := responseSize(c.columns, true)
+
+	for len(resp.Rows) < size {
+		if !c.pending && !c.rows.Next() {
+			return &resp, false, rowsError(c.rows)
+		}
+
+		row, err := scanRow(c.rows, c.columns)
+		if err != nil {
+			return nil, false, err
+		}
+
+		bytes += rowSize(row)
+
+		if c.limits.maxBytes > 0 && bytes > c.limits.maxBytes {
+			if len(resp.Rows) == 0 {
+				return nil, false, twirp.NewError(twirp.ResourceExhausted,
+					fmt.Sprintf("row is larger than the maximum response size of %d bytes", c.limits.maxBytes))
+			}
+
+			// the row is returned in the next page.
+			c.pending = true
+
+			return &resp, true, nil
+		}
+
+		c.pending = false
+
+		resp.Rows = append(resp.Rows, row)
+	}
+
+	if !c.rows.Next() {
+		return &resp, false, rowsError(c.rows)
+	}
+
+	c.pending = true
+
+	return &resp, true, nil
+}
+
+func rowsError(rows *sql.Rows) error {
+	if err := rows.Err(); err != nil {
+		return wrapError(err)
+	}
+
+	return nil
+}
+
+// close closes the rows. lock must be held if the cursor has been shared.
+func (c *cursor) close() {
+	if c.rows == nil {
+		return
+	}
+
+	_ = c.rows.Close()
+	c.cancel()
+	c.rows = nil
+
+	if c.conn != nil {
+		_ = c.conn.Close()
+		c.conn = nil
+	}
+
+	if c.release != nil {
+		c.release()
+	}
+}
+
+// closeTransaction closes all cursors that belong to a transaction.
+func (c *cursors) closeTransaction(transactionID string) {
+	c.lock.Lock()
+	var closing []*cursor
+	for id, cur := range c.cursors {
+		if cur.transactionID == transactionID {
+			closing = append(closing, cur)
+			delete(c.cursors, id)
+		}
+	}
+	c.lock.Unlock()
+
+	for _, cur := range closing {
+		cur.lock.Lock()
+		cur.close()
+		cur.lock.Unlock()
+	}
+}
+
+func (c *cursors) closeCursor(id string) bool {
+	c.lock.Lock()
+	cur, ok := c.cursors[id]
+	delete(c.cursors, id)
+	c.lock.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	cur.lock.Lock()
+	cur.close()
+	cur.lock.Unlock()
+
+	return true
+}
+
+func (c *cursors) reap() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.timeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+		}
+
+		var expired []string
+
+		c.lock.Lock()
+		for id, cur := range c.cursors {
+			if !cur.lock.TryLock() {
+				// in use
+				continue
+			}
+
+			if time.Since(cur.lastUsed) > c.timeout {
+				expired = append(expired, id)
+			}
+
+			cur.lock.Unlock()
+		}
+		c.lock.Unlock()
+
+		for _, id := range expired {
+			c.closeCursor(id)
+		}
+	}
+}
+
+// close stops reaping and closes all cursors.
+func (c *cursors) close() {
+	close(c.stop)
+	c.wg.Wait()
+
+	c.lock.Lock()
+	ids := make([]string, 0, len(c.cursors))
+	for id := range c.cursors {
+		ids = append(ids, id)
+	}
+	c.lock.Unlock()
+
+	for _, id := range ids {
+		c.closeCursor(id)
+	}
+}
+
+// nextPage returns the next page of a paged query.
+func (s *DatabaseServer) nextPage(req *sqliterpc.QueryRequest) (*sqliterpc.QueryResponse, error) {
+	cur, ok := s.cursors.get(req.PageToken)
+	if !ok {
+		return nil, twirp.NotFoundError("cursor not found")
+	}
+
+	if cur.transactionID != req.TransactionId {
+		return nil, twirp.InvalidArgumentError("transaction_id", "does not match the original query")
+	}
+
+	var resp *sqliterpc.QueryResponse
+
+	read := func() error {
+		cur.lock.Lock()
+		defer cur.lock.Unlock()
+
+		if cur.rows == nil {
+			return twirp.NotFoundError("cursor not found")
+		}
+
+		size := int(req.PageSize)
+		if size <= 0 {
+			size = cur.pageSize
+		}
+
+		page, more, err := cur.read(size)
+		if err != nil || !more {
+			s.cursors.remove(req.PageToken)
+			cur.close()
+
+			if err != nil {
+				return err
+			}
+		} else {
+			page.NextPageToken = req.PageToken
+		}
+
+		cur.lastUsed = time.Now()
+		resp = page
+
+		return nil
+	}
+
+	var err error
+
+	if cur.transactionID == "" {
+		err = read()
+	} else {
+		// hold the transaction while reading
+		err = s.transactions.with(cur.transactionID, func(*transaction) error {
+			return read()
+		})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *DatabaseServer) CloseCursor(ctx context.Context, req *sqliterpc.CloseCursorRequest) (*sqliterpc.CloseCursorResponse, error) {
+	if !s.cursors.closeCursor(req.PageToken) {
+		return nil, twirp.NotFoundError("cursor not found")
+	}
+
+	return &sqliterpc.CloseCursorResponse{}, nil
+}
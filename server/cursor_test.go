@@ -0,0 +1,138 @@
+package server_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/server"
+)
+
+func TestPaging(t *testing.T) {
+	file := "cursor.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file, server.WithMaxCursors(1))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = s.Exec(
+		ctx,
+		&sqliterpc.ExecRequest{
+			Sql: `create table testing (intCol INTEGER)`,
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = s.Exec(
+		ctx,
+		&sqliterpc.ExecRequest{
+			Sql: `insert into testing (intCol) values (1), (2), (3), (4), (5)`,
+		},
+	)
+	require.NoError(t, err)
+
+	t.Run("all pages", func(t *testing.T) {
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{
+			Sql:      `select intCol from testing order by intCol`,
+			PageSize: 2,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 2)
+		require.NotEmpty(t, resp.NextPageToken)
+
+		var values []int64
+
+		for {
+			for _, row := range resp.Rows {
+				values = append(values, row.Values[0].GetIntegerValue().Value)
+			}
+
+			if resp.NextPageToken == "" {
+				break
+			}
+
+			resp, err = s.Query(ctx, &sqliterpc.QueryRequest{
+				PageToken: resp.NextPageToken,
+			})
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, []int64{1, 2, 3, 4, 5}, values)
+	})
+
+	t.Run("exact page", func(t *testing.T) {
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{
+			Sql:      `select intCol from testing`,
+			PageSize: 5,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 5)
+		require.Empty(t, resp.NextPageToken)
+	})
+
+	t.Run("close cursor", func(t *testing.T) {
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{
+			Sql:      `select intCol from testing`,
+			PageSize: 1,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.NextPageToken)
+
+		// only one cursor is allowed
+		_, err = s.Query(ctx, &sqliterpc.QueryRequest{
+			Sql:      `select intCol from testing`,
+			PageSize: 1,
+		})
+		require.Error(t, err)
+		require.Equal(t, twirp.ResourceExhausted, err.(twirp.Error).Code())
+
+		_, err = s.CloseCursor(ctx, &sqliterpc.CloseCursorRequest{PageToken: resp.NextPageToken})
+		require.NoError(t, err)
+
+		_, err = s.Query(ctx, &sqliterpc.QueryRequest{
+			PageToken: resp.NextPageToken,
+		})
+		require.Error(t, err)
+		require.Equal(t, twirp.NotFound, err.(twirp.Error).Code())
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		begin, err := s.Begin(ctx, &sqliterpc.BeginRequest{})
+		require.NoError(t, err)
+
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{
+			Sql:           `select intCol from testing`,
+			PageSize:      1,
+			TransactionId: begin.TransactionId,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.NextPageToken)
+
+		_, err = s.Query(ctx, &sqliterpc.QueryRequest{
+			PageToken: resp.NextPageToken,
+		})
+		require.Error(t, err)
+		require.Equal(t, twirp.InvalidArgument, err.(twirp.Error).Code())
+
+		_, err = s.Commit(ctx, &sqliterpc.CommitRequest{TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+
+		// cursor is closed with the transaction
+		_, err = s.Query(ctx, &sqliterpc.QueryRequest{
+			PageToken:     resp.NextPageToken,
+			TransactionId: begin.TransactionId,
+		})
+		require.Error(t, err)
+		require.Equal(t, twirp.NotFound, err.(twirp.Error).Code())
+	})
+}
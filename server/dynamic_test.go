@@ -0,0 +1,112 @@
+package server_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/server"
+)
+
+func TestDynamicColumns(t *testing.T) {
+	s, err := server.New(filepath.Join(t.TempDir(), "dynamic.db"))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table testing (id INTEGER PRIMARY KEY, untyped, other JSON)`})
+	require.NoError(t, err)
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `insert into testing (untyped, other) values (1, '{}'), (2.5, null), ('three', 3), (x'0004', x'')`})
+	require.NoError(t, err)
+
+	integer := func(v int64) *sqliterpc.Value {
+		return &sqliterpc.Value{Kind: &sqliterpc.Value_IntegerValue{IntegerValue: &sqliterpc.IntergerValue{Value: v, Valid: true}}}
+	}
+
+	double := func(v float64) *sqliterpc.Value {
+		return &sqliterpc.Value{Kind: &sqliterpc.Value_RealValue{RealValue: &sqliterpc.RealValue{Value: v, Valid: true}}}
+	}
+
+	text := func(v string) *sqliterpc.Value {
+		return &sqliterpc.Value{Kind: &sqliterpc.Value_TextValue{TextValue: &sqliterpc.TextValue{Value: v, Valid: true}}}
+	}
+
+	blob := func(v []byte) *sqliterpc.Value {
+		return &sqliterpc.Value{Kind: &sqliterpc.Value_BlobValue{BlobValue: &sqliterpc.BlobValue{Value: v, Valid: true}}}
+	}
+
+	null := &sqliterpc.Value{Kind: &sqliterpc.Value_NullValue{NullValue: &sqliterpc.NullValue{}}}
+
+	tests := []struct {
+		name     string
+		sql      string
+		expected [][]*sqliterpc.Value
+	}{
+		{
+			name:     "expressions",
+			sql:      `select 1 + 1, 1.5 * 2, 'a' || 'b', x'ff', null, coalesce(null, 7)`,
+			expected: [][]*sqliterpc.Value{{integer(2), double(3), text("ab"), blob([]byte{0xff}), null, integer(7)}},
+		},
+		{
+			name:     "aggregates",
+			sql:      `select count(*), sum(id), max(untyped) from testing`,
+			expected: [][]*sqliterpc.Value{{integer(4), integer(10), blob([]byte{0, 4})}},
+		},
+		{
+			name: "untyped columns",
+			sql:  `select untyped, other from testing order by id`,
+			expected: [][]*sqliterpc.Value{
+				{integer(1), text("{}")},
+				{double(2.5), null},
+				{text("three"), integer(3)},
+				{blob([]byte{0, 4}), blob(nil)},
+			},
+		},
+		{
+			name:     "pragma",
+			sql:      `pragma user_version`,
+			expected: [][]*sqliterpc.Value{{integer(0)}},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: test.sql})
+			require.NoError(t, err)
+
+			for _, column := range resp.Columns {
+				require.True(t, column.Dynamic, column.Name)
+				require.Equal(t, sqliterpc.TypeCode_TYPE_CODE_UNSPECIFIED, column.Type, column.Name)
+			}
+
+			require.Len(t, resp.Rows, len(test.expected))
+
+			for i, row := range resp.Rows {
+				require.Len(t, row.Values, len(test.expected[i]))
+
+				for j, value := range row.Values {
+					require.True(t, proto.Equal(test.expected[i][j], value), "row %d column %d: expected %v, got %v", i, j, test.expected[i][j], value)
+				}
+			}
+		})
+	}
+
+	// typed columns are unchanged
+	resp, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select id, untyped from testing order by id limit 1`})
+	require.NoError(t, err)
+
+	require.False(t, resp.Columns[0].Dynamic)
+	require.Equal(t, sqliterpc.TypeCode_TYPE_CODE_INTEGER, resp.Columns[0].Type)
+	require.True(t, resp.Columns[1].Dynamic)
+}
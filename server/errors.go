@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+)
+
+// wrapError converts err to a twirp error. sqlite errors are mapped to
+// an appropriate code, and the sqlite codes are added as meta.
+func wrapError(err error) twirp.Error {
+	var twerr twirp.Error
+	if errors.As(err, &twerr) {
+		return twerr
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return twirp.NewError(twirp.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return twirp.NewError(twirp.DeadlineExceeded, err.Error())
+	}
+
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return twirp.InternalErrorWith(err)
+	}
+
+	return twirp.NewError(errorCode(sqliteErr), err.Error()).
+		WithMeta(sqliterpc.ErrorMetaCode, strconv.Itoa(int(sqliteErr.Code))).
+		WithMeta(sqliterpc.ErrorMetaExtendedCode, strconv.Itoa(int(sqliteErr.ExtendedCode)))
+}
+
+// see https://www.sqlite.org/rescode.html
+func errorCode(err sqlite3.Error) twirp.ErrorCode {
+	switch err.Code {
+	case sqlite3.ErrError, sqlite3.ErrTooBig, sqlite3.ErrMismatch, sqlite3.ErrRange:
+		// SQLITE_ERROR is mostly syntax errors and missing tables or columns
+		return twirp.InvalidArgument
+	case sqlite3.ErrConstraint:
+		switch err.ExtendedCode {
+		case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey, sqlite3.ErrConstraintRowID:
+			return twirp.AlreadyExists
+		default:
+			return twirp.FailedPrecondition
+		}
+	case sqlite3.ErrBusy:
+		return twirp.Unavailable
+	case sqlite3.ErrLocked, sqlite3.ErrNomem, sqlite3.ErrFull:
+		return twirp.ResourceExhausted
+	case sqlite3.ErrPerm, sqlite3.ErrAuth:
+		return twirp.PermissionDenied
+	case sqlite3.ErrReadonly:
+		return twirp.FailedPrecondition
+	case sqlite3.ErrAbort, sqlite3.ErrSchema:
+		return twirp.Aborted
+	case sqlite3.ErrInterrupt:
+		return twirp.Canceled
+	case sqlite3.ErrCorrupt, sqlite3.ErrNotADB:
+		return twirp.DataLoss
+	case sqlite3.ErrNoLFS:
+		return twirp.Unimplemented
+	default:
+		return twirp.Internal
+	}
+}
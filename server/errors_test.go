@@ -0,0 +1,121 @@
+package server_test
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/server"
+)
+
+func TestErrors(t *testing.T) {
+	file := "errors.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file)
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = s.Exec(
+		ctx,
+		&sqliterpc.ExecRequest{
+			Sql: `create table testing (intCol INTEGER PRIMARY KEY, textCol TEXT NOT NULL)`,
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = s.Exec(
+		ctx,
+		&sqliterpc.ExecRequest{
+			Sql: `insert into testing (intCol, textCol) values (1, 'one')`,
+		},
+	)
+	require.NoError(t, err)
+
+	canceled, cancelQuery := context.WithCancel(ctx)
+	cancelQuery()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		sql      string
+		code     twirp.ErrorCode
+		sqlite   sqlite3.ErrNo
+		extended sqlite3.ErrNoExtended
+	}{
+		{
+			name:   "syntax",
+			ctx:    ctx,
+			sql:    `insert into`,
+			code:   twirp.InvalidArgument,
+			sqlite: sqlite3.ErrError,
+		},
+		{
+			name:   "missing table",
+			ctx:    ctx,
+			sql:    `insert into missing (intCol) values (1)`,
+			code:   twirp.InvalidArgument,
+			sqlite: sqlite3.ErrError,
+		},
+		{
+			name:     "primary key",
+			ctx:      ctx,
+			sql:      `insert into testing (intCol, textCol) values (1, 'one')`,
+			code:     twirp.AlreadyExists,
+			sqlite:   sqlite3.ErrConstraint,
+			extended: sqlite3.ErrConstraintPrimaryKey,
+		},
+		{
+			name:     "not null",
+			ctx:      ctx,
+			sql:      `insert into testing (intCol, textCol) values (2, NULL)`,
+			code:     twirp.FailedPrecondition,
+			sqlite:   sqlite3.ErrConstraint,
+			extended: sqlite3.ErrConstraintNotNull,
+		},
+		{
+			name: "canceled",
+			ctx:  canceled,
+			sql:  `insert into testing (intCol, textCol) values (3, 'three')`,
+			code: twirp.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Exec(
+				tt.ctx,
+				&sqliterpc.ExecRequest{
+					Sql: tt.sql,
+				},
+			)
+			require.Error(t, err)
+
+			twerr, ok := err.(twirp.Error)
+			require.True(t, ok)
+			require.Equal(t, tt.code, twerr.Code())
+
+			if tt.sqlite == 0 {
+				require.Empty(t, twerr.Meta(sqliterpc.ErrorMetaCode))
+				return
+			}
+
+			require.Equal(t, strconv.Itoa(int(tt.sqlite)), twerr.Meta(sqliterpc.ErrorMetaCode))
+
+			if tt.extended != 0 {
+				require.Equal(t, strconv.Itoa(int(tt.extended)), twerr.Meta(sqliterpc.ErrorMetaExtendedCode))
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/bakins/sqliterpc"
+)
+
+// WithStatementTimeout sets how long a statement may run when the request
+// does not set a timeout. Statements that run longer are interrupted.
+// Zero, the default, does not limit statements.
+func WithStatementTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.limits.timeout = timeout
+	})
+}
+
+// WithMaxStatementTimeout sets the longest timeout a request may set.
+// Longer timeouts are shortened to it. Zero, the default, allows any timeout.
+func WithMaxStatementTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.limits.maxTimeout = timeout
+	})
+}
+
+// WithMaxRows sets the maximum number of rows in a query response.
+// Paged queries return smaller pages. Zero, the default, is no limit.
+func WithMaxRows(max int64) Option {
+	return optionFunc(func(c *config) {
+		c.limits.maxRows = max
+	})
+}
+
+// WithMaxResponseBytes sets the maximum size of a query response when
+// encoded as protobuf. Paged queries return smaller pages. It also limits
+// the size of a batch response, including all of its steps.
+// Zero, the default, is no limit.
+func WithMaxResponseBytes(max int64) Option {
+	return optionFunc(func(c *config) {
+		c.limits.maxBytes = max
+	})
+}
+
+// serverLimits are the server's limits for requests. Requests may only tighten them.
+type serverLimits struct {
+	timeout    time.Duration
+	maxTimeout time.Duration
+	maxRows    int64
+	maxBytes   int64
+}
+
+// requestTimeout returns how long a statement of a request may run. Zero is no limit.
+func (l serverLimits) requestTimeout(timeout *durationpb.Duration) (time.Duration, error) {
+	if timeout == nil {
+		if l.timeout > 0 {
+			return l.timeout, nil
+		}
+
+		return l.maxTimeout, nil
+	}
+
+	if err := timeout.CheckValid(); err != nil || timeout.AsDuration() <= 0 {
+		return 0, twirp.InvalidArgumentError("timeout", "must be positive")
+	}
+
+	d := timeout.AsDuration()
+	if l.maxTimeout > 0 && d > l.maxTimeout {
+		d = l.maxTimeout
+	}
+
+	return d, nil
+}
+
+// rowLimits limit the rows of a query response.
+type rowLimits struct {
+	maxRows  int64
+	maxBytes int64
+	truncate bool
+}
+
+// rowLimits returns the limits for the response to req.
+func (l serverLimits) rowLimits(req *sqliterpc.QueryRequest) (rowLimits, error) {
+	if req.MaxRows < 0 {
+		return rowLimits{}, twirp.InvalidArgumentError("max_rows", "must not be negative")
+	}
+
+	if req.MaxResponseBytes < 0 {
+		return rowLimits{}, twirp.InvalidArgumentError("max_response_bytes", "must not be negative")
+	}
+
+	r := rowLimits{
+		maxRows:  tighter(l.maxRows, req.MaxRows),
+		maxBytes: tighter(l.maxBytes, req.MaxResponseBytes),
+		truncate: req.Truncate,
+	}
+
+	return r, nil
+}
+
+// tighter returns the smaller limit, where zero is no limit.
+func tighter(server, request int64) int64 {
+	if request > 0 && (server == 0 || request < server) {
+		return request
+	}
+
+	return server
+}
+
+// pageSize returns size, or fewer if the response would have too many rows.
+func (r rowLimits) pageSize(size int) int {
+	if r.maxRows > 0 && int64(size) > r.maxRows {
+		return int(r.maxRows)
+	}
+
+	return size
+}
+
+// check returns an error if a response with rows of size bytes exceeds the limits.
+func (r rowLimits) check(rows int, size int64) error {
+	switch {
+	case r.maxRows > 0 && int64(rows) > r.maxRows:
+		return twirp.NewError(twirp.ResourceExhausted,
+			fmt.Sprintf("query returned more than %d rows: use paging or the streaming endpoint for large results", r.maxRows))
+	case r.maxBytes > 0 && size > r.maxBytes:
+		return twirp.NewError(twirp.ResourceExhausted,
+			fmt.Sprintf("query response is larger than %d bytes: use paging or the streaming endpoint for large results", r.maxBytes))
+	}
+
+	return nil
+}
+
+// responseSize is the encoded size of a response with columns and no rows,
+// including the fields that may be set after the rows are read.
+func responseSize(columns []*sqliterpc.Column, pageToken bool) int64 {
+	resp := sqliterpc.QueryResponse{
+		Columns:   columns,
+		Truncated: true,
+	}
+
+	size := int64(proto.Size(&resp))
+
+	if pageToken {
+		// page tokens are the hex encoding of 16 random bytes.
+		size += int64(protowire.SizeTag(3) + protowire.SizeBytes(32))
+	}
+
+	return size
+}
+
+// rowSize is how much row adds to the encoded size of a response.
+func rowSize(row *sqliterpc.ListValue) int64 {
+	return int64(protowire.SizeTag(2) + protowire.SizeBytes(proto.Size(row)))
+}
+
+// withTimeout returns a context that is canceled after timeout, if it is not zero.
+// go-sqlite3 calls sqlite3_interrupt when the context of a running statement is
+// done, which also stops long running queries that do not return rows.
+// see https://www.sqlite.org/c3ref/interrupt.html
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,301 @@
+package server_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/server"
+)
+
+// runaway never finishes without being interrupted.
+const runaway = `with recursive r(n) as (select 1 union all select n + 1 from r) select count(*) from r`
+
+func TestStatementTimeout(t *testing.T) {
+	s, err := server.New(filepath.Join(t.TempDir(), "timeout.db"), server.WithMaxStatementTimeout(time.Millisecond*500))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	timeout := durationpb.New(time.Millisecond * 100)
+
+	t.Run("query", func(t *testing.T) {
+		_, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: runaway, Timeout: timeout})
+		requireCode(t, err, twirp.DeadlineExceeded)
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		_, err := s.Exec(ctx, &sqliterpc.ExecRequest{Sql: runaway, Timeout: timeout})
+		requireCode(t, err, twirp.DeadlineExceeded)
+	})
+
+	t.Run("script", func(t *testing.T) {
+		_, err := s.ExecScript(ctx, &sqliterpc.ExecScriptRequest{Sql: `select 1; ` + runaway, Timeout: timeout})
+		requireCode(t, err, twirp.DeadlineExceeded)
+		require.Equal(t, "1", err.(twirp.Error).Meta(sqliterpc.ErrorMetaStatement))
+	})
+
+	t.Run("page", func(t *testing.T) {
+		_, err := s.Query(ctx, &sqliterpc.QueryRequest{
+			Sql:      `with recursive r(n) as (select 1 union all select n + 1 from r) select n from r where n < 0`,
+			PageSize: 10,
+			Timeout:  timeout,
+		})
+		requireCode(t, err, twirp.DeadlineExceeded)
+	})
+
+	t.Run("page waiting for writer", func(t *testing.T) {
+		_, err := s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table waiting (id INTEGER PRIMARY KEY)`})
+		require.NoError(t, err)
+
+		// the statement holds the writer until the server's maximum timeout
+		done := make(chan error, 1)
+		go func() {
+			_, err := s.Exec(ctx, &sqliterpc.ExecRequest{Sql: runaway})
+			done <- err
+		}()
+
+		defer func() {
+			requireCode(t, <-done, twirp.DeadlineExceeded)
+		}()
+
+		time.Sleep(time.Millisecond * 50)
+
+		_, err = s.Query(ctx, &sqliterpc.QueryRequest{
+			Sql:      `insert into waiting (id) values (1) returning id`,
+			PageSize: 10,
+			Timeout:  timeout,
+		})
+		requireCode(t, err, twirp.DeadlineExceeded)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		resp, err := s.Batch(ctx, &sqliterpc.BatchRequest{
+			Steps: []*sqliterpc.BatchStep{
+				{Step: &sqliterpc.BatchStep_Query{Query: &sqliterpc.QueryRequest{Sql: runaway, Timeout: timeout}}},
+			},
+		})
+		require.NoError(t, err)
+		require.False(t, resp.Committed)
+		require.Equal(t, string(twirp.DeadlineExceeded), resp.Results[0].GetError().GetCode())
+	})
+
+	t.Run("max", func(t *testing.T) {
+		start := time.Now()
+
+		// the server's maximum applies without a timeout, and to longer timeouts.
+		_, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: runaway})
+		requireCode(t, err, twirp.DeadlineExceeded)
+
+		_, err = s.Query(ctx, &sqliterpc.QueryRequest{Sql: runaway, Timeout: durationpb.New(time.Hour)})
+		requireCode(t, err, twirp.DeadlineExceeded)
+
+		require.Less(t, time.Since(start), time.Second*5)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select 1`, Timeout: durationpb.New(-time.Second)})
+		requireCode(t, err, twirp.InvalidArgument)
+	})
+
+	t.Run("within timeout", func(t *testing.T) {
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select 1`, Timeout: timeout})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 1)
+	})
+}
+
+func TestDefaultStatementTimeout(t *testing.T) {
+	s, err := server.New(filepath.Join(t.TempDir(), "timeout.db"), server.WithStatementTimeout(time.Millisecond*100))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = s.Query(ctx, &sqliterpc.QueryRequest{Sql: runaway})
+	requireCode(t, err, twirp.DeadlineExceeded)
+
+	// the connection is usable after the interrupt.
+	resp, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select 1`})
+	require.NoError(t, err)
+	require.Len(t, resp.Rows, 1)
+}
+
+func TestResponseLimits(t *testing.T) {
+	s, err := server.New(filepath.Join(t.TempDir(), "limits.db"), server.WithMaxRows(8))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = s.ExecScript(ctx, &sqliterpc.ExecScriptRequest{
+		Sql: `
+			create table testing (id INTEGER PRIMARY KEY, value TEXT);
+			with recursive r(n) as (select 1 union all select n + 1 from r where n < 10)
+			insert into testing (id, value) select n, printf('%.*c', 100, 'x') from r;
+		`,
+	})
+	require.NoError(t, err)
+
+	const all = `select id, value from testing order by id`
+
+	// pages reads every page of a paged query.
+	pages := func(t *testing.T, req *sqliterpc.QueryRequest) [][]*sqliterpc.ListValue {
+		var pages [][]*sqliterpc.ListValue
+
+		resp, err := s.Query(ctx, req)
+		require.NoError(t, err)
+
+		for {
+			pages = append(pages, resp.Rows)
+
+			if resp.NextPageToken == "" {
+				return pages
+			}
+
+			resp, err = s.Query(ctx, &sqliterpc.QueryRequest{PageToken: resp.NextPageToken})
+			require.NoError(t, err)
+		}
+	}
+
+	t.Run("server max rows", func(t *testing.T) {
+		_, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: all})
+		requireCode(t, err, twirp.ResourceExhausted)
+
+		// requests cannot raise the limit.
+		_, err = s.Query(ctx, &sqliterpc.QueryRequest{Sql: all, MaxRows: 20})
+		requireCode(t, err, twirp.ResourceExhausted)
+
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: all + ` limit 8`})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 8)
+		require.False(t, resp.Truncated)
+	})
+
+	t.Run("truncate rows", func(t *testing.T) {
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: all, MaxRows: 3, Truncate: true})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 3)
+		require.True(t, resp.Truncated)
+	})
+
+	t.Run("truncate bytes", func(t *testing.T) {
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: all, MaxResponseBytes: 500, Truncate: true})
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.Rows)
+		require.Less(t, len(resp.Rows), 8)
+		require.True(t, resp.Truncated)
+		require.LessOrEqual(t, proto.Size(resp), 500)
+
+		_, err = s.Query(ctx, &sqliterpc.QueryRequest{Sql: all, MaxResponseBytes: 500})
+		requireCode(t, err, twirp.ResourceExhausted)
+	})
+
+	t.Run("paged max rows", func(t *testing.T) {
+		got := pages(t, &sqliterpc.QueryRequest{Sql: all, PageSize: 5, MaxRows: 3})
+		require.Len(t, got, 4)
+
+		for _, page := range got[:3] {
+			require.Len(t, page, 3)
+		}
+	})
+
+	t.Run("paged bytes", func(t *testing.T) {
+		got := pages(t, &sqliterpc.QueryRequest{Sql: all, PageSize: 8, MaxResponseBytes: 500})
+		require.Greater(t, len(got), 2)
+
+		var ids []int64
+		for _, page := range got {
+			for _, row := range page {
+				ids = append(ids, row.Values[0].GetIntegerValue().GetValue())
+			}
+		}
+
+		require.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, ids)
+	})
+
+	t.Run("row larger than limit", func(t *testing.T) {
+		_, err := s.Query(ctx, &sqliterpc.QueryRequest{
+			Sql:              `select ?`,
+			Parameters:       []*sqliterpc.Value{{Kind: &sqliterpc.Value_TextValue{TextValue: &sqliterpc.TextValue{Value: strings.Repeat("x", 1000), Valid: true}}}},
+			PageSize:         5,
+			MaxResponseBytes: 500,
+		})
+		requireCode(t, err, twirp.ResourceExhausted)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		resp, err := s.Batch(ctx, &sqliterpc.BatchRequest{
+			Steps: []*sqliterpc.BatchStep{
+				{Step: &sqliterpc.BatchStep_Query{Query: &sqliterpc.QueryRequest{Sql: all, MaxRows: 2, Truncate: true}}},
+			},
+		})
+		require.NoError(t, err)
+		require.True(t, resp.Results[0].GetQuery().GetTruncated())
+		require.Len(t, resp.Results[0].GetQuery().GetRows(), 2)
+	})
+
+	t.Run("batch bytes", func(t *testing.T) {
+		s, err := server.New(filepath.Join(t.TempDir(), "batch.db"), server.WithMaxResponseBytes(1000))
+		require.NoError(t, err)
+
+		defer s.Close()
+
+		// each step is within the limit, but together they are not
+		step := &sqliterpc.BatchStep{Step: &sqliterpc.BatchStep_Query{Query: &sqliterpc.QueryRequest{
+			Sql: `with recursive r(n) as (select 1 union all select n + 1 from r where n < 6)
+				select printf('%.*c', 100, 'x') from r`,
+		}}}
+
+		resp, err := s.Batch(ctx, &sqliterpc.BatchRequest{Steps: []*sqliterpc.BatchStep{step}})
+		require.NoError(t, err)
+		require.Len(t, resp.Results[0].GetQuery().GetRows(), 6)
+
+		resp, err = s.Batch(ctx, &sqliterpc.BatchRequest{Steps: []*sqliterpc.BatchStep{step, step}})
+		require.NoError(t, err)
+		require.False(t, resp.Committed)
+		require.Equal(t, string(twirp.ResourceExhausted), resp.Results[1].GetError().GetCode())
+
+		// a query that would exceed the bytes left is truncated if requested
+		truncated := proto.Clone(step).(*sqliterpc.BatchStep)
+		truncated.GetQuery().Truncate = true
+
+		resp, err = s.Batch(ctx, &sqliterpc.BatchRequest{Steps: []*sqliterpc.BatchStep{step, truncated}})
+		require.NoError(t, err)
+		require.True(t, resp.Committed)
+		require.True(t, resp.Results[1].GetQuery().GetTruncated())
+		require.LessOrEqual(t, proto.Size(resp), 1000)
+
+		// errors and exec results count towards the limit too
+		var steps []*sqliterpc.BatchStep
+		for i := 0; i < 100; i++ {
+			steps = append(steps, &sqliterpc.BatchStep{Step: &sqliterpc.BatchStep_Exec{Exec: &sqliterpc.ExecRequest{Sql: `select from`}}})
+		}
+
+		_, err = s.Batch(ctx, &sqliterpc.BatchRequest{Steps: steps, ContinueOnError: true})
+		requireCode(t, err, twirp.ResourceExhausted)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: all, MaxRows: -1})
+		requireCode(t, err, twirp.InvalidArgument)
+
+		_, err = s.Query(ctx, &sqliterpc.QueryRequest{Sql: all, MaxResponseBytes: -1})
+		requireCode(t, err, twirp.InvalidArgument)
+	})
+}
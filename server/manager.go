@@ -0,0 +1,319 @@
+package server
+
+import (
+	"container/list"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/twitchtv/twirp"
+)
+
+// DatabasePathPrefix is the path prefix used by Manager.
+// Requests for a database are under /db/{name}/
+const DatabasePathPrefix = "/db/"
+
+// Manager serves the databases in a directory. Requests are routed
+// by path, so the Twirp services for the database "tenant42" are under
+// /db/tenant42/twirp/. Databases are opened on first use, and the
+// least recently used idle databases are closed when there are
+// too many open.
+type Manager struct {
+	lock      sync.Mutex
+	databases map[string]*managedDatabase
+	// lru is ordered from most to least recently used
+	lru *list.List
+	cfg managerConfig
+}
+
+type managedDatabase struct {
+	name string
+	// ready is closed once the database is opened. server, handler, and
+	// err are set before then.
+	ready   chan struct{}
+	server  *DatabaseServer
+	handler http.Handler
+	err     error
+	// refs is the number of in flight requests
+	refs    int
+	element *list.Element
+}
+
+type ManagerOption interface {
+	apply(*managerConfig)
+}
+
+type managerConfig struct {
+	dir          string
+	maxOpen      int
+	create       bool
+	options      []Option
+	twirpOptions []interface{}
+}
+
+type managerOptionFunc func(*managerConfig)
+
+func (f managerOptionFunc) apply(c *managerConfig) {
+	f(c)
+}
+
+// WithMaxOpenDatabases sets the number of databases to keep open.
+// Databases with open transactions or cursors are not closed, so
+// this may be exceeded.
+func WithMaxOpenDatabases(max int) ManagerOption {
+	return managerOptionFunc(func(c *managerConfig) {
+		c.maxOpen = max
+	})
+}
+
+// WithCreateDatabases sets whether databases that do not exist
+// are created on first use. By default, a request for a missing database
+// returns a not found error.
+func WithCreateDatabases(create bool) ManagerOption {
+	return managerOptionFunc(func(c *managerConfig) {
+		c.create = create
+	})
+}
+
+// WithServerOptions sets the options used to open each database.
+func WithServerOptions(options ...Option) ManagerOption {
+	return managerOptionFunc(func(c *managerConfig) {
+		c.options = append(c.options, options...)
+	})
+}
+
+// WithTwirpOptions sets the options passed to the Twirp servers.
+func WithTwirpOptions(twirpOptions ...interface{}) ManagerOption {
+	return managerOptionFunc(func(c *managerConfig) {
+		c.twirpOptions = append(c.twirpOptions, twirpOptions...)
+	})
+}
+
+// NewManager creates a manager for the databases in dir.
+// The file for a database is the name with a ".db" extension.
+func NewManager(dir string, options ...ManagerOption) (*Manager, error) {
+	cfg := managerConfig{
+		dir:     dir,
+		maxOpen: 64,
+	}
+
+	for _, o := range options {
+		o.apply(&cfg)
+	}
+
+	// fail now rather than when each database is opened.
+	dbCfg, err := newConfig(cfg.options...)
+	if err != nil {
+		return nil, err
+	}
+
+	// a primary is the url of a single database.
+	if dbCfg.primary != "" {
+		return nil, errors.New("replicas are not supported with a manager")
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return nil, errors.New(dir + " is not a directory")
+	}
+
+	m := Manager{
+		databases: make(map[string]*managedDatabase),
+		lru:       list.New(),
+		cfg:       cfg,
+	}
+
+	return &m, nil
+}
+
+var databaseName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,127}$`)
+
+// ValidDatabaseName returns true if name may be used as a database name.
+func ValidDatabaseName(name string) bool {
+	return databaseName.MatchString(name) && !strings.Contains(name, "..")
+}
+
+func (m *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, DatabasePathPrefix)
+	if rest == r.URL.Path {
+		_ = twirp.WriteError(w, twirp.NewError(twirp.BadRoute, "no handler for path "+r.URL.Path))
+		return
+	}
+
+	name, path, _ := strings.Cut(rest, "/")
+	if !ValidDatabaseName(name) {
+		_ = twirp.WriteError(w, twirp.InvalidArgumentError("database", "invalid name"))
+		return
+	}
+
+	db, err := m.acquire(name)
+	if err != nil {
+		_ = twirp.WriteError(w, err)
+		return
+	}
+
+	defer m.release(db)
+
+	r2 := r.Clone(r.Context())
+	r2.URL.Path = "/" + path
+	r2.URL.RawPath = ""
+
+	db.handler.ServeHTTP(w, r2)
+}
+
+// acquire returns the database, opening it if needed. Databases are opened
+// without holding lock, so requests for other databases are not blocked.
+// Concurrent requests for a database that is being opened wait for it.
+// release must be called when the request is done.
+func (m *Manager) acquire(name string) (*managedDatabase, error) {
+	m.lock.Lock()
+
+	if m.databases == nil {
+		m.lock.Unlock()
+		return nil, twirp.NewError(twirp.Unavailable, "server is closed")
+	}
+
+	db, ok := m.databases[name]
+	if !ok {
+		db = &managedDatabase{
+			name:  name,
+			ready: make(chan struct{}),
+		}
+
+		db.element = m.lru.PushFront(db)
+		m.databases[name] = db
+	}
+
+	db.refs++
+	m.lru.MoveToFront(db.element)
+	m.lock.Unlock()
+
+	if !ok {
+		m.open(db)
+	}
+
+	<-db.ready
+
+	if db.err != nil {
+		return nil, db.err
+	}
+
+	return db, nil
+}
+
+// open opens db and closes ready. If it fails, db is removed so the next
+// request tries again.
+func (m *Manager) open(db *managedDatabase) {
+	defer close(db.ready)
+
+	filename := filepath.Join(m.cfg.dir, db.name+".db")
+
+	if !m.cfg.create {
+		if _, err := os.Stat(filename); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				db.err = twirp.NotFoundError("database not found")
+			} else {
+				db.err = twirp.InternalErrorWith(err)
+			}
+		}
+	}
+
+	if db.err == nil {
+		s, err := New(filename, m.cfg.options...)
+		if err != nil {
+			db.err = twirp.InternalErrorWith(err)
+		} else {
+			db.server = s
+			db.handler = NewHandler(s, m.cfg.twirpOptions...)
+		}
+	}
+
+	if db.err != nil {
+		m.lock.Lock()
+		// the manager may have been closed
+		if m.databases[db.name] == db {
+			m.lru.Remove(db.element)
+			delete(m.databases, db.name)
+		}
+		m.lock.Unlock()
+	}
+}
+
+func (m *Manager) release(db *managedDatabase) {
+	m.lock.Lock()
+	db.refs--
+	evicted := m.evict()
+	m.lock.Unlock()
+
+	for _, s := range evicted {
+		_ = s.Close()
+	}
+}
+
+// evict removes the least recently used idle databases until there are
+// at most maxOpen. The caller must hold lock and close the returned servers.
+func (m *Manager) evict() []*DatabaseServer {
+	var evicted []*DatabaseServer
+
+	for e := m.lru.Back(); e != nil && len(m.databases) > m.cfg.maxOpen; {
+		db := e.Value.(*managedDatabase)
+		e = e.Prev()
+
+		if db.refs > 0 || !db.server.idle() {
+			continue
+		}
+
+		m.lru.Remove(db.element)
+		delete(m.databases, db.name)
+		evicted = append(evicted, db.server)
+	}
+
+	return evicted
+}
+
+// Open returns the number of open databases.
+func (m *Manager) Open() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return len(m.databases)
+}
+
+// Close closes all databases. In flight requests are not waited on.
+func (m *Manager) Close() error {
+	m.lock.Lock()
+	databases := m.databases
+	m.databases = nil
+	m.lru.Init()
+	m.lock.Unlock()
+
+	var errs []string
+
+	for _, db := range databases {
+		// wait for databases that are being opened
+		<-db.ready
+
+		if db.server == nil {
+			continue
+		}
+
+		if err := db.server.Close(); err != nil {
+			errs = append(errs, db.name+": "+err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New("failed to close databases: " + strings.Join(errs, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/server"
+)
+
+func TestManager(t *testing.T) {
+	m, err := server.NewManager(t.TempDir(), server.WithMaxOpenDatabases(1), server.WithCreateDatabases(true))
+	require.NoError(t, err)
+
+	defer m.Close()
+
+	svr := httptest.NewServer(m)
+	defer svr.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	client := func(name string) sqliterpc.DatabaseService {
+		return sqliterpc.NewDatabaseServiceProtobufClient(svr.URL+"/db/"+name, http.DefaultClient)
+	}
+
+	one := client("one")
+	two := client("two")
+
+	for _, c := range []sqliterpc.DatabaseService{one, two} {
+		_, err := c.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table testing (intCol INTEGER PRIMARY KEY)`})
+		require.NoError(t, err)
+	}
+
+	_, err = one.Exec(ctx, &sqliterpc.ExecRequest{Sql: `insert into testing (intCol) values (1)`})
+	require.NoError(t, err)
+
+	// one was closed when two was opened
+	require.Equal(t, 1, m.Open())
+
+	resp, err := one.Query(ctx, &sqliterpc.QueryRequest{Sql: `select intCol from testing`})
+	require.NoError(t, err)
+	require.Len(t, resp.Rows, 1)
+
+	resp, err = two.Query(ctx, &sqliterpc.QueryRequest{Sql: `select intCol from testing`})
+	require.NoError(t, err)
+	require.Len(t, resp.Rows, 0)
+
+	t.Run("open transaction", func(t *testing.T) {
+		begin, err := one.Begin(ctx, &sqliterpc.BeginRequest{})
+		require.NoError(t, err)
+
+		_, err = two.Query(ctx, &sqliterpc.QueryRequest{Sql: `select intCol from testing`})
+		require.NoError(t, err)
+
+		// two is closed rather than one, which has an open transaction
+		require.Equal(t, 1, m.Open())
+
+		_, err = one.Exec(ctx, &sqliterpc.ExecRequest{Sql: `insert into testing (intCol) values (2)`, TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+
+		_, err = one.Commit(ctx, &sqliterpc.CommitRequest{TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		_, err := client("..").Exec(ctx, &sqliterpc.ExecRequest{Sql: `select 1`})
+		require.Error(t, err)
+
+		twerr, ok := err.(twirp.Error)
+		require.True(t, ok)
+		require.Equal(t, twirp.InvalidArgument, twerr.Code())
+	})
+}
+
+func TestManagerConcurrentOpen(t *testing.T) {
+	m, err := server.NewManager(t.TempDir(), server.WithCreateDatabases(true))
+	require.NoError(t, err)
+
+	defer m.Close()
+
+	svr := httptest.NewServer(m)
+	defer svr.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	names := []string{"one", "two", "three", "four"}
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, len(names)*5)
+
+	// requests for a database that is being opened wait for it
+	for i := 0; i < 5; i++ {
+		for _, name := range names {
+			wg.Add(1)
+
+			go func(name string) {
+				defer wg.Done()
+
+				c := sqliterpc.NewDatabaseServiceProtobufClient(svr.URL+"/db/"+name, http.DefaultClient)
+
+				_, err := c.Query(ctx, &sqliterpc.QueryRequest{Sql: `select 1`})
+				errs <- err
+			}(name)
+		}
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, len(names), m.Open())
+}
+
+func TestManagerMissingDatabase(t *testing.T) {
+	dir := t.TempDir()
+
+	m, err := server.NewManager(dir)
+	require.NoError(t, err)
+
+	defer m.Close()
+
+	svr := httptest.NewServer(m)
+	defer svr.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	c := sqliterpc.NewDatabaseServiceProtobufClient(svr.URL+"/db/missing", http.DefaultClient)
+
+	_, err = c.Exec(ctx, &sqliterpc.ExecRequest{Sql: `select 1`})
+	require.Error(t, err)
+
+	twerr, ok := err.(twirp.Error)
+	require.True(t, ok)
+	require.Equal(t, twirp.NotFound, twerr.Code())
+	require.Equal(t, 0, m.Open())
+
+	// the failure is not remembered
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "missing.db"), nil, 0o600))
+
+	_, err = c.Exec(ctx, &sqliterpc.ExecRequest{Sql: `select 1`})
+	require.NoError(t, err)
+	require.Equal(t, 1, m.Open())
+}
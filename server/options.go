@@ -0,0 +1,415 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JournalMode is the sqlite journal mode.
+// see https://www.sqlite.org/pragma.html#pragma_journal_mode
+type JournalMode string
+
+const (
+	JournalModeDelete   = JournalMode("DELETE")
+	JournalModeTruncate = JournalMode("TRUNCATE")
+	JournalModePersist  = JournalMode("PERSIST")
+	JournalModeMemory   = JournalMode("MEMORY")
+	JournalModeWal      = JournalMode("WAL")
+	JournalModeOff      = JournalMode("OFF")
+)
+
+// CacheMode is the sqlite cache mode.
+// see https://www.sqlite.org/sharedcache.html
+type CacheMode string
+
+const (
+	CacheModeShared  = CacheMode("shared")
+	CacheModePrivate = CacheMode("private")
+)
+
+// SynchronousMode is the sqlite synchronous setting.
+// see https://www.sqlite.org/pragma.html#pragma_synchronous
+type SynchronousMode string
+
+const (
+	SynchronousOff    = SynchronousMode("OFF")
+	SynchronousNormal = SynchronousMode("NORMAL")
+	SynchronousFull   = SynchronousMode("FULL")
+	SynchronousExtra  = SynchronousMode("EXTRA")
+)
+
+// TxLock is how transactions that do not set a mode acquire locks.
+// see https://www.sqlite.org/lang_transaction.html
+type TxLock string
+
+const (
+	TxLockDeferred  = TxLock("deferred")
+	TxLockImmediate = TxLock("immediate")
+	TxLockExclusive = TxLock("exclusive")
+)
+
+// OpenMode is how the database file is opened.
+// see https://www.sqlite.org/uri.html#urimode
+type OpenMode string
+
+const (
+	// OpenModeReadOnly opens an existing database. All writes fail.
+	OpenModeReadOnly = OpenMode("ro")
+	// OpenModeReadWrite opens an existing database.
+	OpenModeReadWrite = OpenMode("rw")
+	// OpenModeReadWriteCreate opens a database, creating it if needed.
+	OpenModeReadWriteCreate = OpenMode("rwc")
+	// OpenModeMemory uses a database that is never written to disk.
+	// It is lost when the server is closed.
+	OpenModeMemory = OpenMode("memory")
+)
+
+// TempStore is where temporary tables and indices are kept.
+// see https://www.sqlite.org/pragma.html#pragma_temp_store
+type TempStore string
+
+const (
+	TempStoreDefault = TempStore("DEFAULT")
+	TempStoreFile    = TempStore("FILE")
+	TempStoreMemory  = TempStore("MEMORY")
+)
+
+// LockingMode is the sqlite locking mode.
+// see https://www.sqlite.org/pragma.html#pragma_locking_mode
+type LockingMode string
+
+const (
+	LockingModeNormal    = LockingMode("NORMAL")
+	LockingModeExclusive = LockingMode("EXCLUSIVE")
+)
+
+// WithJournalMode sets the journal mode. The default is WAL, which
+// allows reads while writing.
+func WithJournalMode(mode JournalMode) Option {
+	return optionFunc(func(c *config) {
+		c.journal = mode
+	})
+}
+
+// WithCacheMode sets the cache mode. The default is private, as a shared
+// cache locks tables rather than allowing reads while writing.
+func WithCacheMode(mode CacheMode) Option {
+	return optionFunc(func(c *config) {
+		c.cache = mode
+	})
+}
+
+// WithBusyTimeout sets how long a connection waits for a lock
+// held by another connection before failing.
+func WithBusyTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.busyTimeout = timeout
+	})
+}
+
+// WithSynchronous sets how often sqlite syncs to disk.
+// NORMAL is usually enough when using WAL.
+func WithSynchronous(mode SynchronousMode) Option {
+	return optionFunc(func(c *config) {
+		c.synchronous = mode
+	})
+}
+
+// WithForeignKeys enables or disables enforcing foreign key constraints.
+func WithForeignKeys(enabled bool) Option {
+	return optionFunc(func(c *config) {
+		c.foreignKeys = &enabled
+	})
+}
+
+// WithTxLock sets how transactions that do not set a mode acquire locks.
+// The default is deferred.
+func WithTxLock(lock TxLock) Option {
+	return optionFunc(func(c *config) {
+		c.txLock = lock
+	})
+}
+
+// WithOpenMode sets how the database is opened. The default creates
+// the database if needed.
+func WithOpenMode(mode OpenMode) Option {
+	return optionFunc(func(c *config) {
+		c.mode = mode
+	})
+}
+
+// WithCacheSize sets the page cache size of each connection.
+// A positive size is a number of pages, a negative size is in KiB.
+func WithCacheSize(size int) Option {
+	return optionFunc(func(c *config) {
+		c.cacheSize = &size
+	})
+}
+
+// WithMmapSize sets the maximum number of bytes used for memory mapped I/O.
+// Zero disables memory mapped I/O.
+func WithMmapSize(size int64) Option {
+	return optionFunc(func(c *config) {
+		c.mmapSize = &size
+	})
+}
+
+// WithTempStore sets where temporary tables and indices are kept.
+func WithTempStore(store TempStore) Option {
+	return optionFunc(func(c *config) {
+		c.tempStore = store
+	})
+}
+
+// WithLockingMode sets the locking mode. In exclusive mode, the writer
+// holds the lock for as long as it is open, so it is also used for reads.
+func WithLockingMode(mode LockingMode) Option {
+	return optionFunc(func(c *config) {
+		c.lockingMode = mode
+	})
+}
+
+// WithMaxReadConns sets the maximum number of connections used for queries that only read.
+func WithMaxReadConns(max int) Option {
+	return optionFunc(func(c *config) {
+		c.maxReadConns = max
+	})
+}
+
+// WithMaxWriteConns sets the maximum number of connections used for writes.
+// The default of one serializes writes in the server rather than having
+// connections contend for the database lock. Write transactions and paged
+// queries that write hold a connection until they finish. While every
+// connection is held, other writes fail with an unavailable error with the
+// SQLITE_BUSY code, rather than waiting.
+func WithMaxWriteConns(max int) Option {
+	return optionFunc(func(c *config) {
+		c.maxWriteConns = max
+	})
+}
+
+// WithConnMaxLifetime sets how long a connection may be reused.
+// Zero, the default, reuses connections forever.
+func WithConnMaxLifetime(lifetime time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.connMaxLifetime = lifetime
+	})
+}
+
+func newConfig(options ...Option) (config, error) {
+	cfg := config{
+		journal:            JournalModeWal,
+		cache:              CacheModePrivate,
+		busyTimeout:        5 * time.Second,
+		txLock:             TxLockDeferred,
+		mode:               OpenModeReadWriteCreate,
+		maxReadConns:       16,
+		maxWriteConns:      1,
+		transactionTimeout: time.Minute,
+		cursorTimeout:      time.Minute,
+		maxCursors:         8,
+		maxStatements:      128,
+	}
+
+	for _, o := range options {
+		o.apply(&cfg)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return config{}, err
+	}
+
+	return cfg, nil
+}
+
+func oneOf(name string, value string, allowed ...string) error {
+	for _, a := range allowed {
+		if strings.EqualFold(value, a) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid %s %q: must be one of %s", name, value, strings.Join(allowed, ", "))
+}
+
+func (c config) validate() error {
+	if err := oneOf("journal mode", string(c.journal),
+		string(JournalModeDelete), string(JournalModeTruncate), string(JournalModePersist),
+		string(JournalModeMemory), string(JournalModeWal), string(JournalModeOff)); err != nil {
+		return err
+	}
+
+	if err := oneOf("cache mode", string(c.cache), string(CacheModeShared), string(CacheModePrivate)); err != nil {
+		return err
+	}
+
+	if c.synchronous != "" {
+		if err := oneOf("synchronous mode", string(c.synchronous),
+			string(SynchronousOff), string(SynchronousNormal), string(SynchronousFull), string(SynchronousExtra)); err != nil {
+			return err
+		}
+	}
+
+	if err := oneOf("tx lock", string(c.txLock), string(TxLockDeferred), string(TxLockImmediate), string(TxLockExclusive)); err != nil {
+		return err
+	}
+
+	if err := oneOf("open mode", string(c.mode),
+		string(OpenModeReadOnly), string(OpenModeReadWrite), string(OpenModeReadWriteCreate), string(OpenModeMemory)); err != nil {
+		return err
+	}
+
+	if c.tempStore != "" {
+		if err := oneOf("temp store", string(c.tempStore), string(TempStoreDefault), string(TempStoreFile), string(TempStoreMemory)); err != nil {
+			return err
+		}
+	}
+
+	if c.lockingMode != "" {
+		if err := oneOf("locking mode", string(c.lockingMode), string(LockingModeNormal), string(LockingModeExclusive)); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case c.busyTimeout < 0:
+		return errors.New("busy timeout must not be negative")
+	case c.mmapSize != nil && *c.mmapSize < 0:
+		return errors.New("mmap size must not be negative")
+	case c.maxReadConns < 1:
+		return errors.New("max read connections must be at least 1")
+	case c.maxWriteConns < 1:
+		return errors.New("max write connections must be at least 1")
+	case c.connMaxLifetime < 0:
+		return errors.New("connection max lifetime must not be negative")
+	case c.limits.timeout < 0:
+		return errors.New("statement timeout must not be negative")
+	case c.limits.maxTimeout < 0:
+		return errors.New("max statement timeout must not be negative")
+	case c.limits.maxTimeout > 0 && c.limits.timeout > c.limits.maxTimeout:
+		return errors.New("statement timeout must not be longer than the max statement timeout")
+	case c.limits.maxRows < 0:
+		return errors.New("max rows must not be negative")
+	case c.limits.maxBytes < 0:
+		return errors.New("max response bytes must not be negative")
+	}
+
+	if c.primary != "" {
+		u, err := url.Parse(c.primary)
+		if err != nil {
+			return fmt.Errorf("invalid primary url: %w", err)
+		}
+
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid primary url %q: must be an http or https url", c.primary)
+		}
+
+		// changes from the primary are written locally.
+		if strings.EqualFold(string(c.mode), string(OpenModeReadOnly)) {
+			return errors.New("a replica cannot be opened read only")
+		}
+	}
+
+	// the database is lost when its last connection is closed.
+	if c.memory() && c.connMaxLifetime > 0 {
+		return errors.New("connection max lifetime cannot be used with an in memory database")
+	}
+
+	if c.exclusive() && c.maxWriteConns > 1 {
+		return errors.New("exclusive locking mode requires a single write connection")
+	}
+
+	return nil
+}
+
+func (c config) memory() bool {
+	return strings.EqualFold(string(c.mode), string(OpenModeMemory))
+}
+
+func (c config) exclusive() bool {
+	return strings.EqualFold(string(c.lockingMode), string(LockingModeExclusive))
+}
+
+// singlePool returns true if the writer's connections are also used for reads.
+// Other connections cannot see an in memory database unless the cache is shared,
+// and cannot read at all while the writer holds an exclusive lock.
+func (c config) singlePool() bool {
+	return c.memory() || c.exclusive()
+}
+
+// se https://github.com/mattn/go-sqlite3#connection-string
+func (c config) dsn(filename string, readOnly bool) string {
+	mode := OpenMode(strings.ToLower(string(c.mode)))
+	if readOnly {
+		mode = OpenModeReadOnly
+	}
+
+	options := map[string]string{
+		"_busy_timeout": strconv.FormatInt(c.busyTimeout.Milliseconds(), 10),
+		"_txlock":       strings.ToLower(string(c.txLock)),
+		"cache":         strings.ToLower(string(c.cache)),
+		"mode":          string(mode),
+	}
+
+	// connections to an in memory database only share it with a shared cache.
+	if mode == OpenModeMemory {
+		options["cache"] = string(CacheModeShared)
+	}
+
+	// the writer sets the journal mode, as read only connections cannot.
+	if mode != OpenModeReadOnly {
+		options["_journal_mode"] = strings.ToUpper(string(c.journal))
+	}
+
+	if c.synchronous != "" {
+		options["_synchronous"] = strings.ToUpper(string(c.synchronous))
+	}
+
+	if c.foreignKeys != nil {
+		options["_foreign_keys"] = "0"
+		if *c.foreignKeys {
+			options["_foreign_keys"] = "1"
+		}
+	}
+
+	if c.cacheSize != nil {
+		options["_cache_size"] = strconv.Itoa(*c.cacheSize)
+	}
+
+	if c.lockingMode != "" {
+		options["_locking_mode"] = strings.ToUpper(string(c.lockingMode))
+	}
+
+	var args []string
+
+	for k, v := range options {
+		args = append(args, k+"="+v)
+	}
+
+	sort.Strings(args)
+
+	dsn := "file:" + filename + "?" + strings.Join(args, "&")
+
+	return dsn
+}
+
+// pragmas returns the settings that go-sqlite3 does not support in the dsn.
+// They are run when each connection is opened.
+func (c config) pragmas() []string {
+	var pragmas []string
+
+	if c.mmapSize != nil {
+		pragmas = append(pragmas, "PRAGMA mmap_size = "+strconv.FormatInt(*c.mmapSize, 10))
+	}
+
+	if c.tempStore != "" {
+		pragmas = append(pragmas, "PRAGMA temp_store = "+strings.ToUpper(string(c.tempStore)))
+	}
+
+	return pragmas
+}
@@ -0,0 +1,169 @@
+package server_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/server"
+)
+
+func TestInvalidOptions(t *testing.T) {
+	tests := map[string]server.Option{
+		"journal mode":      server.WithJournalMode("fast"),
+		"cache mode":        server.WithCacheMode("sometimes"),
+		"busy timeout":      server.WithBusyTimeout(-time.Second),
+		"synchronous":       server.WithSynchronous("always"),
+		"tx lock":           server.WithTxLock("eventually"),
+		"open mode":         server.WithOpenMode("rwx"),
+		"mmap size":         server.WithMmapSize(-1),
+		"temp store":        server.WithTempStore("cloud"),
+		"locking mode":      server.WithLockingMode("shared"),
+		"max read conns":    server.WithMaxReadConns(0),
+		"max write conns":   server.WithMaxWriteConns(0),
+		"conn max lifetime": server.WithConnMaxLifetime(-time.Second),
+		"statement timeout": server.WithStatementTimeout(-time.Second),
+		"max timeout":       server.WithMaxStatementTimeout(-time.Second),
+		"max rows":          server.WithMaxRows(-1),
+		"max bytes":         server.WithMaxResponseBytes(-1),
+	}
+
+	for name, option := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := server.New(filepath.Join(t.TempDir(), "invalid.db"), option)
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("memory with lifetime", func(t *testing.T) {
+		_, err := server.New("invalid", server.WithOpenMode(server.OpenModeMemory), server.WithConnMaxLifetime(time.Minute))
+		require.Error(t, err)
+	})
+
+	t.Run("timeout longer than max", func(t *testing.T) {
+		_, err := server.New(filepath.Join(t.TempDir(), "invalid.db"), server.WithStatementTimeout(time.Minute), server.WithMaxStatementTimeout(time.Second))
+		require.Error(t, err)
+	})
+
+	t.Run("exclusive with multiple writers", func(t *testing.T) {
+		_, err := server.New(filepath.Join(t.TempDir(), "invalid.db"), server.WithLockingMode(server.LockingModeExclusive), server.WithMaxWriteConns(2))
+		require.Error(t, err)
+	})
+
+	t.Run("manager", func(t *testing.T) {
+		_, err := server.NewManager(t.TempDir(), server.WithServerOptions(server.WithOpenMode("rwx")))
+		require.Error(t, err)
+	})
+}
+
+func TestOptions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	// exercise creates a table, writes, and reads it back.
+	exercise := func(t *testing.T, s *server.DatabaseServer) {
+		t.Helper()
+
+		_, err := s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table testing (intCol INTEGER, textCol TEXT)`})
+		require.NoError(t, err)
+
+		_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `insert into testing (intCol, textCol) values (1, 'one'), (2, 'two')`})
+		require.NoError(t, err)
+
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select intCol from testing`})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 2)
+
+		begin, err := s.Begin(ctx, &sqliterpc.BeginRequest{})
+		require.NoError(t, err)
+
+		_, err = s.Commit(ctx, &sqliterpc.CommitRequest{TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+	}
+
+	t.Run("connection settings", func(t *testing.T) {
+		s, err := server.New(
+			filepath.Join(t.TempDir(), "settings.db"),
+			server.WithBusyTimeout(time.Second),
+			server.WithSynchronous(server.SynchronousNormal),
+			server.WithTxLock(server.TxLockImmediate),
+			server.WithCacheSize(-4096),
+			server.WithMmapSize(1<<20),
+			server.WithTempStore(server.TempStoreMemory),
+			server.WithMaxReadConns(4),
+			server.WithConnMaxLifetime(time.Minute),
+		)
+		require.NoError(t, err)
+
+		defer s.Close()
+
+		exercise(t, s)
+	})
+
+	t.Run("foreign keys", func(t *testing.T) {
+		s, err := server.New(filepath.Join(t.TempDir(), "fk.db"), server.WithForeignKeys(true))
+		require.NoError(t, err)
+
+		defer s.Close()
+
+		_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table parent (id INTEGER PRIMARY KEY)`})
+		require.NoError(t, err)
+
+		_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table child (id INTEGER PRIMARY KEY, parent_id INTEGER REFERENCES parent(id))`})
+		require.NoError(t, err)
+
+		_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `insert into child (id, parent_id) values (1, 1)`})
+		requireCode(t, err, twirp.FailedPrecondition)
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		s, err := server.New("options-memory", server.WithOpenMode(server.OpenModeMemory))
+		require.NoError(t, err)
+
+		defer s.Close()
+
+		exercise(t, s)
+	})
+
+	t.Run("exclusive", func(t *testing.T) {
+		s, err := server.New(filepath.Join(t.TempDir(), "exclusive.db"), server.WithLockingMode(server.LockingModeExclusive))
+		require.NoError(t, err)
+
+		defer s.Close()
+
+		exercise(t, s)
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "readonly.db")
+
+		_, err := server.New(file, server.WithOpenMode(server.OpenModeReadOnly))
+		require.Error(t, err, "database does not exist")
+
+		_, err = server.New(file, server.WithOpenMode(server.OpenModeReadWrite))
+		require.Error(t, err, "database does not exist")
+
+		s, err := server.New(file)
+		require.NoError(t, err)
+
+		exercise(t, s)
+		require.NoError(t, s.Close())
+
+		s, err = server.New(file, server.WithOpenMode(server.OpenModeReadOnly))
+		require.NoError(t, err)
+
+		defer s.Close()
+
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select intCol from testing`})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 2)
+
+		_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `delete from testing`})
+		requireCode(t, err, twirp.FailedPrecondition)
+	})
+}
@@ -0,0 +1,330 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+
+	"github.com/bakins/sqliterpc/auth"
+)
+
+// Policy restricts what a principal may do. Statements that are not allowed
+// fail with a permission denied error.
+type Policy struct {
+	// ReadOnly only allows queries. Pragmas may be read but not set.
+	ReadOnly bool `json:"read_only"`
+	// DenyTables may not be read, written, or altered.
+	DenyTables []string `json:"deny_tables"`
+	// Admin allows the database to be backed up and restored, unless the
+	// principal is also restricted by read only or denied tables.
+	Admin bool `json:"admin"`
+}
+
+// Policies are the policies for principals. A principal is restricted by
+// its own policy and the policies of all of its roles. Default applies to
+// principals that do not match any other policy, including requests that
+// are not authenticated.
+//
+// Regardless of policy, ATTACH, DETACH, and PRAGMA writable_schema are never allowed.
+// Once policies are set, backup and restore are only allowed by a policy with
+// admin set. Without policies, every principal may back up and restore.
+//
+//	{
+//	  "roles": {
+//	    "readonly": {"read_only": true},
+//	    "analytics": {"deny_tables": ["users"]},
+//	    "operator": {"admin": true}
+//	  },
+//	  "default": {"read_only": true}
+//	}
+type Policies struct {
+	// Principals are keyed by principal name.
+	Principals map[string]Policy `json:"principals"`
+	// Roles are keyed by role.
+	Roles   map[string]Policy `json:"roles"`
+	Default *Policy           `json:"default"`
+}
+
+// LoadPolicies reads policies from a JSON file.
+func LoadPolicies(filename string) (*Policies, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var p Policies
+	if err := decoder.Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	return &p, nil
+}
+
+// WithPolicies restricts principals. Principals are set by the auth middleware.
+func WithPolicies(policies *Policies) Option {
+	return optionFunc(func(c *config) {
+		c.policies = policies
+	})
+}
+
+// policy is the combination of the policies for a principal.
+type policy struct {
+	readOnly   bool
+	denyTables map[string]struct{}
+}
+
+// matched returns the policies that apply to principal.
+func (p *Policies) matched(principal *auth.Principal) []Policy {
+	if p == nil {
+		return nil
+	}
+
+	var matched []Policy
+
+	if principal != nil {
+		if pol, ok := p.Principals[principal.Name]; ok {
+			matched = append(matched, pol)
+		}
+
+		for _, role := range principal.Roles {
+			if pol, ok := p.Roles[role]; ok {
+				matched = append(matched, pol)
+			}
+		}
+	}
+
+	if len(matched) == 0 && p.Default != nil {
+		matched = append(matched, *p.Default)
+	}
+
+	return matched
+}
+
+// policy returns the policy for principal, or nil if it is not restricted.
+func (p *Policies) policy(principal *auth.Principal) *policy {
+	combined := policy{
+		denyTables: make(map[string]struct{}),
+	}
+
+	for _, pol := range p.matched(principal) {
+		combined.readOnly = combined.readOnly || pol.ReadOnly
+
+		for _, table := range pol.DenyTables {
+			combined.denyTables[strings.ToLower(table)] = struct{}{}
+		}
+	}
+
+	if !combined.readOnly && len(combined.denyTables) == 0 {
+		return nil
+	}
+
+	return &combined
+}
+
+// admin returns true if principal may back up and restore the database.
+// Principals are not restricted when there are no policies.
+func (p *Policies) admin(principal *auth.Principal) bool {
+	if p == nil {
+		return true
+	}
+
+	if p.policy(principal) != nil {
+		return false
+	}
+
+	for _, pol := range p.matched(principal) {
+		if pol.Admin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// denies returns true if table may not be used. p may be nil.
+func (p *policy) denies(table string) bool {
+	if p == nil {
+		return false
+	}
+
+	_, ok := p.denyTables[strings.ToLower(table)]
+
+	return ok
+}
+
+// not defined by go-sqlite3
+const sqliteRecursive = 33
+
+// changesTable is the temporary table the capture triggers write to.
+const changesTable = "sqliterpc_changes"
+
+// authorize is the sqlite authorizer for a connection. trigger is the name
+// of the trigger the action is in, if any.
+// see https://www.sqlite.org/c3ref/set_authorizer.html
+func (s *connState) authorize(action int, arg1, arg2, database, trigger string) int {
+	switch action {
+	case sqlite3.SQLITE_ATTACH, sqlite3.SQLITE_DETACH:
+		return sqlite3.SQLITE_DENY
+	case sqlite3.SQLITE_PRAGMA:
+		if strings.EqualFold(arg1, "writable_schema") {
+			return sqlite3.SQLITE_DENY
+		}
+	case sqlite3.SQLITE_ALTER_TABLE:
+		// arg1 is the database
+		if strings.EqualFold(arg1, "main") {
+			s.altered = true
+		}
+	case sqlite3.SQLITE_DROP_TABLE:
+		if strings.EqualFold(database, "main") {
+			s.dropping = arg1
+		}
+	}
+
+	// the capture triggers are only created by the server.
+	if s.internal || isCaptureName(trigger) {
+		return sqlite3.SQLITE_OK
+	}
+
+	if s.usesCapture(action, arg1, arg2) {
+		return sqlite3.SQLITE_DENY
+	}
+
+	if s.policy == nil {
+		return sqlite3.SQLITE_OK
+	}
+
+	return s.policy.authorize(action, arg1, arg2)
+}
+
+// usesCapture returns true if an action uses the table, triggers or functions
+// that capture changes, which principals may not use, so they cannot publish
+// changes that were not made, or stop changes from being published. The
+// triggers of a table are dropped with it, which is allowed, but the triggers
+// are created again in case the table was not dropped.
+func (s *connState) usesCapture(action int, arg1, arg2 string) bool {
+	switch action {
+	case sqlite3.SQLITE_FUNCTION:
+		return isCaptureName(arg2)
+	case sqlite3.SQLITE_CREATE_TRIGGER, sqlite3.SQLITE_CREATE_TEMP_TRIGGER, sqlite3.SQLITE_DROP_TRIGGER:
+		if isCaptureName(arg1) {
+			return true
+		}
+	case sqlite3.SQLITE_DROP_TEMP_TRIGGER:
+		if !isCaptureName(arg1) {
+			break
+		}
+
+		if s.dropping == "" || !strings.EqualFold(arg2, s.dropping) {
+			return true
+		}
+
+		s.uncaptured = true
+		s.triggersReady = false
+
+		return false
+	}
+
+	return strings.EqualFold(actionTable(action, arg1, arg2), changesTable)
+}
+
+// isCaptureName returns true if name is used by the objects that capture changes.
+func isCaptureName(name string) bool {
+	return len(name) > len("sqliterpc_") && strings.EqualFold(name[:len("sqliterpc_")], "sqliterpc_")
+}
+
+func (p *policy) authorize(action int, arg1, arg2 string) int {
+	if p.readOnly {
+		switch action {
+		case sqlite3.SQLITE_SELECT, sqlite3.SQLITE_READ, sqlite3.SQLITE_FUNCTION,
+			sqlite3.SQLITE_TRANSACTION, sqlite3.SQLITE_SAVEPOINT, sqliteRecursive:
+		case sqlite3.SQLITE_PRAGMA:
+			// arg2 is the value when setting a pragma
+			if arg2 != "" {
+				return sqlite3.SQLITE_DENY
+			}
+		default:
+			return sqlite3.SQLITE_DENY
+		}
+	}
+
+	if len(p.denyTables) > 0 {
+		if table := actionTable(action, arg1, arg2); table != "" && p.denies(table) {
+			return sqlite3.SQLITE_DENY
+		}
+	}
+
+	return sqlite3.SQLITE_OK
+}
+
+// actionTable returns the table an action applies to, if any.
+// see https://www.sqlite.org/c3ref/c_alter_table.html
+func actionTable(action int, arg1, arg2 string) string {
+	switch action {
+	case sqlite3.SQLITE_CREATE_TABLE, sqlite3.SQLITE_CREATE_TEMP_TABLE,
+		sqlite3.SQLITE_DROP_TABLE, sqlite3.SQLITE_DROP_TEMP_TABLE,
+		sqlite3.SQLITE_CREATE_VTABLE, sqlite3.SQLITE_DROP_VTABLE,
+		sqlite3.SQLITE_INSERT, sqlite3.SQLITE_UPDATE, sqlite3.SQLITE_DELETE,
+		sqlite3.SQLITE_READ, sqlite3.SQLITE_ANALYZE:
+		return arg1
+	case sqlite3.SQLITE_CREATE_INDEX, sqlite3.SQLITE_CREATE_TEMP_INDEX,
+		sqlite3.SQLITE_DROP_INDEX, sqlite3.SQLITE_DROP_TEMP_INDEX,
+		sqlite3.SQLITE_CREATE_TRIGGER, sqlite3.SQLITE_CREATE_TEMP_TRIGGER,
+		sqlite3.SQLITE_DROP_TRIGGER, sqlite3.SQLITE_DROP_TEMP_TRIGGER,
+		sqlite3.SQLITE_ALTER_TABLE:
+		return arg2
+	default:
+		return ""
+	}
+}
+
+// setPolicy sets the policy used when preparing statements on conn.
+func setPolicy(conn *sql.Conn, p *policy) error {
+	return withConnState(conn, func(state *connState) {
+		state.policy = p
+	})
+}
+
+// policyConn is a connection pinned to enforce a policy.
+type policyConn struct {
+	*sql.Conn
+	// claimed is set when the connection is owned by a cursor,
+	// which closes it when it is done.
+	claimed bool
+}
+
+// withPolicy calls fn with a connection from db that enforces p.
+func (s *DatabaseServer) withPolicy(ctx context.Context, db *sql.DB, p *policy, fn func(queryer) error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	if err := setPolicy(conn, p); err != nil {
+		_ = conn.Close()
+		return wrapError(err)
+	}
+
+	pc := policyConn{
+		Conn: conn,
+	}
+
+	err = fn(&pc)
+
+	// the policy is only needed while statements are prepared.
+	_ = setPolicy(conn, nil)
+
+	if !pc.claimed {
+		_ = conn.Close()
+	}
+
+	return err
+}
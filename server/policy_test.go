@@ -0,0 +1,181 @@
+package server_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/auth"
+	"github.com/bakins/sqliterpc/server"
+)
+
+func requireCode(t *testing.T, err error, code twirp.ErrorCode) {
+	t.Helper()
+
+	require.Error(t, err)
+
+	twerr, ok := err.(twirp.Error)
+	require.True(t, ok, "expected a twirp error, got %T", err)
+	require.Equal(t, code, twerr.Code(), twerr.Msg())
+}
+
+func TestPolicies(t *testing.T) {
+	file := "policy.db"
+	defer os.Remove(file)
+
+	policyFile := filepath.Join(t.TempDir(), "policy.json")
+	err := os.WriteFile(policyFile, []byte(`{
+		"roles": {
+			"readonly": {"read_only": true},
+			"analytics": {"deny_tables": ["Users"]}
+		}
+	}`), 0o600)
+	require.NoError(t, err)
+
+	policies, err := server.LoadPolicies(policyFile)
+	require.NoError(t, err)
+
+	s, err := server.New(file, server.WithPolicies(policies))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	admin := auth.ToContext(ctx, &auth.Principal{Name: "admin"})
+	readonly := auth.ToContext(ctx, &auth.Principal{Name: "reader", Roles: []string{"readonly"}})
+	analytics := auth.ToContext(ctx, &auth.Principal{Name: "analyst", Roles: []string{"analytics"}})
+
+	statements := []string{
+		`create table users (id INTEGER PRIMARY KEY, name TEXT)`,
+		`create table orders (id INTEGER PRIMARY KEY, user_id INTEGER)`,
+		`insert into users (id, name) values (1, 'one'), (2, 'two')`,
+		`insert into orders (id, user_id) values (1, 1), (2, 1), (3, 2)`,
+	}
+
+	for _, statement := range statements {
+		_, err = s.Exec(admin, &sqliterpc.ExecRequest{Sql: statement})
+		require.NoError(t, err)
+	}
+
+	t.Run("always denied", func(t *testing.T) {
+		for _, c := range []context.Context{ctx, admin, readonly, analytics} {
+			_, err := s.Exec(c, &sqliterpc.ExecRequest{Sql: `ATTACH DATABASE 'other.db' AS other`})
+			requireCode(t, err, twirp.PermissionDenied)
+
+			_, err = s.Exec(c, &sqliterpc.ExecRequest{Sql: `PRAGMA writable_schema = ON`})
+			requireCode(t, err, twirp.PermissionDenied)
+		}
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		resp, err := s.Query(readonly, &sqliterpc.QueryRequest{Sql: `select name from users`})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 2)
+
+		_, err = s.Exec(readonly, &sqliterpc.ExecRequest{Sql: `PRAGMA user_version`})
+		require.NoError(t, err)
+
+		_, err = s.Exec(readonly, &sqliterpc.ExecRequest{Sql: `PRAGMA user_version = 1`})
+		requireCode(t, err, twirp.PermissionDenied)
+
+		_, err = s.Exec(readonly, &sqliterpc.ExecRequest{Sql: `insert into users (id, name) values (3, 'three')`})
+		requireCode(t, err, twirp.PermissionDenied)
+
+		_, err = s.Exec(readonly, &sqliterpc.ExecRequest{Sql: `create table other (id INTEGER)`})
+		requireCode(t, err, twirp.PermissionDenied)
+	})
+
+	t.Run("deny tables", func(t *testing.T) {
+		_, err := s.Query(analytics, &sqliterpc.QueryRequest{Sql: `select name from users`})
+		requireCode(t, err, twirp.PermissionDenied)
+
+		_, err = s.Query(analytics, &sqliterpc.QueryRequest{Sql: `select o.id from orders o join users u on u.id = o.user_id`})
+		requireCode(t, err, twirp.PermissionDenied)
+
+		_, err = s.Exec(analytics, &sqliterpc.ExecRequest{Sql: `delete from users`})
+		requireCode(t, err, twirp.PermissionDenied)
+
+		resp, err := s.Query(analytics, &sqliterpc.QueryRequest{Sql: `select id from orders`})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 3)
+	})
+
+	t.Run("schema", func(t *testing.T) {
+		tables, err := s.ListTables(analytics, &sqliterpc.ListTablesRequest{})
+		require.NoError(t, err)
+		require.Len(t, tables.Tables, 1)
+		require.Equal(t, "orders", tables.Tables[0].Name)
+
+		_, err = s.DescribeTable(analytics, &sqliterpc.DescribeTableRequest{Name: "users"})
+		requireCode(t, err, twirp.PermissionDenied)
+
+		_, err = s.ListIndexes(analytics, &sqliterpc.ListIndexesRequest{Table: "USERS"})
+		requireCode(t, err, twirp.PermissionDenied)
+
+		_, err = s.DescribeTable(analytics, &sqliterpc.DescribeTableRequest{Name: "orders"})
+		require.NoError(t, err)
+
+		tables, err = s.ListTables(admin, &sqliterpc.ListTablesRequest{})
+		require.NoError(t, err)
+		require.Len(t, tables.Tables, 2)
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		resp, err := s.Query(analytics, &sqliterpc.QueryRequest{Sql: `select id from orders`, PageSize: 1})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 1)
+
+		count := 1
+		for resp.NextPageToken != "" {
+			resp, err = s.Query(analytics, &sqliterpc.QueryRequest{PageToken: resp.NextPageToken})
+			require.NoError(t, err)
+			count += len(resp.Rows)
+		}
+
+		require.Equal(t, 3, count)
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		begin, err := s.Begin(readonly, &sqliterpc.BeginRequest{})
+		require.NoError(t, err)
+
+		_, err = s.Query(readonly, &sqliterpc.QueryRequest{Sql: `select name from users`, TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+
+		_, err = s.Exec(readonly, &sqliterpc.ExecRequest{Sql: `insert into users (id, name) values (3, 'three')`, TransactionId: begin.TransactionId})
+		requireCode(t, err, twirp.PermissionDenied)
+
+		_, err = s.Commit(readonly, &sqliterpc.CommitRequest{TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		resp, err := s.Batch(readonly, &sqliterpc.BatchRequest{
+			Steps: []*sqliterpc.BatchStep{
+				{Step: &sqliterpc.BatchStep_Query{Query: &sqliterpc.QueryRequest{Sql: `select name from users`}}},
+				{Step: &sqliterpc.BatchStep_Exec{Exec: &sqliterpc.ExecRequest{Sql: `delete from users`}}},
+			},
+		})
+		require.NoError(t, err)
+		require.False(t, resp.Committed)
+		require.Equal(t, string(twirp.PermissionDenied), resp.Results[1].GetError().Code)
+	})
+
+	// connections used by restricted principals are not left restricted
+	for i := 0; i < 20; i++ {
+		_, err := s.Exec(admin, &sqliterpc.ExecRequest{Sql: `update users set name = name`})
+		require.NoError(t, err)
+	}
+
+	resp, err := s.Query(admin, &sqliterpc.QueryRequest{Sql: `select name from users`})
+	require.NoError(t, err)
+	require.Len(t, resp.Rows, 2)
+}
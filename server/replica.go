@@ -0,0 +1,321 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bakins/sqliterpc"
+)
+
+const (
+	minReplicaBackoff = 100 * time.Millisecond
+	maxReplicaBackoff = 30 * time.Second
+)
+
+// WithPrimary makes the server a read replica of the sqliterpc server at url.
+// The replica copies the primary's database, then applies the changes
+// committed on the primary. It serves queries, and rejects writes with a
+// FailedPrecondition error with the primary's url in the "primary" meta.
+//
+// client is used for requests to the primary, so it should add any
+// credentials the primary requires. If the primary has policies, it must have
+// an admin policy for the client, as it backs up the database. If client is nil, http.DefaultClient is used.
+//
+// Changes are copied by rowid, so changes to tables without rowids, tables
+// with a column named rowid, and virtual tables are not replicated, and
+// triggers that write on the replica are not supported.
+func WithPrimary(url string, client *http.Client) Option {
+	return optionFunc(func(c *config) {
+		c.primary = url
+		c.primaryClient = client
+	})
+}
+
+// replica applies the changes committed on a primary server.
+type replica struct {
+	server  *DatabaseServer
+	primary string
+	client  *http.Client
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+func newReplica(s *DatabaseServer, primary string, client *http.Client) *replica {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	primary = strings.TrimSuffix(primary, "/")
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	r := replica{
+		server:  s,
+		primary: primary,
+		client:  client,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+
+	go r.run(ctx)
+
+	return &r
+}
+
+func (r *replica) close() {
+	r.cancel()
+	<-r.done
+}
+
+// run replicates until ctx is done, starting over with a new copy
+// of the database after any error.
+func (r *replica) run(ctx context.Context) {
+	defer close(r.done)
+
+	backoff := minReplicaBackoff
+
+	for {
+		synced, _ := r.replicate(ctx)
+
+		if synced {
+			backoff = minReplicaBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		if !synced {
+			backoff *= 2
+			if backoff > maxReplicaBackoff {
+				backoff = maxReplicaBackoff
+			}
+		}
+	}
+}
+
+// replicate subscribes to the primary's changes, copies the database, then
+// applies changes until an error occurs. synced is true if the copy was made.
+// Subscribing first means no change is missed. Changes made before the copy
+// are applied again, which is harmless as the changes made after them are
+// also applied, in order.
+func (r *replica) replicate(ctx context.Context) (synced bool, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	body, err := r.subscribe(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer body.Close()
+
+	if err := r.sync(ctx); err != nil {
+		return false, err
+	}
+
+	for {
+		var frame sqliterpc.SubscribeFrame
+
+		if err := sqliterpc.ReadMessage(body, &frame); err != nil {
+			return true, err
+		}
+
+		if e := frame.GetError(); e != nil {
+			return true, twirp.NewError(twirp.ErrorCode(e.Code), e.Message)
+		}
+
+		set := frame.GetChanges()
+		if set == nil {
+			return true, fmt.Errorf("unexpected frame %T", frame.Frame)
+		}
+
+		// schema changes are not part of the feed, so copy the database again.
+		// The copy includes the changes in set.
+		if set.SchemaChanged {
+			err = r.sync(ctx)
+		} else {
+			err = r.apply(ctx, set.Changes)
+		}
+
+		if err != nil {
+			return true, err
+		}
+	}
+}
+
+// subscribe starts a subscription to every table on the primary and waits
+// for it to start.
+func (r *replica) subscribe(ctx context.Context) (io.ReadCloser, error) {
+	req, err := proto.Marshal(&sqliterpc.SubscribeRequest{IncludeRows: true})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, r.primary+sqliterpc.SubscribePath, bytes.NewReader(req))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Content-Type", sqliterpc.ContentTypeProtobuf)
+
+	resp, err := r.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("subscribe failed with status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	var frame sqliterpc.SubscribeFrame
+
+	if err := sqliterpc.ReadMessage(resp.Body, &frame); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+
+	if frame.GetStarted() == nil {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("unexpected first frame %T", frame.Frame)
+	}
+
+	return resp.Body, nil
+}
+
+// sync replaces the local database with a backup of the primary.
+func (r *replica) sync(ctx context.Context) error {
+	filename, err := tempFile("sqliterpc-replica-*.db")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(filename)
+
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	err = FetchBackup(ctx, r.client, r.primary, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return r.server.restore(ctx, filename)
+}
+
+// apply writes the rows of a committed transaction in a single transaction.
+func (r *replica) apply(ctx context.Context, changes []*sqliterpc.Change) error {
+	conn, err := r.server.writer.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return err
+	}
+
+	if err := applyChanges(ctx, conn, changes); err != nil {
+		_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, "COMMIT")
+
+	return err
+}
+
+// applyChanges makes changes in order. Deleted rows are deleted, and
+// inserted and updated rows are updated, or inserted if they do not exist.
+// Rows are not replaced, which would delete them first and run foreign key
+// actions. Other rows that conflict are replaced, as rows the primary
+// deleted to resolve a conflict are not captured.
+// see https://www.sqlite.org/lang_conflict.html
+func applyChanges(ctx context.Context, conn *sql.Conn, changes []*sqliterpc.Change) error {
+	// rows in a transaction may refer to each other in any order.
+	if _, err := conn.ExecContext(ctx, "PRAGMA defer_foreign_keys = ON"); err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		table := quoteIdentifier(change.Table)
+
+		if change.Operation == sqliterpc.ChangeOperation_CHANGE_OPERATION_DELETE {
+			if _, err := conn.ExecContext(ctx, "DELETE FROM "+table+" WHERE rowid = ?", change.Rowid); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if change.Row == nil {
+			return fmt.Errorf("%s of %s is missing its row", change.Operation, change.Table)
+		}
+
+		values, err := valuesToParams(change.Row.Values)
+		if err != nil {
+			return err
+		}
+
+		if len(values) != len(change.Columns) {
+			return fmt.Errorf("change to %s has %d columns and %d values", change.Table, len(change.Columns), len(values))
+		}
+
+		columns := make([]string, len(change.Columns))
+		for i, column := range change.Columns {
+			columns[i] = quoteIdentifier(column.Name)
+		}
+
+		args := append(values, change.Rowid)
+
+		result, err := conn.ExecContext(ctx, "UPDATE OR REPLACE "+table+" SET "+strings.Join(columns, " = ?, ")+" = ? WHERE rowid = ?", args...)
+		if err != nil {
+			return err
+		}
+
+		if affected, _ := result.RowsAffected(); affected > 0 {
+			continue
+		}
+
+		args = append([]interface{}{change.Rowid}, values...)
+		placeholders := strings.Repeat(", ?", len(values))
+
+		_, err = conn.ExecContext(ctx, "INSERT OR REPLACE INTO "+table+" (rowid, "+strings.Join(columns, ", ")+") VALUES (?"+placeholders+")", args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkWritable returns an error if the server is a replica.
+func (s *DatabaseServer) checkWritable() error {
+	if s.replica == nil {
+		return nil
+	}
+
+	return twirp.NewError(twirp.FailedPrecondition, "database is a read replica").
+		WithMeta(sqliterpc.ErrorMetaPrimary, s.replica.primary)
+}
@@ -0,0 +1,135 @@
+package server_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/server"
+)
+
+func TestReplica(t *testing.T) {
+	dir := t.TempDir()
+
+	// the replica backs up the primary
+	policies := server.Policies{
+		Default: &server.Policy{Admin: true},
+	}
+
+	primary, err := server.New(filepath.Join(dir, "primary.db"), server.WithPolicies(&policies))
+	require.NoError(t, err)
+
+	defer primary.Close()
+
+	primarySvr := httptest.NewServer(server.NewHandler(primary))
+	defer primarySvr.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	exec := func(sql string) {
+		_, err := primary.Exec(ctx, &sqliterpc.ExecRequest{Sql: sql})
+		require.NoError(t, err)
+	}
+
+	exec(`create table testing (id INTEGER PRIMARY KEY, name TEXT)`)
+	exec(`insert into testing (id, name) values (1, 'one'), (2, 'two')`)
+
+	replica, err := server.New(filepath.Join(dir, "replica.db"), server.WithPrimary(primarySvr.URL, nil))
+	require.NoError(t, err)
+
+	defer replica.Close()
+
+	replicaSvr := httptest.NewServer(server.NewHandler(replica))
+	defer replicaSvr.Close()
+
+	names := func(sql string) []string {
+		resp, err := replica.Query(ctx, &sqliterpc.QueryRequest{Sql: sql})
+		if err != nil {
+			return nil
+		}
+
+		var names []string
+		for _, row := range resp.Rows {
+			names = append(names, row.Values[0].GetTextValue().GetValue())
+		}
+
+		return names
+	}
+
+	requireNames := func(sql string, expected ...string) {
+		require.Eventually(t, func() bool {
+			return assert.ObjectsAreEqual(expected, names(sql))
+		}, time.Second*5, time.Millisecond*10)
+	}
+
+	// the initial copy
+	requireNames(`select name from testing order by id`, "one", "two")
+
+	exec(`insert into testing (id, name) values (3, 'three')`)
+	exec(`update testing set name = 'uno' where id = 1`)
+	exec(`delete from testing where id = 2`)
+
+	requireNames(`select name from testing order by id`, "uno", "three")
+
+	// schema changes copy the database again
+	exec(`create table other (id INTEGER PRIMARY KEY, name TEXT)`)
+	exec(`insert into other (id, name) values (1, 'other')`)
+
+	requireNames(`select name from other`, "other")
+
+	// rows are copied as they were committed
+	_, err = primary.ExecScript(ctx, &sqliterpc.ExecScriptRequest{
+		Sql: `
+			insert into testing (id, name) values (5, 'five');
+			update testing set name = 'cinq' where id = 5;
+			insert into testing (id, name) values (6, 'six');
+			delete from testing where id = 6;
+		`,
+		Transaction: true,
+	})
+	require.NoError(t, err)
+
+	requireNames(`select name from testing order by id`, "uno", "three", "cinq")
+
+	// generated columns are computed by the replica
+	exec(`create table generated (id INTEGER PRIMARY KEY, name TEXT UNIQUE, upper TEXT AS (upper(name)))`)
+	exec(`insert into generated (id, name) values (1, 'a'), (2, 'b')`)
+	requireNames(`select upper from generated order by id`, "A", "B")
+
+	// rows deleted to resolve a conflict are replaced
+	exec(`insert or replace into generated (id, name) values (3, 'a')`)
+	exec(`update generated set id = 4 where id = 2`)
+
+	requireNames(`select upper || id from generated order by id`, "A3", "B4")
+
+	t.Run("writes", func(t *testing.T) {
+		client := sqliterpc.NewDatabaseServiceProtobufClient(replicaSvr.URL, replicaSvr.Client())
+
+		_, err := client.Exec(ctx, &sqliterpc.ExecRequest{Sql: `insert into testing (id, name) values (4, 'four')`})
+		requireCode(t, err, twirp.FailedPrecondition)
+
+		var twerr twirp.Error
+		require.ErrorAs(t, err, &twerr)
+		require.Equal(t, primarySvr.URL, twerr.Meta(sqliterpc.ErrorMetaPrimary))
+
+		_, err = client.Query(ctx, &sqliterpc.QueryRequest{Sql: `delete from testing returning id`})
+		requireCode(t, err, twirp.FailedPrecondition)
+
+		_, err = client.Begin(ctx, &sqliterpc.BeginRequest{})
+		requireCode(t, err, twirp.FailedPrecondition)
+
+		tx, err := client.Begin(ctx, &sqliterpc.BeginRequest{ReadOnly: true})
+		require.NoError(t, err)
+
+		_, err = client.Rollback(ctx, &sqliterpc.RollbackRequest{TransactionId: tx.TransactionId})
+		require.NoError(t, err)
+	})
+}
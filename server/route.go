@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+)
+
+// maxRoutes bounds the number of queries remembered by routes.
+const maxRoutes = 1024
+
+// routes remembers whether queries only read, so each query is only
+// classified once.
+type routes struct {
+	lock     sync.Mutex
+	readOnly map[string]bool
+	max      int
+}
+
+func newRoutes(max int) *routes {
+	r := routes{
+		readOnly: make(map[string]bool),
+		max:      max,
+	}
+
+	return &r
+}
+
+func (r *routes) get(query string) (bool, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	readOnly, ok := r.readOnly[query]
+	return readOnly, ok
+}
+
+func (r *routes) set(query string, readOnly bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// queries are usually a small set repeated, so simply start over when full.
+	if len(r.readOnly) >= r.max {
+		r.readOnly = make(map[string]bool)
+	}
+
+	r.readOnly[query] = readOnly
+}
+
+// route returns the database to run a query on. A prepared statement runs
+// on the database it was prepared on. Queries that only read use the readers.
+// Anything else, including sql that cannot be prepared, uses the writer,
+// which reports any error when the query is run. A replica has no writer
+// for queries, so returns an error for queries that write and uses the
+// readers for sql that cannot be prepared.
+func (s *DatabaseServer) route(ctx context.Context, st *statement, query string) (*sql.DB, error) {
+	if st != nil {
+		return st.db, nil
+	}
+
+	readOnly, ok := s.routes.get(query)
+	if !ok {
+		info, err := s.inspect(ctx, query, nil)
+		if err != nil {
+			if s.replica != nil {
+				return s.readers, nil
+			}
+			return s.writer, nil
+		}
+
+		readOnly = info.readOnly
+		s.routes.set(query, readOnly)
+	}
+
+	if readOnly {
+		return s.readers, nil
+	}
+
+	if err := s.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	return s.writer, nil
+}
+
+// inspect prepares query on a reader to describe it. If p is not nil, the
+// connection enforces it, so statements that are not allowed fail now
+// rather than when run.
+func (s *DatabaseServer) inspect(ctx context.Context, query string, p *policy) (statementInfo, error) {
+	if err := s.holds.check(s.readers); err != nil {
+		return statementInfo{}, err
+	}
+
+	conn, err := s.readers.Conn(ctx)
+	if err != nil {
+		return statementInfo{}, err
+	}
+
+	defer conn.Close()
+
+	if p != nil {
+		if err := setPolicy(conn, p); err != nil {
+			return statementInfo{}, err
+		}
+
+		defer func() {
+			_ = setPolicy(conn, nil)
+		}()
+	}
+
+	return inspect(conn, query)
+}
+
+// writerHolds counts the writer's connections that are held between requests
+// by transactions and cursors. Requests for the writer would wait until one
+// is released, which may not be until the transaction times out, so they
+// fail with a busy error instead when every connection is held.
+type writerHolds struct {
+	writer *sql.DB
+	max    int32
+	held   int32
+}
+
+func newWriterHolds(writer *sql.DB, max int) *writerHolds {
+	w := writerHolds{
+		writer: writer,
+		max:    int32(max),
+	}
+
+	return &w
+}
+
+// hold records that a connection of db is held until release is called.
+func (w *writerHolds) hold(db *sql.DB) (release func()) {
+	if db != w.writer {
+		return func() {}
+	}
+
+	atomic.AddInt32(&w.held, 1)
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			atomic.AddInt32(&w.held, -1)
+		})
+	}
+}
+
+// check returns an error if db is the writer and all of its connections
+// are held. The error is the same as sqlite's when another connection
+// holds the write lock.
+func (w *writerHolds) check(db *sql.DB) error {
+	if db != w.writer || atomic.LoadInt32(&w.held) < w.max {
+		return nil
+	}
+
+	return twirp.NewError(twirp.Unavailable, "the writer is held by open transactions or cursors").
+		WithMeta(sqliterpc.ErrorMetaCode, strconv.Itoa(int(sqlite3.ErrBusy))).
+		WithMeta(sqliterpc.ErrorMetaExtendedCode, strconv.Itoa(int(sqlite3.ErrBusy)))
+}
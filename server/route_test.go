@@ -0,0 +1,228 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/server"
+)
+
+func TestReadersAndWriter(t *testing.T) {
+	file := "route.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file, server.WithBusyTimeout(time.Millisecond))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table testing (intCol INTEGER, textCol TEXT)`})
+	require.NoError(t, err)
+
+	t.Run("concurrent writes", func(t *testing.T) {
+		var wg sync.WaitGroup
+		errs := make(chan error, 100)
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				for j := 0; j < 10; j++ {
+					_, err := s.Exec(ctx, &sqliterpc.ExecRequest{
+						Sql: fmt.Sprintf(`insert into testing (intCol, textCol) values (%d, 'text')`, i*10+j),
+					})
+					errs <- err
+				}
+			}(i)
+		}
+
+		wg.Wait()
+		close(errs)
+
+		// writes wait for the writer rather than failing with SQLITE_BUSY
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select intCol from testing`})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 100)
+	})
+
+	t.Run("read during write transaction", func(t *testing.T) {
+		begin, err := s.Begin(ctx, &sqliterpc.BeginRequest{})
+		require.NoError(t, err)
+
+		_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `delete from testing`, TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+
+		// readers are not blocked and do not see uncommitted writes
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select intCol from testing`})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 100)
+
+		readOnly, err := s.Begin(ctx, &sqliterpc.BeginRequest{ReadOnly: true})
+		require.NoError(t, err)
+
+		resp, err = s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select intCol from testing`, TransactionId: readOnly.TransactionId})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 100)
+
+		_, err = s.Commit(ctx, &sqliterpc.CommitRequest{TransactionId: readOnly.TransactionId})
+		require.NoError(t, err)
+
+		_, err = s.Commit(ctx, &sqliterpc.CommitRequest{TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+
+		resp, err = s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select intCol from testing`})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 0)
+	})
+
+	t.Run("writer held", func(t *testing.T) {
+		begin, err := s.Begin(ctx, &sqliterpc.BeginRequest{})
+		require.NoError(t, err)
+
+		// writes fail rather than waiting for the transaction
+		start := time.Now()
+
+		_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `insert into testing (intCol, textCol) values (1, 'text')`})
+		requireCode(t, err, twirp.Unavailable)
+		require.Equal(t, "5", err.(twirp.Error).Meta(sqliterpc.ErrorMetaCode))
+
+		_, err = s.Begin(ctx, &sqliterpc.BeginRequest{})
+		requireCode(t, err, twirp.Unavailable)
+
+		_, err = s.ExecScript(ctx, &sqliterpc.ExecScriptRequest{Sql: `delete from testing`})
+		requireCode(t, err, twirp.Unavailable)
+
+		require.Less(t, time.Since(start), time.Second)
+
+		// reads and the transaction are not affected
+		_, err = s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select intCol from testing`})
+		require.NoError(t, err)
+
+		_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `insert into testing (intCol, textCol) values (1, 'text')`, TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+
+		_, err = s.Rollback(ctx, &sqliterpc.RollbackRequest{TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+
+		// a cursor of a query that writes holds the writer until it is read
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{
+			Sql:      `insert into testing (intCol, textCol) values (1, 'text'), (2, 'text') returning intCol`,
+			PageSize: 1,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.NextPageToken)
+
+		_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `delete from testing`})
+		requireCode(t, err, twirp.Unavailable)
+
+		for resp.NextPageToken != "" {
+			resp, err = s.Query(ctx, &sqliterpc.QueryRequest{PageToken: resp.NextPageToken})
+			require.NoError(t, err)
+		}
+
+		_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `delete from testing`})
+		require.NoError(t, err)
+	})
+
+	t.Run("query that writes", func(t *testing.T) {
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: `insert into testing (intCol, textCol) values (1, 'text') returning intCol`})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 1)
+
+		prepared, err := s.Prepare(ctx, &sqliterpc.PrepareRequest{Sql: `insert into testing (intCol, textCol) values (2, 'text') returning intCol`})
+		require.NoError(t, err)
+
+		resp, err = s.Query(ctx, &sqliterpc.QueryRequest{StatementId: prepared.StatementId})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 1)
+
+		resp, err = s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select intCol from testing`})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 2)
+	})
+}
+
+// BenchmarkReadWrite runs a mix of one write to four reads in parallel.
+// Compare a single writer to pooled writers with:
+//
+//	go test -run XXX -bench ReadWrite ./server
+func BenchmarkReadWrite(b *testing.B) {
+	for _, writers := range []int{1, 16} {
+		b.Run(fmt.Sprintf("writers=%d", writers), func(b *testing.B) {
+			file := "readwrite.db"
+			defer os.Remove(file)
+
+			s, err := server.New(file, server.WithMaxWriteConns(writers))
+			require.NoError(b, err)
+
+			defer s.Close()
+
+			ctx := context.Background()
+
+			_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `DROP TABLE IF EXISTS testing`})
+			require.NoError(b, err)
+
+			_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table testing (intCol INTEGER, textCol TEXT)`})
+			require.NoError(b, err)
+
+			insert := sqliterpc.ExecRequest{
+				Sql: `insert into testing (intCol, textCol) values(?, ?)`,
+				Parameters: []*sqliterpc.Value{
+					{Kind: &sqliterpc.Value_IntegerValue{IntegerValue: &sqliterpc.IntergerValue{Value: 10, Valid: true}}},
+					{Kind: &sqliterpc.Value_TextValue{TextValue: &sqliterpc.TextValue{Value: "hello", Valid: true}}},
+				},
+			}
+
+			sel := sqliterpc.QueryRequest{
+				Sql: `select intCol, textCol from testing where rowid = 1`,
+			}
+
+			_, err = s.Exec(ctx, &insert)
+			require.NoError(b, err)
+
+			var (
+				lock   sync.Mutex
+				failed int
+			)
+
+			b.ResetTimer()
+
+			b.RunParallel(func(pb *testing.PB) {
+				var i int
+				for pb.Next() {
+					var err error
+					if i%5 == 0 {
+						_, err = s.Exec(ctx, &insert)
+					} else {
+						_, err = s.Query(ctx, &sel)
+					}
+					i++
+
+					if err != nil {
+						lock.Lock()
+						failed++
+						lock.Unlock()
+					}
+				}
+			})
+
+			b.ReportMetric(float64(failed), "errors")
+		})
+	}
+}
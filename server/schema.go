@@ -0,0 +1,387 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/auth"
+)
+
+// The schema is read using the pragma table-valued functions so the
+// table name can be passed as a parameter.
+// see https://www.sqlite.org/pragma.html#pragfunc
+//
+// Tables denied by the caller's policy are left out of lists, and cannot be
+// described.
+
+func (s *DatabaseServer) ListTables(ctx context.Context, req *sqliterpc.ListTablesRequest) (*sqliterpc.ListTablesResponse, error) {
+	query := "SELECT name, type, sql FROM sqlite_master WHERE type IN ('table', 'view')"
+	if !req.IncludeInternal {
+		query += " AND name NOT LIKE 'sqlite\\_%' ESCAPE '\\'"
+	}
+	query += " ORDER BY name"
+
+	rows, err := s.readers.QueryContext(ctx, query)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	defer rows.Close()
+
+	p := s.policies.policy(auth.FromContext(ctx))
+
+	var resp sqliterpc.ListTablesResponse
+
+	for rows.Next() {
+		table, err := scanTable(rows)
+		if err != nil {
+			return nil, wrapError(err)
+		}
+
+		if p.denies(table.Name) {
+			continue
+		}
+
+		resp.Tables = append(resp.Tables, table)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return &resp, nil
+}
+
+func (s *DatabaseServer) DescribeTable(ctx context.Context, req *sqliterpc.DescribeTableRequest) (*sqliterpc.DescribeTableResponse, error) {
+	if req.Name == "" {
+		return nil, twirp.RequiredArgumentError("name")
+	}
+
+	if err := s.checkTable(ctx, req.Name); err != nil {
+		return nil, err
+	}
+
+	// use a single connection so the schema is consistent
+	conn, err := s.readers.Conn(ctx)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	defer conn.Close()
+
+	row := conn.QueryRowContext(ctx, "SELECT name, type, sql FROM sqlite_master WHERE type IN ('table', 'view') AND name = ?", req.Name)
+
+	table, err := scanTable(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, twirp.NotFoundError("table not found")
+		}
+		return nil, wrapError(err)
+	}
+
+	resp := sqliterpc.DescribeTableResponse{
+		Table: table,
+	}
+
+	if resp.Columns, err = tableColumns(ctx, conn, req.Name); err != nil {
+		return nil, wrapError(err)
+	}
+
+	if resp.Indexes, err = tableIndexes(ctx, conn, req.Name); err != nil {
+		return nil, wrapError(err)
+	}
+
+	if resp.ForeignKeys, err = foreignKeys(ctx, conn, req.Name); err != nil {
+		return nil, wrapError(err)
+	}
+
+	if resp.Triggers, err = triggers(ctx, conn, req.Name); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return &resp, nil
+}
+
+func (s *DatabaseServer) ListIndexes(ctx context.Context, req *sqliterpc.ListIndexesRequest) (*sqliterpc.ListIndexesResponse, error) {
+	if req.Table != "" {
+		if err := s.checkTable(ctx, req.Table); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := s.readers.Conn(ctx)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	defer conn.Close()
+
+	var tables []string
+
+	if req.Table != "" {
+		tables = []string{req.Table}
+	} else {
+		tables, err = tableNames(ctx, conn)
+		if err != nil {
+			return nil, wrapError(err)
+		}
+	}
+
+	p := s.policies.policy(auth.FromContext(ctx))
+
+	var resp sqliterpc.ListIndexesResponse
+
+	for _, table := range tables {
+		if p.denies(table) {
+			continue
+		}
+
+		indexes, err := tableIndexes(ctx, conn, table)
+		if err != nil {
+			return nil, wrapError(err)
+		}
+
+		resp.Indexes = append(resp.Indexes, indexes...)
+	}
+
+	return &resp, nil
+}
+
+// checkTable returns an error if the caller's policy denies table.
+func (s *DatabaseServer) checkTable(ctx context.Context, table string) error {
+	if s.policies.policy(auth.FromContext(ctx)).denies(table) {
+		return twirp.NewError(twirp.PermissionDenied, "not allowed by policy").WithMeta("table", table)
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTable(row scanner) (*sqliterpc.Table, error) {
+	var (
+		name      string
+		tableType string
+		statement sql.NullString
+	)
+
+	if err := row.Scan(&name, &tableType, &statement); err != nil {
+		return nil, err
+	}
+
+	table := sqliterpc.Table{
+		Name: name,
+		Type: sqliterpc.TableType_TABLE_TYPE_TABLE,
+		Sql:  statement.String,
+	}
+
+	if tableType == "view" {
+		table.Type = sqliterpc.TableType_TABLE_TYPE_VIEW
+	}
+
+	return &table, nil
+}
+
+func tableNames(ctx context.Context, conn *sql.Conn) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT name FROM sqlite_master WHERE type = 'table' ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var names []string
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+func tableColumns(ctx context.Context, conn *sql.Conn, table string) ([]*sqliterpc.TableColumn, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT name, type, "notnull", dflt_value, pk FROM pragma_table_info(?) ORDER BY cid`, table)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var columns []*sqliterpc.TableColumn
+
+	for rows.Next() {
+		var (
+			column       sqliterpc.TableColumn
+			defaultValue sql.NullString
+		)
+
+		if err := rows.Scan(&column.Name, &column.DeclaredType, &column.NotNull, &defaultValue, &column.PrimaryKey); err != nil {
+			return nil, err
+		}
+
+		column.DefaultValue = defaultValue.String
+		column.HasDefault = defaultValue.Valid
+
+		// go-sqlite3 upper cases declared types for query results
+		column.Type = databaseTypeConvSqlite(strings.ToUpper(column.DeclaredType))
+		if column.Type == sqliterpc.TypeCode_TYPE_CODE_NULL {
+			column.Type = sqliterpc.TypeCode_TYPE_CODE_UNSPECIFIED
+		}
+
+		columns = append(columns, &column)
+	}
+
+	return columns, rows.Err()
+}
+
+func tableIndexes(ctx context.Context, conn *sql.Conn, table string) ([]*sqliterpc.Index, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT il.name, il."unique", il.origin, il.partial, m.sql
+		FROM pragma_index_list(?) AS il
+		LEFT JOIN sqlite_master AS m ON m.type = 'index' AND m.name = il.name
+		ORDER BY il.name`, table)
+	if err != nil {
+		return nil, err
+	}
+
+	// read all indexes before querying columns as the connection is shared
+	var indexes []*sqliterpc.Index
+
+	for rows.Next() {
+		var (
+			index     sqliterpc.Index
+			statement sql.NullString
+		)
+
+		if err := rows.Scan(&index.Name, &index.Unique, &index.Origin, &index.Partial, &statement); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+
+		index.Table = table
+		index.Sql = statement.String
+
+		indexes = append(indexes, &index)
+	}
+
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return nil, err
+	}
+
+	_ = rows.Close()
+
+	for _, index := range indexes {
+		if index.Columns, err = indexColumns(ctx, conn, index.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return indexes, nil
+}
+
+func indexColumns(ctx context.Context, conn *sql.Conn, index string) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT name FROM pragma_index_info(?) ORDER BY seqno", index)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var columns []string
+
+	for rows.Next() {
+		// name is NULL for expressions
+		var name sql.NullString
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+
+		columns = append(columns, name.String)
+	}
+
+	return columns, rows.Err()
+}
+
+func foreignKeys(ctx context.Context, conn *sql.Conn, table string) ([]*sqliterpc.ForeignKey, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT id, "table", "from", "to", on_update, on_delete, "match"
+		FROM pragma_foreign_key_list(?) ORDER BY id, seq`, table)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var keys []*sqliterpc.ForeignKey
+
+	for rows.Next() {
+		var (
+			id       int32
+			parent   string
+			from     string
+			to       sql.NullString
+			onUpdate string
+			onDelete string
+			match    string
+		)
+
+		if err := rows.Scan(&id, &parent, &from, &to, &onUpdate, &onDelete, &match); err != nil {
+			return nil, err
+		}
+
+		// multi-column keys are returned as one row per column
+		if len(keys) == 0 || keys[len(keys)-1].Id != id {
+			keys = append(keys, &sqliterpc.ForeignKey{
+				Id:              id,
+				ReferencedTable: parent,
+				OnUpdate:        onUpdate,
+				OnDelete:        onDelete,
+				Match:           match,
+			})
+		}
+
+		key := keys[len(keys)-1]
+		key.Columns = append(key.Columns, from)
+		key.ReferencedColumns = append(key.ReferencedColumns, to.String)
+	}
+
+	return keys, rows.Err()
+}
+
+func triggers(ctx context.Context, conn *sql.Conn, table string) ([]*sqliterpc.Trigger, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT name, sql FROM sqlite_master WHERE type = 'trigger' AND tbl_name = ? ORDER BY name", table)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var list []*sqliterpc.Trigger
+
+	for rows.Next() {
+		trigger := sqliterpc.Trigger{
+			Table: table,
+		}
+
+		var statement sql.NullString
+		if err := rows.Scan(&trigger.Name, &statement); err != nil {
+			return nil, err
+		}
+
+		trigger.Sql = statement.String
+
+		list = append(list, &trigger)
+	}
+
+	return list, rows.Err()
+}
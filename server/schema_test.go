@@ -0,0 +1,144 @@
+package server_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/server"
+)
+
+func TestSchema(t *testing.T) {
+	file := "schema.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file)
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	statements := []string{
+		`create table authors (id INTEGER PRIMARY KEY, name VARCHAR(64) NOT NULL UNIQUE)`,
+		`create table books (
+			id INTEGER PRIMARY KEY,
+			author_id INTEGER NOT NULL REFERENCES authors (id) ON DELETE CASCADE,
+			title TEXT NOT NULL,
+			price REAL DEFAULT 0.0,
+			published DATETIME,
+			data JSONB
+		)`,
+		`create index books_title on books (title) where price > 0`,
+		`create view cheap_books as select * from books where price < 10`,
+		`create trigger books_delete after delete on books begin select 1; end`,
+	}
+
+	for _, statement := range statements {
+		_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: statement})
+		require.NoError(t, err)
+	}
+
+	t.Run("list tables", func(t *testing.T) {
+		resp, err := s.ListTables(ctx, &sqliterpc.ListTablesRequest{})
+		require.NoError(t, err)
+
+		var names []string
+		for _, table := range resp.Tables {
+			names = append(names, table.Name)
+		}
+		require.Equal(t, []string{"authors", "books", "cheap_books"}, names)
+		require.Equal(t, sqliterpc.TableType_TABLE_TYPE_VIEW, resp.Tables[2].Type)
+		require.Contains(t, resp.Tables[0].Sql, "authors (id INTEGER PRIMARY KEY")
+	})
+
+	t.Run("describe table", func(t *testing.T) {
+		resp, err := s.DescribeTable(ctx, &sqliterpc.DescribeTableRequest{Name: "books"})
+		require.NoError(t, err)
+
+		require.Equal(t, sqliterpc.TableType_TABLE_TYPE_TABLE, resp.Table.Type)
+		require.Len(t, resp.Columns, 6)
+
+		id := resp.Columns[0]
+		require.Equal(t, "id", id.Name)
+		require.Equal(t, sqliterpc.TypeCode_TYPE_CODE_INTEGER, id.Type)
+		require.Equal(t, int32(1), id.PrimaryKey)
+
+		title := resp.Columns[2]
+		require.True(t, title.NotNull)
+		require.Equal(t, sqliterpc.TypeCode_TYPE_CODE_TEXT, title.Type)
+		require.False(t, title.HasDefault)
+
+		price := resp.Columns[3]
+		require.False(t, price.NotNull)
+		require.Equal(t, sqliterpc.TypeCode_TYPE_CODE_REAL, price.Type)
+		require.True(t, price.HasDefault)
+		require.Equal(t, "0.0", price.DefaultValue)
+
+		require.Equal(t, sqliterpc.TypeCode_TYPE_CODE_TIME, resp.Columns[4].Type)
+
+		data := resp.Columns[5]
+		require.Equal(t, "JSONB", data.DeclaredType)
+		require.Equal(t, sqliterpc.TypeCode_TYPE_CODE_UNSPECIFIED, data.Type)
+
+		require.Len(t, resp.Indexes, 1)
+		index := resp.Indexes[0]
+		require.Equal(t, "books_title", index.Name)
+		require.Equal(t, "c", index.Origin)
+		require.True(t, index.Partial)
+		require.False(t, index.Unique)
+		require.Equal(t, []string{"title"}, index.Columns)
+		require.Contains(t, index.Sql, "books_title on books (title)")
+
+		require.Len(t, resp.ForeignKeys, 1)
+		key := resp.ForeignKeys[0]
+		require.Equal(t, "authors", key.ReferencedTable)
+		require.Equal(t, []string{"author_id"}, key.Columns)
+		require.Equal(t, []string{"id"}, key.ReferencedColumns)
+		require.Equal(t, "CASCADE", key.OnDelete)
+
+		require.Len(t, resp.Triggers, 1)
+		require.Equal(t, "books_delete", resp.Triggers[0].Name)
+	})
+
+	t.Run("describe view", func(t *testing.T) {
+		resp, err := s.DescribeTable(ctx, &sqliterpc.DescribeTableRequest{Name: "cheap_books"})
+		require.NoError(t, err)
+
+		require.Equal(t, sqliterpc.TableType_TABLE_TYPE_VIEW, resp.Table.Type)
+		require.Len(t, resp.Columns, 6)
+	})
+
+	t.Run("describe missing table", func(t *testing.T) {
+		_, err := s.DescribeTable(ctx, &sqliterpc.DescribeTableRequest{Name: "missing"})
+		require.Error(t, err)
+
+		twerr, ok := err.(twirp.Error)
+		require.True(t, ok)
+		require.Equal(t, twirp.NotFound, twerr.Code())
+	})
+
+	t.Run("list indexes", func(t *testing.T) {
+		resp, err := s.ListIndexes(ctx, &sqliterpc.ListIndexesRequest{})
+		require.NoError(t, err)
+
+		require.Len(t, resp.Indexes, 2)
+		require.Equal(t, "authors", resp.Indexes[0].Table)
+		require.True(t, resp.Indexes[0].Unique)
+		require.Equal(t, "u", resp.Indexes[0].Origin)
+		require.Equal(t, []string{"name"}, resp.Indexes[0].Columns)
+		require.Empty(t, resp.Indexes[0].Sql)
+
+		require.Equal(t, "books_title", resp.Indexes[1].Name)
+
+		resp, err = s.ListIndexes(ctx, &sqliterpc.ListIndexesRequest{Table: "books"})
+		require.NoError(t, err)
+		require.Len(t, resp.Indexes, 1)
+	})
+}
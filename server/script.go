@@ -0,0 +1,257 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/auth"
+)
+
+func (s *DatabaseServer) ExecScript(ctx context.Context, req *sqliterpc.ExecScriptRequest) (*sqliterpc.ExecScriptResponse, error) {
+	if err := s.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	parameters, err := scriptParameters(req.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout, err := s.limits.requestTimeout(req.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.TransactionId != "" {
+		if req.Transaction {
+			return nil, twirp.InvalidArgumentError("transaction", "must not be set with transaction_id")
+		}
+
+		var resp *sqliterpc.ExecScriptResponse
+
+		err := s.transactions.with(req.TransactionId, func(t *transaction) error {
+			var err error
+			resp, err = execScript(ctx, t.conn, req.Sql, parameters, timeout)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return resp, nil
+	}
+
+	if err := s.holds.check(s.writer); err != nil {
+		return nil, err
+	}
+
+	conn, err := s.writer.Conn(ctx)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	defer conn.Close()
+
+	if req.Transaction {
+		if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+			return nil, wrapError(err)
+		}
+	}
+
+	if p := s.policies.policy(auth.FromContext(ctx)); p != nil {
+		if err := setPolicy(conn, p); err != nil {
+			rollbackScript(conn)
+			return nil, wrapError(err)
+		}
+
+		defer func() {
+			_ = setPolicy(conn, nil)
+		}()
+	}
+
+	resp, err := execScript(ctx, conn, req.Sql, parameters, timeout)
+	if err != nil {
+		// the transaction may have been started by the request or the script.
+		rollbackScript(conn)
+		return nil, err
+	}
+
+	if req.Transaction {
+		if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+			rollbackScript(conn)
+			return nil, wrapError(err)
+		}
+
+		return resp, nil
+	}
+
+	// the connection is returned to the pool, so it must not be left in a transaction.
+	if !autoCommit(conn) {
+		rollbackScript(conn)
+		return nil, twirp.FailedPrecondition.Error("script must not leave a transaction open")
+	}
+
+	return resp, nil
+}
+
+// scriptParameters converts parameters, which must be named as it is
+// not clear which statement a positional parameter is for.
+func scriptParameters(values []*sqliterpc.Value) ([]driver.NamedValue, error) {
+	parameters, err := valuesToParams(values)
+	if err != nil {
+		twerr := twirp.InvalidArgumentError("parameters", err.Error())
+		return nil, twerr
+	}
+
+	named := make([]driver.NamedValue, len(parameters))
+
+	for i, p := range parameters {
+		arg, ok := p.(sql.NamedArg)
+		if !ok {
+			return nil, twirp.InvalidArgumentError("parameters", "must be named in scripts")
+		}
+
+		named[i] = driver.NamedValue{
+			Name:    arg.Name,
+			Ordinal: i + 1,
+			Value:   arg.Value,
+		}
+	}
+
+	return named, nil
+}
+
+// execScript runs each statement of script in order. Each statement is only
+// prepared after the statements before it have run, the same as sqlite3_exec.
+// Each statement may run for up to timeout.
+// see https://www.sqlite.org/c3ref/exec.html
+func execScript(ctx context.Context, conn *sql.Conn, script string, parameters []driver.NamedValue, timeout time.Duration) (*sqliterpc.ExecScriptResponse, error) {
+	var resp sqliterpc.ExecScriptResponse
+
+	err := conn.Raw(func(dc interface{}) error {
+		sc, ok := dc.(*sqliteConn)
+		if !ok {
+			return errNotSQLite
+		}
+
+		for rest := script; rest != ""; {
+			index := len(resp.Results)
+
+			var statement string
+			statement, rest = splitStatement(rest)
+
+			if emptyStatement(statement) {
+				continue
+			}
+
+			ds, err := sc.PrepareContext(ctx, statement)
+			if err != nil {
+				return scriptError(err, index)
+			}
+
+			stmt := ds.(*sqliteStmt)
+
+			stmtCtx, cancel := withTimeout(ctx, timeout)
+			result, err := execScriptStatement(stmtCtx, sc, stmt, parameters)
+			cancel()
+			_ = stmt.Close()
+
+			if err != nil {
+				return scriptError(err, index)
+			}
+
+			resp.Results = append(resp.Results, result)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func execScriptStatement(ctx context.Context, sc *sqliteConn, stmt *sqliteStmt, parameters []driver.NamedValue) (*sqliterpc.ExecResponse, error) {
+	before, err := totalChanges(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := stmt.ExecContext(ctx, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := totalChanges(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	last, _ := result.LastInsertId()
+
+	resp := sqliterpc.ExecResponse{
+		LastInsertId: last,
+	}
+
+	// sqlite3_changes is not reset by statements other than INSERT, UPDATE and DELETE,
+	// so it is only used if the statement changed rows.
+	// see https://www.sqlite.org/c3ref/changes.html
+	if after != before {
+		resp.RowsAffected, _ = result.RowsAffected()
+	}
+
+	return &resp, nil
+}
+
+func totalChanges(ctx context.Context, sc *sqliteConn) (int64, error) {
+	rows, err := sc.SQLiteConn.QueryContext(ctx, "SELECT total_changes()", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil {
+		if err == io.EOF {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	total, _ := dest[0].(int64)
+
+	return total - sc.state.internalChanges, nil
+}
+
+func scriptError(err error, index int) twirp.Error {
+	return wrapError(err).WithMeta(sqliterpc.ErrorMetaStatement, strconv.Itoa(index))
+}
+
+// autoCommit returns false if the connection is in a transaction.
+func autoCommit(conn *sql.Conn) bool {
+	autoCommit := true
+
+	_ = conn.Raw(func(dc interface{}) error {
+		if sc, ok := dc.(*sqliteConn); ok {
+			autoCommit = sc.AutoCommit()
+		}
+		return nil
+	})
+
+	return autoCommit
+}
+
+func rollbackScript(conn *sql.Conn) {
+	if !autoCommit(conn) {
+		_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+	}
+}
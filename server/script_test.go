@@ -0,0 +1,189 @@
+package server_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/server"
+)
+
+func TestExecScript(t *testing.T) {
+	s, err := server.New(filepath.Join(t.TempDir(), "script.db"))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	named := func(name string, v int64) *sqliterpc.Value {
+		return &sqliterpc.Value{
+			Kind: &sqliterpc.Value_IntegerValue{
+				IntegerValue: &sqliterpc.IntergerValue{
+					Value: v,
+					Valid: true,
+				},
+			},
+			Name: name,
+		}
+	}
+
+	count := func(t *testing.T) int64 {
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select count(*) from testing`})
+		require.NoError(t, err)
+		return resp.Rows[0].Values[0].GetIntegerValue().GetValue()
+	}
+
+	requireStatement := func(t *testing.T, err error, code twirp.ErrorCode, statement string) {
+		t.Helper()
+
+		requireCode(t, err, code)
+		require.Equal(t, statement, err.(twirp.Error).Meta(sqliterpc.ErrorMetaStatement))
+	}
+
+	t.Run("statements", func(t *testing.T) {
+		resp, err := s.ExecScript(ctx, &sqliterpc.ExecScriptRequest{
+			Sql: `
+				-- the table is created before the inserts are prepared
+				create table testing (id INTEGER PRIMARY KEY, value INTEGER);
+				insert into testing (value) values (:value), (:value + 1);;
+				update testing set value = value * 10;
+				insert into testing (value) values ('x'); -- done
+			`,
+			Parameters: []*sqliterpc.Value{named("value", 1)},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 4)
+
+		require.Equal(t, int64(0), resp.Results[0].RowsAffected)
+		require.Equal(t, int64(2), resp.Results[1].RowsAffected)
+		require.Equal(t, int64(2), resp.Results[1].LastInsertId)
+		require.Equal(t, int64(2), resp.Results[2].RowsAffected)
+		require.Equal(t, int64(1), resp.Results[3].RowsAffected)
+		require.Equal(t, int64(3), resp.Results[3].LastInsertId)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		_, err := s.ExecScript(ctx, &sqliterpc.ExecScriptRequest{
+			Sql: `insert into testing (id) values (10); insert into testing (id) values (1); insert into testing (id) values (11)`,
+		})
+		requireStatement(t, err, twirp.AlreadyExists, "1")
+
+		// statements before the failure are committed
+		require.Equal(t, int64(4), count(t))
+
+		_, err = s.ExecScript(ctx, &sqliterpc.ExecScriptRequest{
+			Sql: `insert into testing (id) values (12); select * from missing`,
+		})
+		requireStatement(t, err, twirp.InvalidArgument, "1")
+		require.Equal(t, int64(5), count(t))
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		_, err := s.ExecScript(ctx, &sqliterpc.ExecScriptRequest{
+			Sql:         `insert into testing (id) values (20); insert into testing (id) values (1)`,
+			Transaction: true,
+		})
+		requireStatement(t, err, twirp.AlreadyExists, "1")
+		require.Equal(t, int64(5), count(t))
+
+		resp, err := s.ExecScript(ctx, &sqliterpc.ExecScriptRequest{
+			Sql:         `insert into testing (id) values (20); insert into testing (id) values (21)`,
+			Transaction: true,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 2)
+		require.Equal(t, int64(7), count(t))
+	})
+
+	t.Run("script transaction", func(t *testing.T) {
+		_, err := s.ExecScript(ctx, &sqliterpc.ExecScriptRequest{
+			Sql: `begin; insert into testing (id) values (30); commit`,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(8), count(t))
+
+		_, err = s.ExecScript(ctx, &sqliterpc.ExecScriptRequest{
+			Sql: `begin; insert into testing (id) values (31)`,
+		})
+		requireCode(t, err, twirp.FailedPrecondition)
+
+		// rolled back, and the connection is usable
+		require.Equal(t, int64(8), count(t))
+
+		_, err = s.ExecScript(ctx, &sqliterpc.ExecScriptRequest{
+			Sql: `begin; insert into testing (id) values (31); insert into testing (id) values (1); commit`,
+		})
+		requireStatement(t, err, twirp.AlreadyExists, "2")
+		require.Equal(t, int64(8), count(t))
+
+		_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `insert into testing (id) values (31)`})
+		require.NoError(t, err)
+		require.Equal(t, int64(9), count(t))
+	})
+
+	t.Run("begin", func(t *testing.T) {
+		tx, err := s.Begin(ctx, &sqliterpc.BeginRequest{})
+		require.NoError(t, err)
+
+		_, err = s.ExecScript(ctx, &sqliterpc.ExecScriptRequest{
+			Sql:           `insert into testing (id) values (40); insert into testing (id) values (41)`,
+			TransactionId: tx.TransactionId,
+		})
+		require.NoError(t, err)
+
+		_, err = s.ExecScript(ctx, &sqliterpc.ExecScriptRequest{
+			Sql:           `select 1`,
+			TransactionId: tx.TransactionId,
+			Transaction:   true,
+		})
+		requireCode(t, err, twirp.InvalidArgument)
+
+		_, err = s.Rollback(ctx, &sqliterpc.RollbackRequest{TransactionId: tx.TransactionId})
+		require.NoError(t, err)
+
+		require.Equal(t, int64(9), count(t))
+	})
+
+	t.Run("positional parameters", func(t *testing.T) {
+		_, err := s.ExecScript(ctx, &sqliterpc.ExecScriptRequest{
+			Sql:        `insert into testing (id) values (?)`,
+			Parameters: []*sqliterpc.Value{named("", 50)},
+		})
+		requireCode(t, err, twirp.InvalidArgument)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		resp, err := s.ExecScript(ctx, &sqliterpc.ExecScriptRequest{Sql: ` ; -- nothing`})
+		require.NoError(t, err)
+		require.Empty(t, resp.Results)
+	})
+
+	t.Run("semicolons", func(t *testing.T) {
+		// semicolons in comments, strings, and trigger bodies do not end a statement.
+		resp, err := s.ExecScript(ctx, &sqliterpc.ExecScriptRequest{
+			Sql: `
+				create table log (value TEXT /* ; */);
+				create trigger testing_log after insert on testing begin
+					insert into log (value) values ('inserted;');
+				end;
+				insert into log (value) values ('script; ');
+				insert into testing (value) values (30) -- ;
+			`,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 4)
+
+		log, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select value from log order by rowid`})
+		require.NoError(t, err)
+		require.Len(t, log.Rows, 2)
+		require.Equal(t, "script; ", log.Rows[0].Values[0].GetTextValue().GetValue())
+		require.Equal(t, "inserted;", log.Rows[1].Values[0].GetTextValue().GetValue())
+	})
+}
@@ -4,18 +4,35 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"sort"
+	"net/http"
 	"strings"
+	"time"
 
 	sqlite3 "github.com/mattn/go-sqlite3"
 	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/auth"
 )
 
 type DatabaseServer struct {
-	db *sql.DB
+	// writer runs anything that may write. readers are read only
+	// connections for queries.
+	writer       *sql.DB
+	readers      *sql.DB
+	routes       *routes
+	transactions *transactions
+	cursors      *cursors
+	policies     *Policies
+	connector    *connector
+	statements   *statements
+	changes      *changeFeed
+	limits       serverLimits
+	holds        *writerHolds
+	// replica is set if the server is a replica of another server.
+	replica *replica
 }
 
 var (
@@ -27,91 +44,238 @@ type Option interface {
 	apply(*config)
 }
 
-type JournalMode string
+type config struct {
+	journal            JournalMode
+	cache              CacheMode
+	busyTimeout        time.Duration
+	synchronous        SynchronousMode
+	foreignKeys        *bool
+	txLock             TxLock
+	mode               OpenMode
+	cacheSize          *int
+	mmapSize           *int64
+	tempStore          TempStore
+	lockingMode        LockingMode
+	maxReadConns       int
+	maxWriteConns      int
+	connMaxLifetime    time.Duration
+	transactionTimeout time.Duration
+	cursorTimeout      time.Duration
+	maxCursors         int
+	maxStatements      int
+	limits             serverLimits
+	policies           *Policies
+	primary            string
+	primaryClient      *http.Client
+}
 
-const (
-	JournalModeWal = JournalMode("WAL")
-)
+type optionFunc func(*config)
 
-type CacheMode string
+func (f optionFunc) apply(c *config) {
+	f(c)
+}
 
-const (
-	CacheModeShared = CacheMode("shared")
-)
+// WithTransactionTimeout sets how long a transaction may be idle
+// before it is rolled back.
+func WithTransactionTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.transactionTimeout = timeout
+	})
+}
 
-type config struct {
-	journal JournalMode
-	cache   CacheMode
+// WithCursorTimeout sets how long a paged query may be idle
+// before its cursor is closed.
+func WithCursorTimeout(timeout time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.cursorTimeout = timeout
+	})
 }
 
-// se https://github.com/mattn/go-sqlite3#connection-string
-func (c config) dsn(filename string) string {
-	options := map[string]string{
-		"_journal_mode": string(c.journal),
-		"cache":         string(c.cache),
-	}
+// WithMaxCursors sets the maximum number of open cursors for paged queries.
+// Each open cursor holds a database connection.
+func WithMaxCursors(max int) Option {
+	return optionFunc(func(c *config) {
+		c.maxCursors = max
+	})
+}
 
-	var args []string
+// WithMaxStatements sets the maximum number of prepared statements.
+// The least recently used statements are closed when there are more.
+func WithMaxStatements(max int) Option {
+	return optionFunc(func(c *config) {
+		c.maxStatements = max
+	})
+}
 
-	for k, v := range options {
-		args = append(args, k+"="+v)
+func New(filename string, options ...Option) (*DatabaseServer, error) {
+	cfg, err := newConfig(options...)
+	if err != nil {
+		return nil, err
 	}
 
-	sort.Strings(args)
+	var schema schemaState
 
-	dsn := "file:" + filename + "?" + strings.Join(args, "&")
+	changes := newChangeFeed(schema.generation)
 
-	return dsn
-}
+	// sqlite allows a single writer, so writes are queued for the writer's
+	// connections rather than contending for the lock and failing with SQLITE_BUSY.
+	// In WAL mode, readers are not blocked by the writer.
+	// see https://github.com/mattn/go-sqlite3/issues/209 and linked issues
+	c := newConnector(cfg.dsn(filename, false), cfg.pragmas(), changes, &schema)
+	writer := sql.OpenDB(c)
 
-func New(filename string, options ...Option) (*DatabaseServer, error) {
-	cfg := config{
-		journal: JournalModeWal,
-		cache:   CacheModeShared,
-	}
+	writer.SetConnMaxLifetime(cfg.connMaxLifetime)
+	writer.SetMaxIdleConns(cfg.maxWriteConns)
+	writer.SetMaxOpenConns(cfg.maxWriteConns)
 
-	for _, o := range options {
-		o.apply(&cfg)
+	// the writer creates the database, which must exist before it can be opened read only.
+	if err := writer.Ping(); err != nil {
+		_ = writer.Close()
+		return nil, err
 	}
 
-	db, err := sql.Open("sqlite3", cfg.dsn(filename))
-	if err != nil {
+	// commits are compared to the schema version when the server started.
+	if err := writer.QueryRow("PRAGMA schema_version").Scan(&schema.version); err != nil {
+		_ = writer.Close()
 		return nil, err
 	}
 
-	db.SetConnMaxLifetime(-1)
-	db.SetMaxIdleConns(16)
-	db.SetMaxOpenConns(16)
-	// TODO: is this correct? I think we just need to lock on execs
-	// see https://github.com/mattn/go-sqlite3/issues/209 and linked issues
-	// db.SetMaxOpenConns(1)
+	readers := writer
+
+	if !cfg.singlePool() {
+		// readers do not write, so do not capture changes.
+		readers = sql.OpenDB(newConnector(cfg.dsn(filename, true), cfg.pragmas(), nil, &schema))
+
+		readers.SetConnMaxLifetime(cfg.connMaxLifetime)
+		readers.SetMaxIdleConns(cfg.maxReadConns)
+		readers.SetMaxOpenConns(cfg.maxReadConns)
+	}
 
 	s := DatabaseServer{
-		db: db,
+		writer:     writer,
+		readers:    readers,
+		routes:     newRoutes(maxRoutes),
+		holds:      newWriterHolds(writer, cfg.maxWriteConns),
+		policies:   cfg.policies,
+		connector:  c,
+		statements: newStatements(c, cfg.maxStatements),
+		changes:    changes,
+		limits:     cfg.limits,
+	}
+
+	s.cursors = newCursors(cfg.cursorTimeout, cfg.maxCursors, s.holds)
+	s.transactions = newTransactions(cfg.transactionTimeout, cfg.txLock, s.holds, s.cursors.closeTransaction)
+
+	if cfg.primary != "" {
+		s.replica = newReplica(&s, cfg.primary, cfg.primaryClient)
 	}
 
 	return &s, nil
 }
 
 func (s *DatabaseServer) Close() error {
-	return s.db.Close()
+	if s.replica != nil {
+		s.replica.close()
+	}
+
+	s.changes.close()
+	s.cursors.close()
+	s.transactions.close()
+	s.statements.closeAll()
+
+	if s.readers == s.writer {
+		return s.writer.Close()
+	}
+
+	err := s.readers.Close()
+	if werr := s.writer.Close(); werr != nil {
+		err = werr
+	}
+
+	return err
+}
+
+// idle returns true if there are no open transactions, cursors, or subscriptions.
+func (s *DatabaseServer) idle() bool {
+	return s.transactions.len() == 0 && s.cursors.len() == 0 && s.changes.len() == 0
+}
+
+// queryer is implemented by *sql.DB and *sql.Conn
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// withQueryer calls fn with the transaction for transactionID
+// or db if transactionID is empty. If the principal is
+// restricted by a policy, fn is called with a connection that enforces it.
+func (s *DatabaseServer) withQueryer(ctx context.Context, transactionID string, db *sql.DB, fn func(queryer) error) error {
+	if transactionID != "" {
+		return s.transactions.with(transactionID, func(t *transaction) error {
+			return fn(t.conn)
+		})
+	}
+
+	if err := s.holds.check(db); err != nil {
+		return err
+	}
+
+	if p := s.policies.policy(auth.FromContext(ctx)); p != nil {
+		return s.withPolicy(ctx, db, p, fn)
+	}
+
+	return fn(db)
 }
 
 func (s *DatabaseServer) Exec(ctx context.Context, req *sqliterpc.ExecRequest) (*sqliterpc.ExecResponse, error) {
+	if err := s.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	st, release, err := s.statements.acquire(principalName(ctx), req.StatementId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	if st != nil {
+		req = proto.Clone(req).(*sqliterpc.ExecRequest)
+		req.Sql = st.sql
+	}
+
+	timeout, err := s.limits.requestTimeout(req.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+
+	var resp *sqliterpc.ExecResponse
+
+	err = s.withQueryer(ctx, req.TransactionId, s.writer, func(q queryer) error {
+		var err error
+		resp, err = exec(ctx, st.queryer(q), req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func exec(ctx context.Context, q queryer, req *sqliterpc.ExecRequest) (*sqliterpc.ExecResponse, error) {
 	parameters, err := valuesToParams(req.Parameters)
 	if err != nil {
-		// TODO: properly wrap the errors - bad sql should return invalidargument, etc
-		twerr := twirp.InternalError(err.Error())
+		twerr := twirp.InvalidArgumentError("parameters", err.Error())
 		return nil, twerr
 	}
 
-	// TODO: prepared statement cache?
-
-	result, err := s.db.ExecContext(ctx, req.Sql, parameters...)
+	result, err := q.ExecContext(ctx, req.Sql, parameters...)
 	if err != nil {
-		// TODO: properly wrap the errors - bad sql should return invalidargument, etc
-		// see https://pkg.go.dev/github.com/mattn/go-sqlite3#ErrNo
-		twerr := twirp.InternalError(err.Error())
+		twerr := wrapError(err)
 		return nil, twerr
 	}
 
@@ -146,7 +310,12 @@ func valuesToParams(values []*sqliterpc.Value) ([]interface{}, error) {
 
 		case *sqliterpc.Value_BlobValue:
 			if parameter.BlobValue.Valid {
-				parameters[i] = parameter.BlobValue.Value
+				// empty blobs are decoded as nil, which go-sqlite3 binds as NULL.
+				b := parameter.BlobValue.Value
+				if b == nil {
+					b = []byte{}
+				}
+				parameters[i] = b
 			} else {
 				parameters[i] = nil
 			}
@@ -183,196 +352,397 @@ func valuesToParams(values []*sqliterpc.Value) ([]interface{}, error) {
 		default:
 			return nil, fmt.Errorf("unsupported type %T", parameter)
 		}
+
+		if val.Name != "" {
+			// go-sqlite3 adds the prefix when binding
+			name := strings.TrimLeft(val.Name, ":@$")
+			parameters[i] = sql.Named(name, parameters[i])
+		}
 	}
 	return parameters, nil
 }
 
 func (s *DatabaseServer) Query(ctx context.Context, req *sqliterpc.QueryRequest) (*sqliterpc.QueryResponse, error) {
-	parameters, err := valuesToParams(req.Parameters)
+	if req.PageToken != "" {
+		return s.nextPage(req)
+	}
+
+	st, release, err := s.statements.acquire(principalName(ctx), req.StatementId)
 	if err != nil {
-		// TODO: properly wrap the errors - bad sql should return invalidargument, etc
-		twerr := twirp.InternalError(err.Error())
-		return nil, twerr
+		return nil, err
 	}
 
-	// TODO: prepared statement cache?
+	defer release()
 
-	rows, err := s.db.QueryContext(ctx, req.Sql, parameters...)
+	if st != nil {
+		req = proto.Clone(req).(*sqliterpc.QueryRequest)
+		req.Sql = st.sql
+	}
+
+	timeout, err := s.limits.requestTimeout(req.Timeout)
 	if err != nil {
-		// TODO: properly wrap the errors - bad sql should return invalidargument, etc
-		twerr := twirp.InternalError(err.Error())
-		return nil, twerr
+		return nil, err
+	}
+
+	limits, err := s.limits.rowLimits(req)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := s.route(ctx, st, req.Sql)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *sqliterpc.QueryResponse
+
+	err = s.withQueryer(ctx, req.TransactionId, db, func(q queryer) error {
+		q = st.queryer(q)
+
+		var err error
+		if req.PageSize > 0 {
+			// a cursor outside a transaction holds a connection of db.
+			held := db
+			if req.TransactionId != "" {
+				held = nil
+			}
+
+			// the timeout limits reading each page.
+			resp, err = s.cursors.query(q, held, req, timeout, limits)
+		} else {
+			ctx, cancel := withTimeout(ctx, timeout)
+			defer cancel()
+
+			resp, err = query(ctx, q, req, limits)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// query returns all rows of the query in one response. Responses that would
+// exceed limits are truncated if requested, and otherwise fail.
+func query(ctx context.Context, q queryer, req *sqliterpc.QueryRequest, limits rowLimits) (*sqliterpc.QueryResponse, error) {
+	rows, columns, err := startQuery(ctx, q, req)
+	if err != nil {
+		return nil, err
 	}
 
 	defer rows.Close()
 
-	// TODO: how to handle large responses without streaming?
-	// use paging?
+	// large results should use paging or the streaming endpoint.
+
+	resp := sqliterpc.QueryResponse{
+		Columns: columns,
+	}
+
+	size := responseSize(columns, false)
+
+	for rows.Next() {
+		row, err := scanRow(rows, columns)
+		if err != nil {
+			return nil, err
+		}
+
+		size += rowSize(row)
+
+		if err := limits.check(len(resp.Rows)+1, size); err != nil {
+			if !limits.truncate {
+				return nil, err
+			}
+
+			resp.Truncated = true
+
+			return &resp, nil
+		}
+
+		resp.Rows = append(resp.Rows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		twerr := wrapError(err)
+		return nil, twerr
+	}
+
+	return &resp, nil
+}
+
+// startQuery runs the query and determines the column types.
+// rows must be closed by the caller if err is nil.
+func startQuery(ctx context.Context, q queryer, req *sqliterpc.QueryRequest) (*sql.Rows, []*sqliterpc.Column, error) {
+	parameters, err := valuesToParams(req.Parameters)
+	if err != nil {
+		twerr := twirp.InvalidArgumentError("parameters", err.Error())
+		return nil, nil, twerr
+	}
+
+	rows, err := q.QueryContext(ctx, req.Sql, parameters...)
+	if err != nil {
+		twerr := wrapError(err)
+		return nil, nil, twerr
+	}
 
 	// can we cache column metadata? select * may be an issues.
 	// also, if DDL changes while cached.
 
 	types, err := rows.ColumnTypes()
 	if err != nil {
-		// TODO: properly wrap the errors
-		twerr := twirp.InternalError(err.Error())
-		return nil, twerr
+		_ = rows.Close()
+		twerr := wrapError(err)
+		return nil, nil, twerr
 	}
 
-	resp := sqliterpc.QueryResponse{
-		Columns: make([]*sqliterpc.Column, len(types)),
-	}
+	columns := make([]*sqliterpc.Column, len(types))
 
 	for i, t := range types {
+		name := t.Name()
+
 		code := databaseTypeConvSqlite(t.DatabaseTypeName())
 		if code == sqliterpc.TypeCode_TYPE_CODE_NULL {
-			// can you even declare a column as type NULL?
-			twerr := twirp.InternalErrorf("unable to handle column type %q", t.DatabaseTypeName())
-			return nil, twerr
+			// expressions and columns without a known declared type
+			// can hold any type, so each value has the type of its storage class.
+			columns[i] = &sqliterpc.Column{
+				Name:    name,
+				Dynamic: true,
+			}
+			continue
 		}
 
-		name := t.Name()
-
-		resp.Columns[i] = &sqliterpc.Column{
+		columns[i] = &sqliterpc.Column{
 			Type: code,
 			Name: name,
 		}
 	}
 
-	// avert your eyes! this is clunky and needs some refactoring
-	for rows.Next() {
-		scanTarget := make([]interface{}, len(types))
-
-		// see https://github.com/mattn/go-sqlite3/blob/2df077b74c66723d9b44d01c8db88e74191bdd0e/sqlite3_type.go#L58
-		for i, t := range resp.Columns {
-			switch t.Type {
-			case sqliterpc.TypeCode_TYPE_CODE_INTEGER:
-				scanTarget[i] = &sql.NullInt64{}
-			case sqliterpc.TypeCode_TYPE_CODE_TEXT:
-				scanTarget[i] = &sql.NullString{}
-			case sqliterpc.TypeCode_TYPE_CODE_BLOB:
-				scanTarget[i] = &nullBytes{}
-			case sqliterpc.TypeCode_TYPE_CODE_REAL:
-				scanTarget[i] = &sql.NullFloat64{}
-			case sqliterpc.TypeCode_TYPE_CODE_NUMERIC:
-				scanTarget[i] = &sql.NullFloat64{}
-			case sqliterpc.TypeCode_TYPE_CODE_BOOL:
-				scanTarget[i] = &sql.NullBool{}
-			case sqliterpc.TypeCode_TYPE_CODE_TIME:
-				scanTarget[i] = &sql.NullTime{}
-			default:
-				// should never get here, but just in case
-				twerr := twirp.InternalErrorf("unable to handle column type %q", t.String())
-				return nil, twerr
-			}
+	return rows, columns, nil
+}
+
+// avert your eyes! this is clunky and needs some refactoring
+func scanRow(rows *sql.Rows, columns []*sqliterpc.Column) (*sqliterpc.ListValue, error) {
+	scanTarget := make([]interface{}, len(columns))
+
+	// see https://github.com/mattn/go-sqlite3/blob/2df077b74c66723d9b44d01c8db88e74191bdd0e/sqlite3_type.go#L58
+	for i, t := range columns {
+		if t.Dynamic {
+			scanTarget[i] = new(interface{})
+			continue
 		}
 
-		if err := rows.Scan(scanTarget...); err != nil {
-			// TODO: properly wrap the errors
-			twerr := twirp.InternalError(err.Error())
+		switch t.Type {
+		case sqliterpc.TypeCode_TYPE_CODE_INTEGER:
+			scanTarget[i] = &sql.NullInt64{}
+		case sqliterpc.TypeCode_TYPE_CODE_TEXT:
+			scanTarget[i] = &sql.NullString{}
+		case sqliterpc.TypeCode_TYPE_CODE_BLOB:
+			scanTarget[i] = &nullBytes{}
+		case sqliterpc.TypeCode_TYPE_CODE_REAL:
+			scanTarget[i] = &sql.NullFloat64{}
+		case sqliterpc.TypeCode_TYPE_CODE_NUMERIC:
+			scanTarget[i] = &sql.NullFloat64{}
+		case sqliterpc.TypeCode_TYPE_CODE_BOOL:
+			scanTarget[i] = &sql.NullBool{}
+		case sqliterpc.TypeCode_TYPE_CODE_TIME:
+			scanTarget[i] = &sql.NullTime{}
+		default:
+			// should never get here, but just in case
+			twerr := twirp.InternalErrorf("unable to handle column type %q", t.String())
 			return nil, twerr
 		}
+	}
+
+	if err := rows.Scan(scanTarget...); err != nil {
+		twerr := wrapError(err)
+		return nil, twerr
+	}
+
+	row := sqliterpc.ListValue{
+		Values: make([]*sqliterpc.Value, len(columns)),
+	}
 
-		row := sqliterpc.ListValue{
-			Values: make([]*sqliterpc.Value, len(types)),
+	for i, t := range columns {
+		if t.Dynamic {
+			v, err := dynamicValue(*scanTarget[i].(*interface{}))
+			if err != nil {
+				return nil, err
+			}
+			row.Values[i] = v
+			continue
 		}
 
-		for i, t := range resp.Columns {
-			switch t.Type {
-			case sqliterpc.TypeCode_TYPE_CODE_INTEGER:
-				s := scanTarget[i].(*sql.NullInt64)
-				row.Values[i] = &sqliterpc.Value{
-					Kind: &sqliterpc.Value_IntegerValue{
-						IntegerValue: &sqliterpc.IntergerValue{
-							Value: s.Int64,
-							Valid: s.Valid,
-						},
+		switch t.Type {
+		case sqliterpc.TypeCode_TYPE_CODE_INTEGER:
+			s := scanTarget[i].(*sql.NullInt64)
+			row.Values[i] = &sqliterpc.Value{
+				Kind: &sqliterpc.Value_IntegerValue{
+					IntegerValue: &sqliterpc.IntergerValue{
+						Value: s.Int64,
+						Valid: s.Valid,
 					},
-				}
+				},
+			}
 
-			case sqliterpc.TypeCode_TYPE_CODE_TEXT:
-				s := scanTarget[i].(*sql.NullString)
-				row.Values[i] = &sqliterpc.Value{
-					Kind: &sqliterpc.Value_TextValue{
-						TextValue: &sqliterpc.TextValue{
-							Value: s.String,
-							Valid: s.Valid,
-						},
+		case sqliterpc.TypeCode_TYPE_CODE_TEXT:
+			s := scanTarget[i].(*sql.NullString)
+			row.Values[i] = &sqliterpc.Value{
+				Kind: &sqliterpc.Value_TextValue{
+					TextValue: &sqliterpc.TextValue{
+						Value: s.String,
+						Valid: s.Valid,
 					},
-				}
+				},
+			}
 
-			case sqliterpc.TypeCode_TYPE_CODE_BLOB:
-				s := scanTarget[i].(*nullBytes)
-				row.Values[i] = &sqliterpc.Value{
-					Kind: &sqliterpc.Value_BlobValue{
-						BlobValue: &sqliterpc.BlobValue{
-							Value: s.Value,
-							Valid: s.Valid,
-						},
+		case sqliterpc.TypeCode_TYPE_CODE_BLOB:
+			s := scanTarget[i].(*nullBytes)
+			row.Values[i] = &sqliterpc.Value{
+				Kind: &sqliterpc.Value_BlobValue{
+					BlobValue: &sqliterpc.BlobValue{
+						Value: s.Value,
+						Valid: s.Valid,
 					},
-				}
+				},
+			}
 
-			case sqliterpc.TypeCode_TYPE_CODE_REAL:
-				s := scanTarget[i].(*sql.NullFloat64)
-				row.Values[i] = &sqliterpc.Value{
-					Kind: &sqliterpc.Value_RealValue{
-						RealValue: &sqliterpc.RealValue{
-							Value: s.Float64,
-							Valid: s.Valid,
-						},
+		case sqliterpc.TypeCode_TYPE_CODE_REAL:
+			s := scanTarget[i].(*sql.NullFloat64)
+			row.Values[i] = &sqliterpc.Value{
+				Kind: &sqliterpc.Value_RealValue{
+					RealValue: &sqliterpc.RealValue{
+						Value: s.Float64,
+						Valid: s.Valid,
 					},
-				}
+				},
+			}
 
-			case sqliterpc.TypeCode_TYPE_CODE_NUMERIC:
-				s := scanTarget[i].(*sql.NullFloat64)
-				row.Values[i] = &sqliterpc.Value{
-					Kind: &sqliterpc.Value_NumericValue{
-						NumericValue: &sqliterpc.NumericValue{
-							Value: s.Float64,
-							Valid: s.Valid,
-						},
+		case sqliterpc.TypeCode_TYPE_CODE_NUMERIC:
+			s := scanTarget[i].(*sql.NullFloat64)
+			row.Values[i] = &sqliterpc.Value{
+				Kind: &sqliterpc.Value_NumericValue{
+					NumericValue: &sqliterpc.NumericValue{
+						Value: s.Float64,
+						Valid: s.Valid,
 					},
-				}
+				},
+			}
 
-			case sqliterpc.TypeCode_TYPE_CODE_BOOL:
-				s := scanTarget[i].(*sql.NullBool)
-				row.Values[i] = &sqliterpc.Value{
-					Kind: &sqliterpc.Value_BoolValue{
-						BoolValue: &sqliterpc.BoolValue{
-							Value: s.Bool,
-							Valid: s.Valid,
-						},
+		case sqliterpc.TypeCode_TYPE_CODE_BOOL:
+			s := scanTarget[i].(*sql.NullBool)
+			row.Values[i] = &sqliterpc.Value{
+				Kind: &sqliterpc.Value_BoolValue{
+					BoolValue: &sqliterpc.BoolValue{
+						Value: s.Bool,
+						Valid: s.Valid,
 					},
-				}
-
-			case sqliterpc.TypeCode_TYPE_CODE_TIME:
-				s := scanTarget[i].(*sql.NullTime)
+				},
+			}
 
-				v := sqliterpc.TimeValue{
-					Valid: s.Valid,
-				}
+		case sqliterpc.TypeCode_TYPE_CODE_TIME:
+			s := scanTarget[i].(*sql.NullTime)
 
-				if s.Valid {
-					v.Value = timestamppb.New(s.Time)
-				}
+			v := sqliterpc.TimeValue{
+				Valid: s.Valid,
+			}
 
-				row.Values[i] = &sqliterpc.Value{
-					Kind: &sqliterpc.Value_TimeValue{
-						TimeValue: &v,
-					},
-				}
+			if s.Valid {
+				v.Value = timestamppb.New(s.Time)
+			}
 
-			default:
-				// should never get here, but just in case
-				twerr := twirp.InternalErrorf("unable to handle column type %q", t.String())
-				return nil, twerr
+			row.Values[i] = &sqliterpc.Value{
+				Kind: &sqliterpc.Value_TimeValue{
+					TimeValue: &v,
+				},
 			}
-		}
 
-		resp.Rows = append(resp.Rows, &row)
+		default:
+			// should never get here, but just in case
+			twerr := twirp.InternalErrorf("unable to handle column type %q", t.String())
+			return nil, twerr
+		}
 	}
 
-	return &resp, nil
+	return &row, nil
+}
+
+// dynamicValue converts a value scanned from a dynamic column.
+// see https://github.com/mattn/go-sqlite3/blob/v1.14.12/sqlite3.go#L2199
+func dynamicValue(value interface{}) (*sqliterpc.Value, error) {
+	switch v := value.(type) {
+	case nil:
+		return &sqliterpc.Value{
+			Kind: &sqliterpc.Value_NullValue{
+				NullValue: &sqliterpc.NullValue{},
+			},
+		}, nil
+
+	case int64:
+		return &sqliterpc.Value{
+			Kind: &sqliterpc.Value_IntegerValue{
+				IntegerValue: &sqliterpc.IntergerValue{
+					Value: v,
+					Valid: true,
+				},
+			},
+		}, nil
+
+	case float64:
+		return &sqliterpc.Value{
+			Kind: &sqliterpc.Value_RealValue{
+				RealValue: &sqliterpc.RealValue{
+					Value: v,
+					Valid: true,
+				},
+			},
+		}, nil
+
+	case string:
+		return &sqliterpc.Value{
+			Kind: &sqliterpc.Value_TextValue{
+				TextValue: &sqliterpc.TextValue{
+					Value: v,
+					Valid: true,
+				},
+			},
+		}, nil
+
+	case []byte:
+		return &sqliterpc.Value{
+			Kind: &sqliterpc.Value_BlobValue{
+				BlobValue: &sqliterpc.BlobValue{
+					Value: v,
+					Valid: true,
+				},
+			},
+		}, nil
+
+	case bool:
+		return &sqliterpc.Value{
+			Kind: &sqliterpc.Value_BoolValue{
+				BoolValue: &sqliterpc.BoolValue{
+					Value: v,
+					Valid: true,
+				},
+			},
+		}, nil
+
+	case time.Time:
+		return &sqliterpc.Value{
+			Kind: &sqliterpc.Value_TimeValue{
+				TimeValue: &sqliterpc.TimeValue{
+					Value: timestamppb.New(v),
+					Valid: true,
+				},
+			},
+		}, nil
+
+	default:
+		// should never get here, but just in case
+		twerr := twirp.InternalErrorf("unable to handle value of type %T", value)
+		return nil, twerr
+	}
 }
 
 // based on https://github.com/mattn/go-sqlite3/blob/2df077b74c66723d9b44d01c8db88e74191bdd0e/sqlite3_type.go#L80
@@ -428,56 +798,3 @@ func (n *nullBytes) Scan(value interface{}) error {
 
 	return nil
 }
-
-/*
-type statementCache struct {
-	db    *sql.DB
-	lock  sync.RWMutex
-	cache map[string]*sql.Stmt
-	max   int
-}
-
-func newStatementCache(db *sql.DB, max int) *statementCache {
-	if max < 0 {
-		max = 0
-	}
-
-	c := statementCache{
-		cache: make(map[string]*sql.Stmt, max),
-		db:    db,
-	}
-
-	return &c
-}
-
-// TODO: allow setting an option on context not to cache
-func (c *statementCache) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
-	if stmt := c.get(query); stmt != nil {
-		return stmt, nil
-	}
-
-	stmt, err := c.db.PrepareContext(ctx, query)
-	if err != nil {
-		return nil, err
-	}
-
-	c.set(query, stmt)
-
-	return stmt, nil
-}
-
-func (c *statementCache) get(key string) *sql.Stmt {
-	c.lock.RLock()
-	val := c.cache[key]
-	c.lock.RUnlock()
-
-	return val
-}
-
-// TODO: eviction
-func (c *statementCache) set(key string, val *sql.Stmt) {
-	c.lock.Lock()
-	c.cache[key] = val
-	c.lock.Unlock()
-}
-*/
@@ -369,3 +369,103 @@ func BenchmarkSimple(b *testing.B) {
 		}
 	})
 }
+
+func TestNamedParameters(t *testing.T) {
+	file := "named.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file)
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = s.Exec(
+		ctx,
+		&sqliterpc.ExecRequest{
+			Sql: `create table testing (intCol INTEGER, textCol TEXT)`,
+		},
+	)
+	require.NoError(t, err)
+
+	integer := func(name string, v int64) *sqliterpc.Value {
+		return &sqliterpc.Value{
+			Kind: &sqliterpc.Value_IntegerValue{
+				IntegerValue: &sqliterpc.IntergerValue{
+					Value: v,
+					Valid: true,
+				},
+			},
+			Name: name,
+		}
+	}
+
+	text := func(name string, v string) *sqliterpc.Value {
+		return &sqliterpc.Value{
+			Kind: &sqliterpc.Value_TextValue{
+				TextValue: &sqliterpc.TextValue{
+					Value: v,
+					Valid: true,
+				},
+			},
+			Name: name,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		sql        string
+		parameters []*sqliterpc.Value
+	}{
+		{
+			name:       "colon",
+			sql:        `insert into testing (intCol, textCol) values (:i, :t)`,
+			parameters: []*sqliterpc.Value{text("t", "one"), integer("i", 1)},
+		},
+		{
+			name:       "at",
+			sql:        `insert into testing (intCol, textCol) values (@i, @t)`,
+			parameters: []*sqliterpc.Value{text("t", "two"), integer("i", 2)},
+		},
+		{
+			name:       "dollar",
+			sql:        `insert into testing (intCol, textCol) values ($i, $t)`,
+			parameters: []*sqliterpc.Value{text("$t", "three"), integer("$i", 3)},
+		},
+		{
+			name:       "mixed",
+			sql:        `insert into testing (intCol, textCol) values (?, :t)`,
+			parameters: []*sqliterpc.Value{integer("", 4), text("t", "four")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Exec(
+				ctx,
+				&sqliterpc.ExecRequest{
+					Sql:        tt.sql,
+					Parameters: tt.parameters,
+				},
+			)
+			require.NoError(t, err)
+		})
+	}
+
+	resp, err := s.Query(
+		ctx,
+		&sqliterpc.QueryRequest{
+			Sql:        `select intCol, textCol from testing where intCol > ? and textCol != :skip order by intCol`,
+			Parameters: []*sqliterpc.Value{integer("", 1), text("skip", "three")},
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, resp.Rows, 2)
+
+	require.Equal(t, int64(2), resp.Rows[0].Values[0].GetIntegerValue().Value)
+	require.Equal(t, "two", resp.Rows[0].Values[1].GetTextValue().Value)
+	require.Equal(t, int64(4), resp.Rows[1].Values[0].GetIntegerValue().Value)
+	require.Equal(t, "four", resp.Rows[1].Values[1].GetTextValue().Value)
+}
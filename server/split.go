@@ -0,0 +1,71 @@
+package server
+
+// #include <stdlib.h>
+//
+// // defined by the sqlite library go-sqlite3 is built with.
+// int sqlite3_complete(const char *sql);
+import "C"
+
+import (
+	"strings"
+	"unsafe"
+)
+
+// splitStatement returns the first statement of sql, including its semicolon,
+// and the sql after it. A statement ends at the first semicolon that sqlite
+// considers the end of a complete statement, so semicolons in strings,
+// comments, and trigger bodies do not end it. The tail left by preparing a
+// statement is not used, as go-sqlite3 does not return it, and statements
+// are split before they can be prepared, such as those that use tables
+// created by earlier statements, or follow a statement that fails.
+// see https://www.sqlite.org/c3ref/complete.html
+func splitStatement(sql string) (string, string) {
+	for end := 0; end < len(sql); end++ {
+		i := strings.IndexByte(sql[end:], ';')
+		if i < 0 {
+			break
+		}
+
+		end += i
+
+		if complete(sql[:end+1]) {
+			return sql[:end+1], sql[end+1:]
+		}
+	}
+
+	return sql, ""
+}
+
+func complete(sql string) bool {
+	s := C.CString(sql)
+	defer C.free(unsafe.Pointer(s))
+
+	return C.sqlite3_complete(s) == 1
+}
+
+// emptyStatement returns true if sql is only whitespace, comments, and
+// semicolons, which sqlite does not prepare a statement for.
+// see https://www.sqlite.org/lang_comment.html
+func emptyStatement(sql string) bool {
+	for i := 0; i < len(sql); i++ {
+		switch {
+		case strings.IndexByte(" \t\n\f\r;", sql[i]) >= 0:
+		case strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				return true
+			}
+			i += end
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				return true
+			}
+			i += end + 3
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,390 @@
+package server
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/auth"
+)
+
+// statement is a prepared statement. Statements belong to the principal that
+// prepared them, and are shared by sql, unless prepared in a transaction.
+type statement struct {
+	id        string
+	principal string
+	sql       string
+	numInput  int
+	// stmt is nil for sql with multiple statements, as a *sql.Stmt
+	// only runs the first.
+	stmt *sql.Stmt
+	// db is the database the statement runs on, the readers if it only reads.
+	db *sql.DB
+	// opens is the number of Prepare calls that have not been closed.
+	opens int
+	// refs is the number of in flight requests using stmt.
+	refs int
+	// shared is true if Prepare returns the statement for its sql. Statements
+	// prepared in a transaction may use its changes, so are not shared.
+	shared  bool
+	removed bool
+	element *list.Element
+}
+
+// statementKey identifies the shared statement for sql prepared by principal.
+type statementKey struct {
+	principal string
+	sql       string
+}
+
+// statements is a bounded cache of prepared statements. When the schema changes,
+// all statements are removed.
+type statements struct {
+	lock  sync.Mutex
+	byID  map[string]*statement
+	bySQL map[statementKey]*statement
+	// lru is ordered from most to least recently used
+	lru        *list.List
+	max        int
+	generation uint64
+	connector  *connector
+}
+
+func newStatements(c *connector, max int) *statements {
+	s := statements{
+		byID:       make(map[string]*statement),
+		bySQL:      make(map[statementKey]*statement),
+		lru:        list.New(),
+		max:        max,
+		generation: c.generation(),
+		connector:  c,
+	}
+
+	return &s
+}
+
+// checkSchema removes all statements if the schema has changed. lock must be held.
+func (s *statements) checkSchema() {
+	generation := s.connector.generation()
+	if generation == s.generation {
+		return
+	}
+
+	s.generation = generation
+	s.removeAll()
+}
+
+// remove removes st. The *sql.Stmt is closed once it is no longer in use.
+// lock must be held.
+func (s *statements) remove(st *statement) {
+	if st.removed {
+		return
+	}
+
+	st.removed = true
+	s.lru.Remove(st.element)
+	delete(s.byID, st.id)
+
+	if st.shared {
+		delete(s.bySQL, statementKey{principal: st.principal, sql: st.sql})
+	}
+
+	if st.refs == 0 && st.stmt != nil {
+		_ = st.stmt.Close()
+	}
+}
+
+// open returns the statement for query, if principal has prepared it.
+func (s *statements) open(principal string, query string) (*statement, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.checkSchema()
+
+	st, ok := s.bySQL[statementKey{principal: principal, sql: query}]
+	if !ok {
+		return nil, false
+	}
+
+	st.opens++
+	s.lru.MoveToFront(st.element)
+
+	return st, true
+}
+
+// add adds a statement newly prepared by principal. If shared and principal
+// prepared query concurrently, that statement is used instead.
+func (s *statements) add(principal string, query string, numInput int, db *sql.DB, stmt *sql.Stmt, shared bool) (*statement, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.checkSchema()
+
+	key := statementKey{principal: principal, sql: query}
+
+	if st, ok := s.bySQL[key]; ok && shared {
+		if stmt != nil {
+			_ = stmt.Close()
+		}
+
+		st.opens++
+		s.lru.MoveToFront(st.element)
+
+		return st, nil
+	}
+
+	st := statement{
+		id:        id,
+		principal: principal,
+		sql:       query,
+		numInput:  numInput,
+		stmt:      stmt,
+		db:        db,
+		opens:     1,
+		shared:    shared,
+	}
+
+	st.element = s.lru.PushFront(&st)
+	s.byID[id] = &st
+
+	if shared {
+		s.bySQL[key] = &st
+	}
+
+	for s.lru.Len() > s.max {
+		s.remove(s.lru.Back().Value.(*statement))
+	}
+
+	return &st, nil
+}
+
+// acquire returns the statement for id, which must have been prepared by
+// principal. If id is empty, nil is returned. release must be called when the
+// request is done.
+func (s *statements) acquire(principal string, id string) (*statement, func(), error) {
+	if id == "" {
+		return nil, func() {}, nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.checkSchema()
+
+	st, ok := s.byID[id]
+	if !ok || st.principal != principal {
+		return nil, nil, statementNotFound()
+	}
+
+	st.refs++
+	s.lru.MoveToFront(st.element)
+
+	release := func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		st.refs--
+		if st.refs == 0 && st.removed && st.stmt != nil {
+			_ = st.stmt.Close()
+		}
+	}
+
+	return st, release, nil
+}
+
+// resolve returns the sql for the statement, which must have been prepared
+// by principal. Batches run on a pinned connection, so only need the sql.
+func (s *statements) resolve(principal string, id string) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.checkSchema()
+
+	st, ok := s.byID[id]
+	if !ok || st.principal != principal {
+		return "", statementNotFound()
+	}
+
+	s.lru.MoveToFront(st.element)
+
+	return st.sql, nil
+}
+
+// close closes the statement for id, which must have been prepared by principal.
+func (s *statements) close(principal string, id string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	st, ok := s.byID[id]
+	if !ok || st.principal != principal {
+		return false
+	}
+
+	st.opens--
+	if st.opens <= 0 {
+		s.remove(st)
+	}
+
+	return true
+}
+
+// closeAll closes all statements.
+func (s *statements) closeAll() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.removeAll()
+}
+
+// removeAll removes all statements. lock must be held.
+func (s *statements) removeAll() {
+	for e := s.lru.Front(); e != nil; {
+		st := e.Value.(*statement)
+		e = e.Next()
+		s.remove(st)
+	}
+}
+
+// principalName identifies the principal of ctx, which statements belong to.
+func principalName(ctx context.Context) string {
+	if p := auth.FromContext(ctx); p != nil {
+		return p.Name
+	}
+
+	return ""
+}
+
+func statementNotFound() twirp.Error {
+	return twirp.NotFoundError("statement not found").WithMeta("argument", "statement_id")
+}
+
+// queryer returns the queryer to run the statement. The cached *sql.Stmt can
+// only be used with the database it was prepared on. Transactions and policies
+// use a pinned connection, so the sql is prepared again on that connection.
+func (st *statement) queryer(q queryer) queryer {
+	if st == nil || st.stmt == nil || q != queryer(st.db) {
+		return q
+	}
+
+	return preparedQueryer{stmt: st.stmt}
+}
+
+// preparedQueryer runs a prepared statement. The query is ignored.
+type preparedQueryer struct {
+	stmt *sql.Stmt
+}
+
+func (p preparedQueryer) ExecContext(ctx context.Context, _ string, args ...interface{}) (sql.Result, error) {
+	return p.stmt.ExecContext(ctx, args...)
+}
+
+func (p preparedQueryer) QueryContext(ctx context.Context, _ string, args ...interface{}) (*sql.Rows, error) {
+	return p.stmt.QueryContext(ctx, args...)
+}
+
+func (s *DatabaseServer) Prepare(ctx context.Context, req *sqliterpc.PrepareRequest) (*sqliterpc.PrepareResponse, error) {
+	if req.Sql == "" {
+		return nil, twirp.RequiredArgumentError("sql")
+	}
+
+	if req.TransactionId != "" {
+		return s.prepareInTransaction(ctx, req)
+	}
+
+	principal := principalName(ctx)
+
+	if st, ok := s.statements.open(principal, req.Sql); ok {
+		return prepareResponse(st), nil
+	}
+
+	info, err := s.inspect(ctx, req.Sql, s.policies.policy(auth.FromContext(ctx)))
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	db := s.writer
+	if info.readOnly {
+		db = s.readers
+	} else if err := s.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	var stmt *sql.Stmt
+
+	if info.numInput >= 0 {
+		if err := s.holds.check(db); err != nil {
+			return nil, err
+		}
+
+		stmt, err = db.PrepareContext(ctx, req.Sql)
+		if err != nil {
+			return nil, wrapError(err)
+		}
+	}
+
+	st, err := s.statements.add(principal, req.Sql, info.numInput, db, stmt, true)
+	if err != nil {
+		if stmt != nil {
+			_ = stmt.Close()
+		}
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return prepareResponse(st), nil
+}
+
+// prepareInTransaction prepares the sql on the transaction's connection, which
+// enforces any policy and sees the transaction's changes. The writer may be
+// held by the transaction, so the sql is prepared again whenever it is run.
+func (s *DatabaseServer) prepareInTransaction(ctx context.Context, req *sqliterpc.PrepareRequest) (*sqliterpc.PrepareResponse, error) {
+	var info statementInfo
+
+	err := s.transactions.with(req.TransactionId, func(t *transaction) error {
+		var err error
+		info, err = inspect(t.conn, req.Sql)
+		return err
+	})
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	db := s.writer
+	if info.readOnly {
+		db = s.readers
+	} else if err := s.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	st, err := s.statements.add(principalName(ctx), req.Sql, info.numInput, db, nil, false)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return prepareResponse(st), nil
+}
+
+func prepareResponse(st *statement) *sqliterpc.PrepareResponse {
+	resp := sqliterpc.PrepareResponse{
+		StatementId: st.id,
+		NumInput:    int32(st.numInput),
+	}
+
+	return &resp
+}
+
+func (s *DatabaseServer) CloseStatement(ctx context.Context, req *sqliterpc.CloseStatementRequest) (*sqliterpc.CloseStatementResponse, error) {
+	if !s.statements.close(principalName(ctx), req.StatementId) {
+		return nil, statementNotFound()
+	}
+
+	return &sqliterpc.CloseStatementResponse{}, nil
+}
@@ -0,0 +1,205 @@
+package server_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/auth"
+	"github.com/bakins/sqliterpc/server"
+)
+
+func TestPrepare(t *testing.T) {
+	file := "prepare.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file, server.WithMaxStatements(2))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table testing (intCol INTEGER, textCol TEXT)`})
+	require.NoError(t, err)
+
+	insert, err := s.Prepare(ctx, &sqliterpc.PrepareRequest{Sql: `insert into testing (intCol, textCol) values (?, ?)`})
+	require.NoError(t, err)
+	require.NotEmpty(t, insert.StatementId)
+	require.Equal(t, int32(2), insert.NumInput)
+
+	for i := 0; i < 3; i++ {
+		_, err = s.Exec(ctx, &sqliterpc.ExecRequest{
+			StatementId: insert.StatementId,
+			Parameters: []*sqliterpc.Value{
+				{Kind: &sqliterpc.Value_IntegerValue{IntegerValue: &sqliterpc.IntergerValue{Value: int64(i), Valid: true}}},
+				{Kind: &sqliterpc.Value_TextValue{TextValue: &sqliterpc.TextValue{Value: "text", Valid: true}}},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	sel, err := s.Prepare(ctx, &sqliterpc.PrepareRequest{Sql: `select intCol from testing`})
+	require.NoError(t, err)
+	require.Equal(t, int32(0), sel.NumInput)
+
+	// statements are shared by sql
+	again, err := s.Prepare(ctx, &sqliterpc.PrepareRequest{Sql: `select intCol from testing`})
+	require.NoError(t, err)
+	require.Equal(t, sel.StatementId, again.StatementId)
+
+	resp, err := s.Query(ctx, &sqliterpc.QueryRequest{StatementId: sel.StatementId})
+	require.NoError(t, err)
+	require.Len(t, resp.Rows, 3)
+
+	resp, err = s.Query(ctx, &sqliterpc.QueryRequest{StatementId: sel.StatementId, PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, resp.Rows, 2)
+	require.NotEmpty(t, resp.NextPageToken)
+
+	_, err = s.CloseCursor(ctx, &sqliterpc.CloseCursorRequest{PageToken: resp.NextPageToken})
+	require.NoError(t, err)
+
+	t.Run("transaction", func(t *testing.T) {
+		begin, err := s.Begin(ctx, &sqliterpc.BeginRequest{})
+		require.NoError(t, err)
+
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{StatementId: sel.StatementId, TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 3)
+
+		_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table created (intCol INTEGER)`, TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+
+		// statements prepared in the transaction may use its changes, so are not shared.
+		created, err := s.Prepare(ctx, &sqliterpc.PrepareRequest{Sql: `select intCol from created`, TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+
+		resp, err = s.Query(ctx, &sqliterpc.QueryRequest{StatementId: created.StatementId, TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+		require.Empty(t, resp.Rows)
+
+		_, err = s.Prepare(ctx, &sqliterpc.PrepareRequest{Sql: `select intCol from created`})
+		requireCode(t, err, twirp.InvalidArgument)
+
+		_, err = s.CloseStatement(ctx, &sqliterpc.CloseStatementRequest{StatementId: created.StatementId})
+		require.NoError(t, err)
+
+		_, err = s.Rollback(ctx, &sqliterpc.RollbackRequest{TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+	})
+
+	t.Run("multiple statements", func(t *testing.T) {
+		multi, err := s.Prepare(ctx, &sqliterpc.PrepareRequest{Sql: `update testing set textCol = 'a'; update testing set textCol = 'b'`})
+		require.NoError(t, err)
+		require.Equal(t, int32(-1), multi.NumInput)
+
+		_, err = s.Exec(ctx, &sqliterpc.ExecRequest{StatementId: multi.StatementId})
+		require.NoError(t, err)
+
+		resp, err := s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select textCol from testing where textCol = 'b'`})
+		require.NoError(t, err)
+		require.Len(t, resp.Rows, 3)
+	})
+
+	t.Run("evicted", func(t *testing.T) {
+		// the insert is the least recently used
+		_, err := s.Exec(ctx, &sqliterpc.ExecRequest{StatementId: insert.StatementId})
+		requireCode(t, err, twirp.NotFound)
+	})
+
+	t.Run("schema change", func(t *testing.T) {
+		_, err := s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `alter table testing add column realCol REAL`})
+		require.NoError(t, err)
+
+		_, err = s.Query(ctx, &sqliterpc.QueryRequest{StatementId: sel.StatementId})
+		requireCode(t, err, twirp.NotFound)
+	})
+
+	t.Run("close", func(t *testing.T) {
+		st, err := s.Prepare(ctx, &sqliterpc.PrepareRequest{Sql: `select textCol from testing`})
+		require.NoError(t, err)
+
+		_, err = s.CloseStatement(ctx, &sqliterpc.CloseStatementRequest{StatementId: st.StatementId})
+		require.NoError(t, err)
+
+		_, err = s.Query(ctx, &sqliterpc.QueryRequest{StatementId: st.StatementId})
+		requireCode(t, err, twirp.NotFound)
+
+		_, err = s.CloseStatement(ctx, &sqliterpc.CloseStatementRequest{StatementId: st.StatementId})
+		requireCode(t, err, twirp.NotFound)
+	})
+
+	t.Run("invalid sql", func(t *testing.T) {
+		_, err := s.Prepare(ctx, &sqliterpc.PrepareRequest{Sql: `select from`})
+		requireCode(t, err, twirp.InvalidArgument)
+	})
+
+	t.Run("other principal", func(t *testing.T) {
+		st, err := s.Prepare(ctx, &sqliterpc.PrepareRequest{Sql: `select textCol from testing`})
+		require.NoError(t, err)
+
+		other := auth.ToContext(ctx, &auth.Principal{Name: "other"})
+
+		// statements are only used by the principal that prepared them.
+		_, err = s.Query(other, &sqliterpc.QueryRequest{StatementId: st.StatementId})
+		requireCode(t, err, twirp.NotFound)
+
+		_, err = s.CloseStatement(other, &sqliterpc.CloseStatementRequest{StatementId: st.StatementId})
+		requireCode(t, err, twirp.NotFound)
+
+		otherSt, err := s.Prepare(other, &sqliterpc.PrepareRequest{Sql: `select textCol from testing`})
+		require.NoError(t, err)
+		require.NotEqual(t, st.StatementId, otherSt.StatementId)
+
+		_, err = s.Query(ctx, &sqliterpc.QueryRequest{StatementId: st.StatementId})
+		require.NoError(t, err)
+	})
+}
+
+func TestPrepareSchemaUnchanged(t *testing.T) {
+	s, err := server.New(filepath.Join(t.TempDir(), "unchanged.db"))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table testing (intCol INTEGER)`})
+	require.NoError(t, err)
+
+	sel, err := s.Prepare(ctx, &sqliterpc.PrepareRequest{Sql: `select intCol from testing`})
+	require.NoError(t, err)
+
+	// statements that change the schema are prepared, but not run.
+	_, err = s.Prepare(ctx, &sqliterpc.PrepareRequest{Sql: `create table other (id INTEGER)`})
+	require.NoError(t, err)
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table if not exists testing (intCol INTEGER)`})
+	require.NoError(t, err)
+
+	begin, err := s.Begin(ctx, &sqliterpc.BeginRequest{})
+	require.NoError(t, err)
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `create table rolledback (id INTEGER)`, TransactionId: begin.TransactionId})
+	require.NoError(t, err)
+
+	_, err = s.Rollback(ctx, &sqliterpc.RollbackRequest{TransactionId: begin.TransactionId})
+	require.NoError(t, err)
+
+	_, err = s.Exec(ctx, &sqliterpc.ExecRequest{Sql: `insert into testing (intCol) values (1)`})
+	require.NoError(t, err)
+
+	// none of them changed the schema, so the statement is still prepared.
+	resp, err := s.Query(ctx, &sqliterpc.QueryRequest{StatementId: sel.StatementId})
+	require.NoError(t, err)
+	require.Len(t, resp.Rows, 1)
+}
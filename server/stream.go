@@ -0,0 +1,466 @@
+package server
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/twitchtv/twirp"
+	"github.com/twitchtv/twirp/ctxsetters"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bakins/sqliterpc"
+)
+
+// NewHandler returns a handler that serves the Twirp services and
+// the streaming endpoints for s. twirpOptions are passed to the Twirp servers,
+// and their hooks and interceptors also run for the streaming endpoints.
+// Streams are encoded as protobuf or JSON, like the Twirp methods.
+// Requests may be compressed with gzip.
+func NewHandler(s *DatabaseServer, twirpOptions ...interface{}) http.Handler {
+	ts := sqliterpc.NewDatabaseServiceServer(s, twirpOptions...)
+	schema := sqliterpc.NewSchemaServiceServer(s, twirpOptions...)
+	admin := sqliterpc.NewAdminServiceServer(s, twirpOptions...)
+
+	streams := newStreamServer(twirpOptions)
+
+	mux := http.NewServeMux()
+	mux.Handle(ts.PathPrefix(), ts)
+	mux.Handle(schema.PathPrefix(), schema)
+	mux.Handle(admin.PathPrefix(), admin)
+	mux.Handle(sqliterpc.QueryStreamPath, streams.handler("DatabaseService", "QueryStream", s.serveQueryStream, func() proto.Message {
+		return &sqliterpc.QueryStreamRequest{}
+	}))
+	mux.Handle(sqliterpc.SubscribePath, streams.handler("DatabaseService", "Subscribe", s.serveSubscribe, func() proto.Message {
+		return &sqliterpc.SubscribeRequest{}
+	}))
+	mux.Handle(sqliterpc.BackupStreamPath, streams.handler("AdminService", "BackupStream", s.serveBackupStream, func() proto.Message {
+		return &sqliterpc.BackupRequest{}
+	}))
+
+	return decompressRequests(mux)
+}
+
+// streamServer runs the streaming endpoints with the hooks and interceptors
+// of the Twirp servers, so they are handled like any other method.
+type streamServer struct {
+	hooks       *twirp.ServerHooks
+	interceptor twirp.Interceptor
+}
+
+// newStreamServer reads the hooks and interceptors from twirpOptions the
+// same way the generated servers do.
+func newStreamServer(twirpOptions []interface{}) *streamServer {
+	var options twirp.ServerOptions
+
+	for _, o := range twirpOptions {
+		switch o := o.(type) {
+		case twirp.ServerOption:
+			o(&options)
+		case *twirp.ServerHooks:
+			twirp.WithServerHooks(o)(&options)
+		}
+	}
+
+	s := streamServer{
+		hooks:       options.Hooks,
+		interceptor: twirp.ChainInterceptors(options.Interceptors...),
+	}
+
+	if s.hooks == nil {
+		s.hooks = &twirp.ServerHooks{}
+	}
+
+	return &s
+}
+
+// streamFunc serves a stream. It returns an error if it fails before the
+// response is started, and otherwise sends errors in the stream.
+type streamFunc func(ctx context.Context, sw *streamWriter, req proto.Message) error
+
+// handler returns a handler for the stream method of service. newRequest
+// returns the message the request body is read into, which is passed to serve.
+func (s *streamServer) handler(service, method string, serve streamFunc, newRequest func() proto.Message) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+		ctx = ctxsetters.WithServiceName(ctx, service)
+		ctx = ctxsetters.WithResponseWriter(ctx, w)
+
+		ctx, err := s.callHook(ctx, s.hooks.RequestReceived)
+		if err != nil {
+			s.writeError(ctx, w, err)
+			return
+		}
+
+		if r.Method != http.MethodPost {
+			s.writeError(ctx, w, twirp.NewError(twirp.BadRoute, "unsupported method "+r.Method))
+			return
+		}
+
+		json, ok := jsonContentType(r.Header.Get("Content-Type"))
+		if !ok {
+			s.writeError(ctx, w, twirp.NewError(twirp.BadRoute, fmt.Sprintf("unexpected Content-Type: %q", r.Header.Get("Content-Type"))))
+			return
+		}
+
+		ctx = ctxsetters.WithMethodName(ctx, method)
+
+		ctx, err = s.callHook(ctx, s.hooks.RequestRouted)
+		if err != nil {
+			s.writeError(ctx, w, err)
+			return
+		}
+
+		req := newRequest()
+
+		if err := readRequest(w, r, req, json); err != nil {
+			s.writeError(ctx, w, err)
+			return
+		}
+
+		pw := preparedWriter{
+			ResponseWriter: w,
+			ctx:            ctx,
+			hooks:          s.hooks,
+		}
+
+		call := func(ctx context.Context, req interface{}) (interface{}, error) {
+			m, ok := req.(proto.Message)
+			if !ok {
+				return nil, twirp.InternalError(fmt.Sprintf("unexpected request type %T", req))
+			}
+
+			return nil, serve(ctx, &streamWriter{w: &pw, json: json}, m)
+		}
+
+		if s.interceptor != nil {
+			call = s.interceptor(call)
+		}
+
+		if _, err := call(ctx, req); err != nil {
+			if pw.prepared {
+				// the error was sent in the stream
+				ctx = s.callErrorHook(ctx, err)
+			} else {
+				s.writeError(ctx, w, err)
+				return
+			}
+		}
+
+		ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+
+		if s.hooks.ResponseSent != nil {
+			s.hooks.ResponseSent(ctx)
+		}
+	})
+}
+
+func (s *streamServer) callHook(ctx context.Context, hook func(context.Context) (context.Context, error)) (context.Context, error) {
+	if hook == nil {
+		return ctx, nil
+	}
+
+	return hook(ctx)
+}
+
+func (s *streamServer) callErrorHook(ctx context.Context, err error) context.Context {
+	if s.hooks.Error == nil {
+		return ctx
+	}
+
+	return s.hooks.Error(ctx, wrapError(err))
+}
+
+// writeError writes err as a Twirp error response and calls the hooks.
+func (s *streamServer) writeError(ctx context.Context, w http.ResponseWriter, err error) {
+	twerr := wrapError(err)
+
+	ctx = ctxsetters.WithStatusCode(ctx, twirp.ServerHTTPStatusFromErrorCode(twerr.Code()))
+	ctx = s.callErrorHook(ctx, twerr)
+
+	_ = twirp.WriteError(w, twerr)
+
+	if s.hooks.ResponseSent != nil {
+		s.hooks.ResponseSent(ctx)
+	}
+}
+
+// preparedWriter calls the response prepared hook before the response is
+// started.
+type preparedWriter struct {
+	http.ResponseWriter
+	ctx      context.Context
+	hooks    *twirp.ServerHooks
+	prepared bool
+}
+
+func (w *preparedWriter) WriteHeader(statusCode int) {
+	if !w.prepared {
+		w.prepared = true
+
+		if w.hooks.ResponsePrepared != nil {
+			w.ctx = w.hooks.ResponsePrepared(w.ctx)
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *preparedWriter) Write(b []byte) (int, error) {
+	if !w.prepared {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *preparedWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// jsonContentType returns whether contentType is JSON rather than protobuf,
+// or false for ok if it is neither. Requests without a Content-Type are
+// protobuf, as streams were before they could be JSON.
+func jsonContentType(contentType string) (json, ok bool) {
+	if i := strings.Index(contentType, ";"); i != -1 {
+		contentType = contentType[:i]
+	}
+
+	switch strings.TrimSpace(strings.ToLower(contentType)) {
+	case sqliterpc.ContentTypeJSON:
+		return true, true
+	case sqliterpc.ContentTypeProtobuf, "":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// decompressRequests decodes request bodies compressed with gzip.
+func decompressRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Content-Encoding") {
+		case "", "identity":
+		case "gzip":
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				_ = twirp.WriteError(w, twirp.NewError(twirp.Malformed, "failed to decompress request: "+err.Error()))
+				return
+			}
+
+			defer gz.Close()
+
+			r = r.Clone(r.Context())
+			r.Body = gz
+			r.ContentLength = -1
+			r.Header.Del("Content-Encoding")
+		default:
+			_ = twirp.WriteError(w, twirp.NewError(twirp.Malformed, "unsupported content encoding "+r.Header.Get("Content-Encoding")))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+const defaultStreamBatchSize = 100
+
+func (s *DatabaseServer) serveQueryStream(ctx context.Context, sw *streamWriter, m proto.Message) error {
+	req := m.(*sqliterpc.QueryStreamRequest)
+
+	if req.Query == nil {
+		return twirp.RequiredArgumentError("query")
+	}
+
+	st, release, err := s.statements.acquire(principalName(ctx), req.Query.StatementId)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
+	if st != nil {
+		req.Query.Sql = st.sql
+	}
+
+	batchSize := int(req.BatchSize)
+	if batchSize <= 0 {
+		batchSize = defaultStreamBatchSize
+	}
+
+	// streams are not limited in size, only in how long the query may run.
+	timeout, err := s.limits.requestTimeout(req.Query.Timeout)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+
+	db, err := s.route(ctx, st, req.Query.Sql)
+	if err != nil {
+		return err
+	}
+
+	err = s.withQueryer(ctx, req.Query.TransactionId, db, func(q queryer) error {
+		rows, columns, err := startQuery(ctx, st.queryer(q), req.Query)
+		if err != nil {
+			return err
+		}
+
+		defer rows.Close()
+
+		// errors after this point cannot change the status, so are sent as
+		// frames, and returned only for the hooks and interceptors.
+		sw.start()
+
+		header := sqliterpc.QueryStreamFrame{
+			Frame: &sqliterpc.QueryStreamFrame_Columns{
+				Columns: &sqliterpc.QueryStreamColumns{
+					Columns: columns,
+				},
+			},
+		}
+
+		if err := sw.write(&header); err != nil {
+			return nil
+		}
+
+		batch := sqliterpc.QueryStreamRows{}
+
+		for rows.Next() {
+			row, err := scanRow(rows, columns)
+			if err != nil {
+				sw.writeError(err)
+				return err
+			}
+
+			batch.Rows = append(batch.Rows, row)
+
+			if len(batch.Rows) >= batchSize {
+				if err := sw.writeRows(&batch); err != nil {
+					return nil
+				}
+
+				batch.Rows = nil
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			sw.writeError(wrapError(err))
+			return err
+		}
+
+		if len(batch.Rows) > 0 {
+			if err := sw.writeRows(&batch); err != nil {
+				return nil
+			}
+		}
+
+		done := sqliterpc.QueryStreamFrame{
+			Frame: &sqliterpc.QueryStreamFrame_Done{
+				Done: &sqliterpc.QueryStreamDone{},
+			},
+		}
+
+		_ = sw.write(&done)
+
+		return nil
+	})
+
+	return err
+}
+
+// readRequest reads a protobuf or JSON request body into m. Requests are
+// limited to the size of a message in a stream.
+func readRequest(w http.ResponseWriter, r *http.Request, m proto.Message, json bool) error {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, sqliterpc.MaxMessageSize))
+	if err != nil {
+		if len(data) >= sqliterpc.MaxMessageSize {
+			return twirp.NewError(twirp.ResourceExhausted, fmt.Sprintf("request is larger than %d bytes", sqliterpc.MaxMessageSize))
+		}
+		return twirp.InternalErrorWith(err)
+	}
+
+	if json {
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, m)
+	} else {
+		err = proto.Unmarshal(data, m)
+	}
+
+	if err != nil {
+		return twirp.NewError(twirp.Malformed, "failed to parse request: "+err.Error())
+	}
+
+	return nil
+}
+
+// streamWriter writes the frames of a stream in the encoding of its request.
+type streamWriter struct {
+	w    http.ResponseWriter
+	json bool
+}
+
+// start sends the response status, after which errors are sent as frames.
+func (s *streamWriter) start() {
+	if s.json {
+		s.w.Header().Set("Content-Type", sqliterpc.ContentTypeJSON)
+	} else {
+		s.w.Header().Set("Content-Type", sqliterpc.ContentTypeProtobuf)
+	}
+
+	s.w.WriteHeader(http.StatusOK)
+}
+
+// write writes a message and flushes it to the client.
+func (s *streamWriter) write(m proto.Message) error {
+	var err error
+	if s.json {
+		err = sqliterpc.WriteJSONMessage(s.w, m)
+	} else {
+		err = sqliterpc.WriteMessage(s.w, m)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
+
+func (s *streamWriter) writeRows(rows *sqliterpc.QueryStreamRows) error {
+	frame := sqliterpc.QueryStreamFrame{
+		Frame: &sqliterpc.QueryStreamFrame_Rows{
+			Rows: rows,
+		},
+	}
+
+	return s.write(&frame)
+}
+
+func (s *streamWriter) writeError(err error) {
+	twerr := wrapError(err)
+
+	frame := sqliterpc.QueryStreamFrame{
+		Frame: &sqliterpc.QueryStreamFrame_Error{
+			Error: &sqliterpc.StreamError{
+				Code:    string(twerr.Code()),
+				Message: twerr.Msg(),
+				Meta:    twerr.MetaMap(),
+			},
+		},
+	}
+
+	_ = s.write(&frame)
+}
@@ -0,0 +1,276 @@
+package server_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/server"
+)
+
+func TestQueryStream(t *testing.T) {
+	file := "stream.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file)
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = s.Exec(
+		ctx,
+		&sqliterpc.ExecRequest{
+			Sql: `create table testing (intCol INTEGER)`,
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = s.Exec(
+		ctx,
+		&sqliterpc.ExecRequest{
+			Sql: `insert into testing (intCol) values (1), (2), (3), (4), (5)`,
+		},
+	)
+	require.NoError(t, err)
+
+	svr := httptest.NewServer(server.NewHandler(s))
+	defer svr.Close()
+
+	post := func(req *sqliterpc.QueryStreamRequest) *http.Response {
+		body, err := proto.Marshal(req)
+		require.NoError(t, err)
+
+		resp, err := http.Post(svr.URL+sqliterpc.QueryStreamPath, "application/protobuf", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	resp := post(&sqliterpc.QueryStreamRequest{
+		Query: &sqliterpc.QueryRequest{
+			Sql: `select intCol from testing`,
+		},
+		BatchSize: 2,
+	})
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var frames []*sqliterpc.QueryStreamFrame
+
+	for {
+		var frame sqliterpc.QueryStreamFrame
+
+		err := sqliterpc.ReadMessage(resp.Body, &frame)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		frames = append(frames, &frame)
+	}
+
+	// columns, 3 batches of rows, done
+	require.Len(t, frames, 5)
+	require.Len(t, frames[0].GetColumns().Columns, 1)
+	require.Len(t, frames[1].GetRows().Rows, 2)
+	require.Len(t, frames[2].GetRows().Rows, 2)
+	require.Len(t, frames[3].GetRows().Rows, 1)
+	require.NotNil(t, frames[4].GetDone())
+
+	resp = post(&sqliterpc.QueryStreamRequest{
+		Query: &sqliterpc.QueryRequest{
+			Sql: `select * from missing`,
+		},
+	})
+	defer resp.Body.Close()
+
+	require.NotEqual(t, http.StatusOK, resp.StatusCode)
+
+	t.Run("json", func(t *testing.T) {
+		body := `{"query": {"sql": "select intCol from testing where intCol > 3"}, "batch_size": 1}`
+
+		resp, err := http.Post(svr.URL+sqliterpc.QueryStreamPath, "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+		// each frame is a line of JSON.
+		r := bufio.NewReader(resp.Body)
+
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		require.Contains(t, line, `"columns"`)
+
+		var frames []*sqliterpc.QueryStreamFrame
+
+		for {
+			var frame sqliterpc.QueryStreamFrame
+
+			err := sqliterpc.ReadJSONMessage(r, &frame)
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+
+			frames = append(frames, &frame)
+		}
+
+		// 2 batches of rows, done
+		require.Len(t, frames, 3)
+		require.Equal(t, int64(4), frames[0].GetRows().Rows[0].Values[0].GetIntegerValue().GetValue())
+		require.Equal(t, int64(5), frames[1].GetRows().Rows[0].Values[0].GetIntegerValue().GetValue())
+		require.NotNil(t, frames[2].GetDone())
+	})
+
+	t.Run("content type", func(t *testing.T) {
+		resp, err := http.Post(svr.URL+sqliterpc.QueryStreamPath, "text/plain", strings.NewReader("select 1"))
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestStreamHooks(t *testing.T) {
+	s, err := server.New(filepath.Join(t.TempDir(), "hooks.db"))
+	require.NoError(t, err)
+
+	var (
+		lock   sync.Mutex
+		events []string
+	)
+
+	record := func(event string) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		events = append(events, event)
+	}
+
+	hooks := &twirp.ServerHooks{
+		RequestRouted: func(ctx context.Context) (context.Context, error) {
+			method, _ := twirp.MethodName(ctx)
+			record("routed " + method)
+			return ctx, nil
+		},
+		Error: func(ctx context.Context, err twirp.Error) context.Context {
+			record("error " + string(err.Code()))
+			return ctx
+		},
+		ResponseSent: func(ctx context.Context) {
+			status, _ := twirp.StatusCode(ctx)
+			record("sent " + status)
+		},
+	}
+
+	interceptor := func(next twirp.Method) twirp.Method {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			method, _ := twirp.MethodName(ctx)
+			record("intercepted " + method)
+			return next(ctx, req)
+		}
+	}
+
+	svr := httptest.NewServer(server.NewHandler(s, hooks, twirp.WithServerInterceptors(interceptor)))
+	defer svr.Close()
+
+	post := func(path string, body []byte) {
+		resp, err := http.Post(svr.URL+path, "application/protobuf", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	check := func(t *testing.T, expected ...string) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		require.Equal(t, expected, events)
+		events = nil
+	}
+
+	t.Run("query", func(t *testing.T) {
+		body, err := proto.Marshal(&sqliterpc.QueryStreamRequest{
+			Query: &sqliterpc.QueryRequest{Sql: `select 1`},
+		})
+		require.NoError(t, err)
+
+		post(sqliterpc.QueryStreamPath, body)
+		check(t, "routed QueryStream", "intercepted QueryStream", "sent 200")
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		body, err := proto.Marshal(&sqliterpc.QueryStreamRequest{
+			Query: &sqliterpc.QueryRequest{Sql: `select * from missing`},
+		})
+		require.NoError(t, err)
+
+		post(sqliterpc.QueryStreamPath, body)
+		check(t, "routed QueryStream", "intercepted QueryStream", "error invalid_argument", "sent 400")
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		resp, err := http.Post(svr.URL+sqliterpc.QueryStreamPath, "application/protobuf", io.LimitReader(zeros{}, sqliterpc.MaxMessageSize+1))
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		check(t, "routed QueryStream", "error resource_exhausted", "sent 429")
+	})
+
+	t.Run("subscribe", func(t *testing.T) {
+		body, err := proto.Marshal(&sqliterpc.SubscribeRequest{})
+		require.NoError(t, err)
+
+		resp, err := http.Post(svr.URL+sqliterpc.SubscribePath, "application/protobuf", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		var frame sqliterpc.SubscribeFrame
+
+		require.NoError(t, sqliterpc.ReadMessage(resp.Body, &frame))
+		require.NotNil(t, frame.GetStarted())
+
+		// subscriptions end when the server is closed
+		require.NoError(t, s.Close())
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		check(t, "routed Subscribe", "intercepted Subscribe", "error unavailable", "sent 200")
+	})
+}
+
+// zeros is a reader of zero bytes.
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,323 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/auth"
+)
+
+// transaction pins a connection for the life of a transaction.
+// go-sqlite3 ignores sql.TxOptions, so we issue BEGIN ourselves
+// rather than use a *sql.Tx.
+type transaction struct {
+	lock     sync.Mutex
+	conn     *sql.Conn
+	readOnly bool
+	// restricted is true when the connection enforces a policy
+	restricted bool
+	// unhold is called when the connection is released.
+	unhold   func()
+	lastUsed time.Time
+}
+
+type transactions struct {
+	lock    sync.Mutex
+	txns    map[string]*transaction
+	timeout time.Duration
+	// defaultBegin starts transactions that do not set a mode.
+	defaultBegin string
+	holds        *writerHolds
+	// onFinish is called with the transaction's lock held before it is finished.
+	onFinish func(id string)
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func newTransactions(timeout time.Duration, txLock TxLock, holds *writerHolds, onFinish func(id string)) *transactions {
+	t := transactions{
+		txns:         make(map[string]*transaction),
+		timeout:      timeout,
+		defaultBegin: "BEGIN " + strings.ToUpper(string(txLock)),
+		holds:        holds,
+		onFinish:     onFinish,
+		stop:         make(chan struct{}),
+	}
+
+	if timeout > 0 {
+		t.wg.Add(1)
+		go t.reap()
+	}
+
+	return &t
+}
+
+func newID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b[:]), nil
+}
+
+var beginStatements = map[sqliterpc.TransactionMode]string{
+	sqliterpc.TransactionMode_TRANSACTION_MODE_DEFERRED:  "BEGIN DEFERRED",
+	sqliterpc.TransactionMode_TRANSACTION_MODE_IMMEDIATE: "BEGIN IMMEDIATE",
+	sqliterpc.TransactionMode_TRANSACTION_MODE_EXCLUSIVE: "BEGIN EXCLUSIVE",
+}
+
+// begin starts a transaction. If p is not nil, it is enforced for the life of the transaction.
+func (t *transactions) begin(ctx context.Context, db *sql.DB, req *sqliterpc.BeginRequest, p *policy) (string, error) {
+	statement, ok := beginStatements[req.Mode]
+	if req.Mode == sqliterpc.TransactionMode_TRANSACTION_MODE_UNSPECIFIED {
+		statement, ok = t.defaultBegin, true
+	}
+
+	if !ok {
+		return "", twirp.InvalidArgumentError("mode", "unknown transaction mode")
+	}
+
+	id, err := newID()
+	if err != nil {
+		return "", twirp.InternalErrorWith(err)
+	}
+
+	if err := t.holds.check(db); err != nil {
+		return "", err
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return "", wrapError(err)
+	}
+
+	txn := transaction{
+		conn:     conn,
+		readOnly: req.ReadOnly,
+		unhold:   t.holds.hold(db),
+		lastUsed: time.Now(),
+	}
+
+	if req.ReadOnly {
+		if _, err := conn.ExecContext(ctx, "PRAGMA query_only = 1"); err != nil {
+			_ = conn.Close()
+			txn.unhold()
+			return "", wrapError(err)
+		}
+	}
+
+	if _, err := conn.ExecContext(ctx, statement); err != nil {
+		txn.release()
+		return "", wrapError(err)
+	}
+
+	if p != nil {
+		if err := setPolicy(conn, p); err != nil {
+			_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+			txn.release()
+			return "", wrapError(err)
+		}
+
+		txn.restricted = true
+	}
+
+	t.lock.Lock()
+	t.txns[id] = &txn
+	t.lock.Unlock()
+
+	return id, nil
+}
+
+func (t *transactions) len() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return len(t.txns)
+}
+
+// with calls fn while holding the transaction's lock.
+func (t *transactions) with(id string, fn func(*transaction) error) error {
+	t.lock.Lock()
+	txn, ok := t.txns[id]
+	t.lock.Unlock()
+
+	if !ok {
+		return twirp.NotFoundError("transaction not found")
+	}
+
+	txn.lock.Lock()
+	defer txn.lock.Unlock()
+
+	// may have been finished while we waited on the lock
+	if txn.conn == nil {
+		return twirp.NotFoundError("transaction not found")
+	}
+
+	txn.lastUsed = time.Now()
+	defer func() {
+		txn.lastUsed = time.Now()
+	}()
+
+	return fn(txn)
+}
+
+// finish runs statement, which should be COMMIT or ROLLBACK,
+// and releases the transaction. If idle is set, the transaction is only
+// finished if it has not been used within the timeout, which is checked
+// while holding its lock, as it may be used after the reaper finds it.
+func (t *transactions) finish(ctx context.Context, id string, statement string, idle bool) error {
+	t.lock.Lock()
+	txn, ok := t.txns[id]
+	t.lock.Unlock()
+
+	if !ok {
+		return twirp.NotFoundError("transaction not found")
+	}
+
+	txn.lock.Lock()
+	defer txn.lock.Unlock()
+
+	if txn.conn == nil {
+		return twirp.NotFoundError("transaction not found")
+	}
+
+	if idle && time.Since(txn.lastUsed) <= t.timeout {
+		return nil
+	}
+
+	t.lock.Lock()
+	delete(t.txns, id)
+	t.lock.Unlock()
+
+	if t.onFinish != nil {
+		t.onFinish(id)
+	}
+
+	_, err := txn.conn.ExecContext(ctx, statement)
+	if err != nil {
+		// a failed COMMIT may leave the transaction open.
+		_, _ = txn.conn.ExecContext(context.Background(), "ROLLBACK")
+	}
+
+	txn.release()
+
+	if err != nil {
+		return wrapError(err)
+	}
+
+	return nil
+}
+
+// release returns the connection to the pool. lock must be held.
+func (txn *transaction) release() {
+	if txn.restricted {
+		_ = setPolicy(txn.conn, nil)
+	}
+
+	if txn.readOnly {
+		_, _ = txn.conn.ExecContext(context.Background(), "PRAGMA query_only = 0")
+	}
+
+	_ = txn.conn.Close()
+	txn.conn = nil
+	txn.unhold()
+}
+
+func (t *transactions) reap() {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(t.timeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+		}
+
+		var expired []string
+
+		t.lock.Lock()
+		for id, txn := range t.txns {
+			if !txn.lock.TryLock() {
+				// in use
+				continue
+			}
+
+			if time.Since(txn.lastUsed) > t.timeout {
+				expired = append(expired, id)
+			}
+
+			txn.lock.Unlock()
+		}
+		t.lock.Unlock()
+
+		for _, id := range expired {
+			_ = t.finish(context.Background(), id, "ROLLBACK", true)
+		}
+	}
+}
+
+// close stops reaping and rolls back all open transactions.
+func (t *transactions) close() {
+	close(t.stop)
+	t.wg.Wait()
+
+	t.lock.Lock()
+	ids := make([]string, 0, len(t.txns))
+	for id := range t.txns {
+		ids = append(ids, id)
+	}
+	t.lock.Unlock()
+
+	for _, id := range ids {
+		_ = t.finish(context.Background(), id, "ROLLBACK", false)
+	}
+}
+
+func (s *DatabaseServer) Begin(ctx context.Context, req *sqliterpc.BeginRequest) (*sqliterpc.BeginResponse, error) {
+	// read only transactions do not need to wait for the writer.
+	db := s.writer
+	if req.ReadOnly {
+		db = s.readers
+	} else if err := s.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	id, err := s.transactions.begin(ctx, db, req, s.policies.policy(auth.FromContext(ctx)))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := sqliterpc.BeginResponse{
+		TransactionId: id,
+	}
+
+	return &resp, nil
+}
+
+func (s *DatabaseServer) Commit(ctx context.Context, req *sqliterpc.CommitRequest) (*sqliterpc.CommitResponse, error) {
+	if err := s.transactions.finish(ctx, req.TransactionId, "COMMIT", false); err != nil {
+		return nil, err
+	}
+
+	return &sqliterpc.CommitResponse{}, nil
+}
+
+func (s *DatabaseServer) Rollback(ctx context.Context, req *sqliterpc.RollbackRequest) (*sqliterpc.RollbackResponse, error) {
+	if err := s.transactions.finish(ctx, req.TransactionId, "ROLLBACK", false); err != nil {
+		return nil, err
+	}
+
+	return &sqliterpc.RollbackResponse{}, nil
+}
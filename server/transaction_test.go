@@ -0,0 +1,157 @@
+package server_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/bakins/sqliterpc"
+	"github.com/bakins/sqliterpc/server"
+)
+
+func TestTransaction(t *testing.T) {
+	file := "transaction.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file)
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = s.Exec(
+		ctx,
+		&sqliterpc.ExecRequest{
+			Sql: `DROP TABLE IF EXISTS testing`,
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = s.Exec(
+		ctx,
+		&sqliterpc.ExecRequest{
+			Sql: `create table testing (intCol INTEGER)`,
+		},
+	)
+	require.NoError(t, err)
+
+	count := func(t *testing.T, transactionID string) int {
+		resp, err := s.Query(
+			ctx,
+			&sqliterpc.QueryRequest{
+				Sql:           "select intCol from testing",
+				TransactionId: transactionID,
+			},
+		)
+		require.NoError(t, err)
+
+		return len(resp.Rows)
+	}
+
+	insert := func(transactionID string) error {
+		_, err := s.Exec(
+			ctx,
+			&sqliterpc.ExecRequest{
+				Sql:           `insert into testing (intCol) values(1)`,
+				TransactionId: transactionID,
+			},
+		)
+		return err
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		begin, err := s.Begin(ctx, &sqliterpc.BeginRequest{
+			Mode: sqliterpc.TransactionMode_TRANSACTION_MODE_IMMEDIATE,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, begin.TransactionId)
+
+		require.NoError(t, insert(begin.TransactionId))
+		require.Equal(t, 1, count(t, begin.TransactionId))
+
+		_, err = s.Commit(ctx, &sqliterpc.CommitRequest{TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, count(t, ""))
+
+		// transaction is no longer usable
+		err = insert(begin.TransactionId)
+		require.Error(t, err)
+		require.Equal(t, twirp.NotFound, err.(twirp.Error).Code())
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		begin, err := s.Begin(ctx, &sqliterpc.BeginRequest{})
+		require.NoError(t, err)
+
+		require.NoError(t, insert(begin.TransactionId))
+		require.Equal(t, 2, count(t, begin.TransactionId))
+
+		_, err = s.Rollback(ctx, &sqliterpc.RollbackRequest{TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, count(t, ""))
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		begin, err := s.Begin(ctx, &sqliterpc.BeginRequest{ReadOnly: true})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, count(t, begin.TransactionId))
+		require.Error(t, insert(begin.TransactionId))
+
+		_, err = s.Rollback(ctx, &sqliterpc.RollbackRequest{TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+
+		// connection is writable once returned to the pool
+		require.NoError(t, insert(""))
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := s.Commit(ctx, &sqliterpc.CommitRequest{TransactionId: "unknown"})
+		require.Error(t, err)
+		require.Equal(t, twirp.NotFound, err.(twirp.Error).Code())
+	})
+}
+
+func TestTransactionTimeout(t *testing.T) {
+	file := "transaction.db"
+	defer os.Remove(file)
+
+	s, err := server.New(file, server.WithTransactionTimeout(time.Millisecond*100))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	// transactions that are used are not rolled back.
+	begin, err := s.Begin(ctx, &sqliterpc.BeginRequest{})
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, err = s.Query(ctx, &sqliterpc.QueryRequest{Sql: `select 1`, TransactionId: begin.TransactionId})
+		require.NoError(t, err)
+
+		time.Sleep(time.Millisecond * 40)
+	}
+
+	_, err = s.Commit(ctx, &sqliterpc.CommitRequest{TransactionId: begin.TransactionId})
+	require.NoError(t, err)
+
+	begin, err = s.Begin(ctx, &sqliterpc.BeginRequest{})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 500)
+
+	_, err = s.Commit(ctx, &sqliterpc.CommitRequest{TransactionId: begin.TransactionId})
+	require.Error(t, err)
+	require.Equal(t, twirp.NotFound, err.(twirp.Error).Code())
+}
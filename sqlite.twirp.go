@@ -36,22 +36,4083 @@ type DatabaseService interface {
 	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
 
 	Query(context.Context, *QueryRequest) (*QueryResponse, error)
+
+	Begin(context.Context, *BeginRequest) (*BeginResponse, error)
+
+	Commit(context.Context, *CommitRequest) (*CommitResponse, error)
+
+	Rollback(context.Context, *RollbackRequest) (*RollbackResponse, error)
+
+	Batch(context.Context, *BatchRequest) (*BatchResponse, error)
+
+	CloseCursor(context.Context, *CloseCursorRequest) (*CloseCursorResponse, error)
+
+	Prepare(context.Context, *PrepareRequest) (*PrepareResponse, error)
+
+	CloseStatement(context.Context, *CloseStatementRequest) (*CloseStatementResponse, error)
+
+	// ExecScript runs sql holding many statements. When a statement fails,
+	// the error has its index in the statement meta.
+	ExecScript(context.Context, *ExecScriptRequest) (*ExecScriptResponse, error)
+}
+
+// ===============================
+// DatabaseService Protobuf Client
+// ===============================
+
+type databaseServiceProtobufClient struct {
+	client      HTTPClient
+	urls        [10]string
+	interceptor twirp.Interceptor
+	opts        twirp.ClientOptions
+}
+
+// NewDatabaseServiceProtobufClient creates a Protobuf client that implements the DatabaseService interface.
+// It communicates using Protobuf and can be configured with a custom HTTPClient.
+func NewDatabaseServiceProtobufClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) DatabaseService {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	// Using ReadOpt allows backwards and forwads compatibility with new options in the future
+	literalURLs := false
+	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
+	var pathPrefix string
+	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
+	serviceURL := sanitizeBaseURL(baseURL)
+	serviceURL += baseServicePath(pathPrefix, "sqlite.rpc.v0", "DatabaseService")
+	urls := [10]string{
+		serviceURL + "Exec",
+		serviceURL + "Query",
+		serviceURL + "Begin",
+		serviceURL + "Commit",
+		serviceURL + "Rollback",
+		serviceURL + "Batch",
+		serviceURL + "CloseCursor",
+		serviceURL + "Prepare",
+		serviceURL + "CloseStatement",
+		serviceURL + "ExecScript",
+	}
+
+	return &databaseServiceProtobufClient{
+		client:      client,
+		urls:        urls,
+		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
+		opts:        clientOpts,
+	}
+}
+
+func (c *databaseServiceProtobufClient) Exec(ctx context.Context, in *ExecRequest) (*ExecResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "Exec")
+	caller := c.callExec
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ExecRequest) (*ExecResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExecRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExecRequest) when calling interceptor")
+					}
+					return c.callExec(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExecResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExecResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceProtobufClient) callExec(ctx context.Context, in *ExecRequest) (*ExecResponse, error) {
+	out := new(ExecResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceProtobufClient) Query(ctx context.Context, in *QueryRequest) (*QueryResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "Query")
+	caller := c.callQuery
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*QueryRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*QueryRequest) when calling interceptor")
+					}
+					return c.callQuery(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*QueryResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*QueryResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceProtobufClient) callQuery(ctx context.Context, in *QueryRequest) (*QueryResponse, error) {
+	out := new(QueryResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceProtobufClient) Begin(ctx context.Context, in *BeginRequest) (*BeginResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "Begin")
+	caller := c.callBegin
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *BeginRequest) (*BeginResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BeginRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BeginRequest) when calling interceptor")
+					}
+					return c.callBegin(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BeginResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BeginResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceProtobufClient) callBegin(ctx context.Context, in *BeginRequest) (*BeginResponse, error) {
+	out := new(BeginResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceProtobufClient) Commit(ctx context.Context, in *CommitRequest) (*CommitResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "Commit")
+	caller := c.callCommit
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *CommitRequest) (*CommitResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CommitRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CommitRequest) when calling interceptor")
+					}
+					return c.callCommit(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CommitResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CommitResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceProtobufClient) callCommit(ctx context.Context, in *CommitRequest) (*CommitResponse, error) {
+	out := new(CommitResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceProtobufClient) Rollback(ctx context.Context, in *RollbackRequest) (*RollbackResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "Rollback")
+	caller := c.callRollback
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RollbackRequest) (*RollbackResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RollbackRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RollbackRequest) when calling interceptor")
+					}
+					return c.callRollback(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RollbackResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RollbackResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceProtobufClient) callRollback(ctx context.Context, in *RollbackRequest) (*RollbackResponse, error) {
+	out := new(RollbackResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceProtobufClient) Batch(ctx context.Context, in *BatchRequest) (*BatchResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "Batch")
+	caller := c.callBatch
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *BatchRequest) (*BatchResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BatchRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BatchRequest) when calling interceptor")
+					}
+					return c.callBatch(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BatchResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BatchResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceProtobufClient) callBatch(ctx context.Context, in *BatchRequest) (*BatchResponse, error) {
+	out := new(BatchResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceProtobufClient) CloseCursor(ctx context.Context, in *CloseCursorRequest) (*CloseCursorResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "CloseCursor")
+	caller := c.callCloseCursor
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *CloseCursorRequest) (*CloseCursorResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CloseCursorRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CloseCursorRequest) when calling interceptor")
+					}
+					return c.callCloseCursor(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CloseCursorResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CloseCursorResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceProtobufClient) callCloseCursor(ctx context.Context, in *CloseCursorRequest) (*CloseCursorResponse, error) {
+	out := new(CloseCursorResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[6], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceProtobufClient) Prepare(ctx context.Context, in *PrepareRequest) (*PrepareResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "Prepare")
+	caller := c.callPrepare
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *PrepareRequest) (*PrepareResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PrepareRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PrepareRequest) when calling interceptor")
+					}
+					return c.callPrepare(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*PrepareResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*PrepareResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceProtobufClient) callPrepare(ctx context.Context, in *PrepareRequest) (*PrepareResponse, error) {
+	out := new(PrepareResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[7], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceProtobufClient) CloseStatement(ctx context.Context, in *CloseStatementRequest) (*CloseStatementResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "CloseStatement")
+	caller := c.callCloseStatement
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *CloseStatementRequest) (*CloseStatementResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CloseStatementRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CloseStatementRequest) when calling interceptor")
+					}
+					return c.callCloseStatement(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CloseStatementResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CloseStatementResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceProtobufClient) callCloseStatement(ctx context.Context, in *CloseStatementRequest) (*CloseStatementResponse, error) {
+	out := new(CloseStatementResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[8], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceProtobufClient) ExecScript(ctx context.Context, in *ExecScriptRequest) (*ExecScriptResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "ExecScript")
+	caller := c.callExecScript
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ExecScriptRequest) (*ExecScriptResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExecScriptRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExecScriptRequest) when calling interceptor")
+					}
+					return c.callExecScript(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExecScriptResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExecScriptResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceProtobufClient) callExecScript(ctx context.Context, in *ExecScriptRequest) (*ExecScriptResponse, error) {
+	out := new(ExecScriptResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[9], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// ===========================
+// DatabaseService JSON Client
+// ===========================
+
+type databaseServiceJSONClient struct {
+	client      HTTPClient
+	urls        [10]string
+	interceptor twirp.Interceptor
+	opts        twirp.ClientOptions
+}
+
+// NewDatabaseServiceJSONClient creates a JSON client that implements the DatabaseService interface.
+// It communicates using JSON and can be configured with a custom HTTPClient.
+func NewDatabaseServiceJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) DatabaseService {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	// Using ReadOpt allows backwards and forwads compatibility with new options in the future
+	literalURLs := false
+	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
+	var pathPrefix string
+	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
+	serviceURL := sanitizeBaseURL(baseURL)
+	serviceURL += baseServicePath(pathPrefix, "sqlite.rpc.v0", "DatabaseService")
+	urls := [10]string{
+		serviceURL + "Exec",
+		serviceURL + "Query",
+		serviceURL + "Begin",
+		serviceURL + "Commit",
+		serviceURL + "Rollback",
+		serviceURL + "Batch",
+		serviceURL + "CloseCursor",
+		serviceURL + "Prepare",
+		serviceURL + "CloseStatement",
+		serviceURL + "ExecScript",
+	}
+
+	return &databaseServiceJSONClient{
+		client:      client,
+		urls:        urls,
+		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
+		opts:        clientOpts,
+	}
+}
+
+func (c *databaseServiceJSONClient) Exec(ctx context.Context, in *ExecRequest) (*ExecResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "Exec")
+	caller := c.callExec
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ExecRequest) (*ExecResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExecRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExecRequest) when calling interceptor")
+					}
+					return c.callExec(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExecResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExecResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceJSONClient) callExec(ctx context.Context, in *ExecRequest) (*ExecResponse, error) {
+	out := new(ExecResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceJSONClient) Query(ctx context.Context, in *QueryRequest) (*QueryResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "Query")
+	caller := c.callQuery
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*QueryRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*QueryRequest) when calling interceptor")
+					}
+					return c.callQuery(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*QueryResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*QueryResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceJSONClient) callQuery(ctx context.Context, in *QueryRequest) (*QueryResponse, error) {
+	out := new(QueryResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceJSONClient) Begin(ctx context.Context, in *BeginRequest) (*BeginResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "Begin")
+	caller := c.callBegin
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *BeginRequest) (*BeginResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BeginRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BeginRequest) when calling interceptor")
+					}
+					return c.callBegin(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BeginResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BeginResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceJSONClient) callBegin(ctx context.Context, in *BeginRequest) (*BeginResponse, error) {
+	out := new(BeginResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceJSONClient) Commit(ctx context.Context, in *CommitRequest) (*CommitResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "Commit")
+	caller := c.callCommit
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *CommitRequest) (*CommitResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CommitRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CommitRequest) when calling interceptor")
+					}
+					return c.callCommit(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CommitResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CommitResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceJSONClient) callCommit(ctx context.Context, in *CommitRequest) (*CommitResponse, error) {
+	out := new(CommitResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceJSONClient) Rollback(ctx context.Context, in *RollbackRequest) (*RollbackResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "Rollback")
+	caller := c.callRollback
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RollbackRequest) (*RollbackResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RollbackRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RollbackRequest) when calling interceptor")
+					}
+					return c.callRollback(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RollbackResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RollbackResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceJSONClient) callRollback(ctx context.Context, in *RollbackRequest) (*RollbackResponse, error) {
+	out := new(RollbackResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceJSONClient) Batch(ctx context.Context, in *BatchRequest) (*BatchResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "Batch")
+	caller := c.callBatch
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *BatchRequest) (*BatchResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BatchRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BatchRequest) when calling interceptor")
+					}
+					return c.callBatch(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BatchResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BatchResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceJSONClient) callBatch(ctx context.Context, in *BatchRequest) (*BatchResponse, error) {
+	out := new(BatchResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceJSONClient) CloseCursor(ctx context.Context, in *CloseCursorRequest) (*CloseCursorResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "CloseCursor")
+	caller := c.callCloseCursor
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *CloseCursorRequest) (*CloseCursorResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CloseCursorRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CloseCursorRequest) when calling interceptor")
+					}
+					return c.callCloseCursor(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CloseCursorResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CloseCursorResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceJSONClient) callCloseCursor(ctx context.Context, in *CloseCursorRequest) (*CloseCursorResponse, error) {
+	out := new(CloseCursorResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[6], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceJSONClient) Prepare(ctx context.Context, in *PrepareRequest) (*PrepareResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "Prepare")
+	caller := c.callPrepare
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *PrepareRequest) (*PrepareResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PrepareRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PrepareRequest) when calling interceptor")
+					}
+					return c.callPrepare(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*PrepareResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*PrepareResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceJSONClient) callPrepare(ctx context.Context, in *PrepareRequest) (*PrepareResponse, error) {
+	out := new(PrepareResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[7], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceJSONClient) CloseStatement(ctx context.Context, in *CloseStatementRequest) (*CloseStatementResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "CloseStatement")
+	caller := c.callCloseStatement
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *CloseStatementRequest) (*CloseStatementResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CloseStatementRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CloseStatementRequest) when calling interceptor")
+					}
+					return c.callCloseStatement(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CloseStatementResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CloseStatementResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceJSONClient) callCloseStatement(ctx context.Context, in *CloseStatementRequest) (*CloseStatementResponse, error) {
+	out := new(CloseStatementResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[8], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *databaseServiceJSONClient) ExecScript(ctx context.Context, in *ExecScriptRequest) (*ExecScriptResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithMethodName(ctx, "ExecScript")
+	caller := c.callExecScript
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ExecScriptRequest) (*ExecScriptResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExecScriptRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExecScriptRequest) when calling interceptor")
+					}
+					return c.callExecScript(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExecScriptResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExecScriptResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *databaseServiceJSONClient) callExecScript(ctx context.Context, in *ExecScriptRequest) (*ExecScriptResponse, error) {
+	out := new(ExecScriptResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[9], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// ==============================
+// DatabaseService Server Handler
+// ==============================
+
+type databaseServiceServer struct {
+	DatabaseService
+	interceptor      twirp.Interceptor
+	hooks            *twirp.ServerHooks
+	pathPrefix       string // prefix for routing
+	jsonSkipDefaults bool   // do not include unpopulated fields (default values) in the response
+	jsonCamelCase    bool   // JSON fields are serialized as lowerCamelCase rather than keeping the original proto names
+}
+
+// NewDatabaseServiceServer builds a TwirpServer that can be used as an http.Handler to handle
+// HTTP requests that are routed to the right method in the provided svc implementation.
+// The opts are twirp.ServerOption modifiers, for example twirp.WithServerHooks(hooks).
+func NewDatabaseServiceServer(svc DatabaseService, opts ...interface{}) TwirpServer {
+	serverOpts := newServerOpts(opts)
+
+	// Using ReadOpt allows backwards and forwads compatibility with new options in the future
+	jsonSkipDefaults := false
+	_ = serverOpts.ReadOpt("jsonSkipDefaults", &jsonSkipDefaults)
+	jsonCamelCase := false
+	_ = serverOpts.ReadOpt("jsonCamelCase", &jsonCamelCase)
+	var pathPrefix string
+	if ok := serverOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	return &databaseServiceServer{
+		DatabaseService:  svc,
+		hooks:            serverOpts.Hooks,
+		interceptor:      twirp.ChainInterceptors(serverOpts.Interceptors...),
+		pathPrefix:       pathPrefix,
+		jsonSkipDefaults: jsonSkipDefaults,
+		jsonCamelCase:    jsonCamelCase,
+	}
+}
+
+// writeError writes an HTTP response with a valid Twirp error format, and triggers hooks.
+// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
+func (s *databaseServiceServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
+	writeError(ctx, resp, err, s.hooks)
+}
+
+// handleRequestBodyError is used to handle error when the twirp server cannot read request
+func (s *databaseServiceServer) handleRequestBodyError(ctx context.Context, resp http.ResponseWriter, msg string, err error) {
+	if context.Canceled == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.Canceled, "failed to read request: context canceled"))
+		return
+	}
+	if context.DeadlineExceeded == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.DeadlineExceeded, "failed to read request: deadline exceeded"))
+		return
+	}
+	s.writeError(ctx, resp, twirp.WrapError(malformedRequestError(msg), err))
+}
+
+// DatabaseServicePathPrefix is a convenience constant that may identify URL paths.
+// Should be used with caution, it only matches routes generated by Twirp Go clients,
+// with the default "/twirp" prefix and default CamelCase service and method names.
+// More info: https://twitchtv.github.io/twirp/docs/routing.html
+const DatabaseServicePathPrefix = "/twirp/sqlite.rpc.v0.DatabaseService/"
+
+func (s *databaseServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithResponseWriter(ctx, resp)
+
+	var err error
+	ctx, err = callRequestReceived(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	if req.Method != "POST" {
+		msg := fmt.Sprintf("unsupported method %q (only POST is allowed)", req.Method)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	// Verify path format: [<prefix>]/<package>.<Service>/<Method>
+	prefix, pkgService, method := parseTwirpPath(req.URL.Path)
+	if pkgService != "sqlite.rpc.v0.DatabaseService" {
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+	if prefix != s.pathPrefix {
+		msg := fmt.Sprintf("invalid path prefix %q, expected %q, on path %q", prefix, s.pathPrefix, req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	switch method {
+	case "Exec":
+		s.serveExec(ctx, resp, req)
+		return
+	case "Query":
+		s.serveQuery(ctx, resp, req)
+		return
+	case "Begin":
+		s.serveBegin(ctx, resp, req)
+		return
+	case "Commit":
+		s.serveCommit(ctx, resp, req)
+		return
+	case "Rollback":
+		s.serveRollback(ctx, resp, req)
+		return
+	case "Batch":
+		s.serveBatch(ctx, resp, req)
+		return
+	case "CloseCursor":
+		s.serveCloseCursor(ctx, resp, req)
+		return
+	case "Prepare":
+		s.servePrepare(ctx, resp, req)
+		return
+	case "CloseStatement":
+		s.serveCloseStatement(ctx, resp, req)
+		return
+	case "ExecScript":
+		s.serveExecScript(ctx, resp, req)
+		return
+	default:
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+}
+
+func (s *databaseServiceServer) serveExec(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveExecJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveExecProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *databaseServiceServer) serveExecJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Exec")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ExecRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.DatabaseService.Exec
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ExecRequest) (*ExecResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExecRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExecRequest) when calling interceptor")
+					}
+					return s.DatabaseService.Exec(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExecResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExecResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ExecResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ExecResponse and nil error while calling Exec. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveExecProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Exec")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ExecRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.DatabaseService.Exec
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ExecRequest) (*ExecResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExecRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExecRequest) when calling interceptor")
+					}
+					return s.DatabaseService.Exec(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExecResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExecResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ExecResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ExecResponse and nil error while calling Exec. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveQuery(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveQueryJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveQueryProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *databaseServiceServer) serveQueryJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Query")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(QueryRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.DatabaseService.Query
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*QueryRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*QueryRequest) when calling interceptor")
+					}
+					return s.DatabaseService.Query(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*QueryResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*QueryResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *QueryResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *QueryResponse and nil error while calling Query. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveQueryProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Query")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(QueryRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.DatabaseService.Query
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*QueryRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*QueryRequest) when calling interceptor")
+					}
+					return s.DatabaseService.Query(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*QueryResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*QueryResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *QueryResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *QueryResponse and nil error while calling Query. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveBegin(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveBeginJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveBeginProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *databaseServiceServer) serveBeginJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Begin")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(BeginRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.DatabaseService.Begin
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *BeginRequest) (*BeginResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BeginRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BeginRequest) when calling interceptor")
+					}
+					return s.DatabaseService.Begin(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BeginResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BeginResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *BeginResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BeginResponse and nil error while calling Begin. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveBeginProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Begin")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(BeginRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.DatabaseService.Begin
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *BeginRequest) (*BeginResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BeginRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BeginRequest) when calling interceptor")
+					}
+					return s.DatabaseService.Begin(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BeginResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BeginResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *BeginResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BeginResponse and nil error while calling Begin. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveCommit(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveCommitJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveCommitProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *databaseServiceServer) serveCommitJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Commit")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(CommitRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.DatabaseService.Commit
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *CommitRequest) (*CommitResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CommitRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CommitRequest) when calling interceptor")
+					}
+					return s.DatabaseService.Commit(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CommitResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CommitResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *CommitResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *CommitResponse and nil error while calling Commit. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveCommitProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Commit")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(CommitRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.DatabaseService.Commit
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *CommitRequest) (*CommitResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CommitRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CommitRequest) when calling interceptor")
+					}
+					return s.DatabaseService.Commit(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CommitResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CommitResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *CommitResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *CommitResponse and nil error while calling Commit. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveRollback(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveRollbackJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveRollbackProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *databaseServiceServer) serveRollbackJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Rollback")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(RollbackRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.DatabaseService.Rollback
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *RollbackRequest) (*RollbackResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RollbackRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RollbackRequest) when calling interceptor")
+					}
+					return s.DatabaseService.Rollback(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RollbackResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RollbackResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *RollbackResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RollbackResponse and nil error while calling Rollback. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveRollbackProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Rollback")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(RollbackRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.DatabaseService.Rollback
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *RollbackRequest) (*RollbackResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RollbackRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RollbackRequest) when calling interceptor")
+					}
+					return s.DatabaseService.Rollback(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RollbackResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RollbackResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *RollbackResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RollbackResponse and nil error while calling Rollback. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveBatch(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveBatchJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveBatchProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *databaseServiceServer) serveBatchJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Batch")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(BatchRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.DatabaseService.Batch
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *BatchRequest) (*BatchResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BatchRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BatchRequest) when calling interceptor")
+					}
+					return s.DatabaseService.Batch(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BatchResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BatchResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *BatchResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BatchResponse and nil error while calling Batch. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveBatchProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Batch")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(BatchRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.DatabaseService.Batch
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *BatchRequest) (*BatchResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BatchRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BatchRequest) when calling interceptor")
+					}
+					return s.DatabaseService.Batch(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BatchResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BatchResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *BatchResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BatchResponse and nil error while calling Batch. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveCloseCursor(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveCloseCursorJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveCloseCursorProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *databaseServiceServer) serveCloseCursorJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "CloseCursor")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(CloseCursorRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.DatabaseService.CloseCursor
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *CloseCursorRequest) (*CloseCursorResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CloseCursorRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CloseCursorRequest) when calling interceptor")
+					}
+					return s.DatabaseService.CloseCursor(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CloseCursorResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CloseCursorResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *CloseCursorResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *CloseCursorResponse and nil error while calling CloseCursor. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveCloseCursorProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "CloseCursor")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(CloseCursorRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.DatabaseService.CloseCursor
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *CloseCursorRequest) (*CloseCursorResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CloseCursorRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CloseCursorRequest) when calling interceptor")
+					}
+					return s.DatabaseService.CloseCursor(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CloseCursorResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CloseCursorResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *CloseCursorResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *CloseCursorResponse and nil error while calling CloseCursor. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) servePrepare(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.servePrepareJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.servePrepareProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *databaseServiceServer) servePrepareJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Prepare")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(PrepareRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.DatabaseService.Prepare
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *PrepareRequest) (*PrepareResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PrepareRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PrepareRequest) when calling interceptor")
+					}
+					return s.DatabaseService.Prepare(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*PrepareResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*PrepareResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *PrepareResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *PrepareResponse and nil error while calling Prepare. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) servePrepareProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Prepare")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(PrepareRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.DatabaseService.Prepare
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *PrepareRequest) (*PrepareResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PrepareRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PrepareRequest) when calling interceptor")
+					}
+					return s.DatabaseService.Prepare(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*PrepareResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*PrepareResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *PrepareResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *PrepareResponse and nil error while calling Prepare. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveCloseStatement(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveCloseStatementJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveCloseStatementProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *databaseServiceServer) serveCloseStatementJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "CloseStatement")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(CloseStatementRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.DatabaseService.CloseStatement
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *CloseStatementRequest) (*CloseStatementResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CloseStatementRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CloseStatementRequest) when calling interceptor")
+					}
+					return s.DatabaseService.CloseStatement(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CloseStatementResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CloseStatementResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *CloseStatementResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *CloseStatementResponse and nil error while calling CloseStatement. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveCloseStatementProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "CloseStatement")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(CloseStatementRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.DatabaseService.CloseStatement
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *CloseStatementRequest) (*CloseStatementResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CloseStatementRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CloseStatementRequest) when calling interceptor")
+					}
+					return s.DatabaseService.CloseStatement(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CloseStatementResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CloseStatementResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *CloseStatementResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *CloseStatementResponse and nil error while calling CloseStatement. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveExecScript(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveExecScriptJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveExecScriptProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *databaseServiceServer) serveExecScriptJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ExecScript")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ExecScriptRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.DatabaseService.ExecScript
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ExecScriptRequest) (*ExecScriptResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExecScriptRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExecScriptRequest) when calling interceptor")
+					}
+					return s.DatabaseService.ExecScript(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExecScriptResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExecScriptResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ExecScriptResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ExecScriptResponse and nil error while calling ExecScript. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) serveExecScriptProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ExecScript")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ExecScriptRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.DatabaseService.ExecScript
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ExecScriptRequest) (*ExecScriptResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExecScriptRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExecScriptRequest) when calling interceptor")
+					}
+					return s.DatabaseService.ExecScript(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExecScriptResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExecScriptResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ExecScriptResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ExecScriptResponse and nil error while calling ExecScript. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *databaseServiceServer) ServiceDescriptor() ([]byte, int) {
+	return twirpFileDescriptor0, 0
+}
+
+func (s *databaseServiceServer) ProtocGenTwirpVersion() string {
+	return "v8.1.2"
+}
+
+// PathPrefix returns the base service path, in the form: "/<prefix>/<package>.<Service>/"
+// that is everything in a Twirp route except for the <Method>. This can be used for routing,
+// for example to identify the requests that are targeted to this service in a mux.
+func (s *databaseServiceServer) PathPrefix() string {
+	return baseServicePath(s.pathPrefix, "sqlite.rpc.v0", "DatabaseService")
+}
+
+// =======================
+// SchemaService Interface
+// =======================
+
+// `SchemaService` describes the schema of the database.
+type SchemaService interface {
+	ListTables(context.Context, *ListTablesRequest) (*ListTablesResponse, error)
+
+	DescribeTable(context.Context, *DescribeTableRequest) (*DescribeTableResponse, error)
+
+	ListIndexes(context.Context, *ListIndexesRequest) (*ListIndexesResponse, error)
+}
+
+// =============================
+// SchemaService Protobuf Client
+// =============================
+
+type schemaServiceProtobufClient struct {
+	client      HTTPClient
+	urls        [3]string
+	interceptor twirp.Interceptor
+	opts        twirp.ClientOptions
+}
+
+// NewSchemaServiceProtobufClient creates a Protobuf client that implements the SchemaService interface.
+// It communicates using Protobuf and can be configured with a custom HTTPClient.
+func NewSchemaServiceProtobufClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) SchemaService {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	// Using ReadOpt allows backwards and forwads compatibility with new options in the future
+	literalURLs := false
+	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
+	var pathPrefix string
+	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
+	serviceURL := sanitizeBaseURL(baseURL)
+	serviceURL += baseServicePath(pathPrefix, "sqlite.rpc.v0", "SchemaService")
+	urls := [3]string{
+		serviceURL + "ListTables",
+		serviceURL + "DescribeTable",
+		serviceURL + "ListIndexes",
+	}
+
+	return &schemaServiceProtobufClient{
+		client:      client,
+		urls:        urls,
+		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
+		opts:        clientOpts,
+	}
+}
+
+func (c *schemaServiceProtobufClient) ListTables(ctx context.Context, in *ListTablesRequest) (*ListTablesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "SchemaService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListTables")
+	caller := c.callListTables
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListTablesRequest) (*ListTablesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListTablesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListTablesRequest) when calling interceptor")
+					}
+					return c.callListTables(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListTablesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListTablesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *schemaServiceProtobufClient) callListTables(ctx context.Context, in *ListTablesRequest) (*ListTablesResponse, error) {
+	out := new(ListTablesResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *schemaServiceProtobufClient) DescribeTable(ctx context.Context, in *DescribeTableRequest) (*DescribeTableResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "SchemaService")
+	ctx = ctxsetters.WithMethodName(ctx, "DescribeTable")
+	caller := c.callDescribeTable
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DescribeTableRequest) (*DescribeTableResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DescribeTableRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DescribeTableRequest) when calling interceptor")
+					}
+					return c.callDescribeTable(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DescribeTableResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DescribeTableResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *schemaServiceProtobufClient) callDescribeTable(ctx context.Context, in *DescribeTableRequest) (*DescribeTableResponse, error) {
+	out := new(DescribeTableResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *schemaServiceProtobufClient) ListIndexes(ctx context.Context, in *ListIndexesRequest) (*ListIndexesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "SchemaService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListIndexes")
+	caller := c.callListIndexes
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListIndexesRequest) (*ListIndexesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListIndexesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListIndexesRequest) when calling interceptor")
+					}
+					return c.callListIndexes(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListIndexesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListIndexesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *schemaServiceProtobufClient) callListIndexes(ctx context.Context, in *ListIndexesRequest) (*ListIndexesResponse, error) {
+	out := new(ListIndexesResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// =========================
+// SchemaService JSON Client
+// =========================
+
+type schemaServiceJSONClient struct {
+	client      HTTPClient
+	urls        [3]string
+	interceptor twirp.Interceptor
+	opts        twirp.ClientOptions
+}
+
+// NewSchemaServiceJSONClient creates a JSON client that implements the SchemaService interface.
+// It communicates using JSON and can be configured with a custom HTTPClient.
+func NewSchemaServiceJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) SchemaService {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	// Using ReadOpt allows backwards and forwads compatibility with new options in the future
+	literalURLs := false
+	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
+	var pathPrefix string
+	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
+	serviceURL := sanitizeBaseURL(baseURL)
+	serviceURL += baseServicePath(pathPrefix, "sqlite.rpc.v0", "SchemaService")
+	urls := [3]string{
+		serviceURL + "ListTables",
+		serviceURL + "DescribeTable",
+		serviceURL + "ListIndexes",
+	}
+
+	return &schemaServiceJSONClient{
+		client:      client,
+		urls:        urls,
+		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
+		opts:        clientOpts,
+	}
+}
+
+func (c *schemaServiceJSONClient) ListTables(ctx context.Context, in *ListTablesRequest) (*ListTablesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "SchemaService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListTables")
+	caller := c.callListTables
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListTablesRequest) (*ListTablesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListTablesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListTablesRequest) when calling interceptor")
+					}
+					return c.callListTables(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListTablesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListTablesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *schemaServiceJSONClient) callListTables(ctx context.Context, in *ListTablesRequest) (*ListTablesResponse, error) {
+	out := new(ListTablesResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *schemaServiceJSONClient) DescribeTable(ctx context.Context, in *DescribeTableRequest) (*DescribeTableResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "SchemaService")
+	ctx = ctxsetters.WithMethodName(ctx, "DescribeTable")
+	caller := c.callDescribeTable
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DescribeTableRequest) (*DescribeTableResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DescribeTableRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DescribeTableRequest) when calling interceptor")
+					}
+					return c.callDescribeTable(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DescribeTableResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DescribeTableResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *schemaServiceJSONClient) callDescribeTable(ctx context.Context, in *DescribeTableRequest) (*DescribeTableResponse, error) {
+	out := new(DescribeTableResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *schemaServiceJSONClient) ListIndexes(ctx context.Context, in *ListIndexesRequest) (*ListIndexesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "SchemaService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListIndexes")
+	caller := c.callListIndexes
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListIndexesRequest) (*ListIndexesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListIndexesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListIndexesRequest) when calling interceptor")
+					}
+					return c.callListIndexes(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListIndexesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListIndexesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *schemaServiceJSONClient) callListIndexes(ctx context.Context, in *ListIndexesRequest) (*ListIndexesResponse, error) {
+	out := new(ListIndexesResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// ============================
+// SchemaService Server Handler
+// ============================
+
+type schemaServiceServer struct {
+	SchemaService
+	interceptor      twirp.Interceptor
+	hooks            *twirp.ServerHooks
+	pathPrefix       string // prefix for routing
+	jsonSkipDefaults bool   // do not include unpopulated fields (default values) in the response
+	jsonCamelCase    bool   // JSON fields are serialized as lowerCamelCase rather than keeping the original proto names
+}
+
+// NewSchemaServiceServer builds a TwirpServer that can be used as an http.Handler to handle
+// HTTP requests that are routed to the right method in the provided svc implementation.
+// The opts are twirp.ServerOption modifiers, for example twirp.WithServerHooks(hooks).
+func NewSchemaServiceServer(svc SchemaService, opts ...interface{}) TwirpServer {
+	serverOpts := newServerOpts(opts)
+
+	// Using ReadOpt allows backwards and forwads compatibility with new options in the future
+	jsonSkipDefaults := false
+	_ = serverOpts.ReadOpt("jsonSkipDefaults", &jsonSkipDefaults)
+	jsonCamelCase := false
+	_ = serverOpts.ReadOpt("jsonCamelCase", &jsonCamelCase)
+	var pathPrefix string
+	if ok := serverOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	return &schemaServiceServer{
+		SchemaService:    svc,
+		hooks:            serverOpts.Hooks,
+		interceptor:      twirp.ChainInterceptors(serverOpts.Interceptors...),
+		pathPrefix:       pathPrefix,
+		jsonSkipDefaults: jsonSkipDefaults,
+		jsonCamelCase:    jsonCamelCase,
+	}
+}
+
+// writeError writes an HTTP response with a valid Twirp error format, and triggers hooks.
+// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
+func (s *schemaServiceServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
+	writeError(ctx, resp, err, s.hooks)
+}
+
+// handleRequestBodyError is used to handle error when the twirp server cannot read request
+func (s *schemaServiceServer) handleRequestBodyError(ctx context.Context, resp http.ResponseWriter, msg string, err error) {
+	if context.Canceled == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.Canceled, "failed to read request: context canceled"))
+		return
+	}
+	if context.DeadlineExceeded == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.DeadlineExceeded, "failed to read request: deadline exceeded"))
+		return
+	}
+	s.writeError(ctx, resp, twirp.WrapError(malformedRequestError(msg), err))
+}
+
+// SchemaServicePathPrefix is a convenience constant that may identify URL paths.
+// Should be used with caution, it only matches routes generated by Twirp Go clients,
+// with the default "/twirp" prefix and default CamelCase service and method names.
+// More info: https://twitchtv.github.io/twirp/docs/routing.html
+const SchemaServicePathPrefix = "/twirp/sqlite.rpc.v0.SchemaService/"
+
+func (s *schemaServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
+	ctx = ctxsetters.WithServiceName(ctx, "SchemaService")
+	ctx = ctxsetters.WithResponseWriter(ctx, resp)
+
+	var err error
+	ctx, err = callRequestReceived(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	if req.Method != "POST" {
+		msg := fmt.Sprintf("unsupported method %q (only POST is allowed)", req.Method)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	// Verify path format: [<prefix>]/<package>.<Service>/<Method>
+	prefix, pkgService, method := parseTwirpPath(req.URL.Path)
+	if pkgService != "sqlite.rpc.v0.SchemaService" {
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+	if prefix != s.pathPrefix {
+		msg := fmt.Sprintf("invalid path prefix %q, expected %q, on path %q", prefix, s.pathPrefix, req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	switch method {
+	case "ListTables":
+		s.serveListTables(ctx, resp, req)
+		return
+	case "DescribeTable":
+		s.serveDescribeTable(ctx, resp, req)
+		return
+	case "ListIndexes":
+		s.serveListIndexes(ctx, resp, req)
+		return
+	default:
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+}
+
+func (s *schemaServiceServer) serveListTables(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListTablesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListTablesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *schemaServiceServer) serveListTablesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListTables")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListTablesRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.SchemaService.ListTables
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListTablesRequest) (*ListTablesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListTablesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListTablesRequest) when calling interceptor")
+					}
+					return s.SchemaService.ListTables(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListTablesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListTablesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListTablesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTablesResponse and nil error while calling ListTables. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *schemaServiceServer) serveListTablesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListTables")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListTablesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.SchemaService.ListTables
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListTablesRequest) (*ListTablesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListTablesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListTablesRequest) when calling interceptor")
+					}
+					return s.SchemaService.ListTables(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListTablesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListTablesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListTablesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTablesResponse and nil error while calling ListTables. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *schemaServiceServer) serveDescribeTable(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveDescribeTableJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveDescribeTableProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *schemaServiceServer) serveDescribeTableJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DescribeTable")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(DescribeTableRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.SchemaService.DescribeTable
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DescribeTableRequest) (*DescribeTableResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DescribeTableRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DescribeTableRequest) when calling interceptor")
+					}
+					return s.SchemaService.DescribeTable(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DescribeTableResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DescribeTableResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DescribeTableResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DescribeTableResponse and nil error while calling DescribeTable. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *schemaServiceServer) serveDescribeTableProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DescribeTable")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(DescribeTableRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.SchemaService.DescribeTable
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DescribeTableRequest) (*DescribeTableResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DescribeTableRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DescribeTableRequest) when calling interceptor")
+					}
+					return s.SchemaService.DescribeTable(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DescribeTableResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DescribeTableResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DescribeTableResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DescribeTableResponse and nil error while calling DescribeTable. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *schemaServiceServer) serveListIndexes(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListIndexesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListIndexesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *schemaServiceServer) serveListIndexesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListIndexes")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListIndexesRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.SchemaService.ListIndexes
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListIndexesRequest) (*ListIndexesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListIndexesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListIndexesRequest) when calling interceptor")
+					}
+					return s.SchemaService.ListIndexes(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListIndexesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListIndexesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListIndexesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListIndexesResponse and nil error while calling ListIndexes. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
 }
 
-// ===============================
-// DatabaseService Protobuf Client
-// ===============================
+func (s *schemaServiceServer) serveListIndexesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListIndexes")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
 
-type databaseServiceProtobufClient struct {
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListIndexesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.SchemaService.ListIndexes
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListIndexesRequest) (*ListIndexesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListIndexesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListIndexesRequest) when calling interceptor")
+					}
+					return s.SchemaService.ListIndexes(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListIndexesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListIndexesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListIndexesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListIndexesResponse and nil error while calling ListIndexes. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *schemaServiceServer) ServiceDescriptor() ([]byte, int) {
+	return twirpFileDescriptor0, 1
+}
+
+func (s *schemaServiceServer) ProtocGenTwirpVersion() string {
+	return "v8.1.2"
+}
+
+// PathPrefix returns the base service path, in the form: "/<prefix>/<package>.<Service>/"
+// that is everything in a Twirp route except for the <Method>. This can be used for routing,
+// for example to identify the requests that are targeted to this service in a mux.
+func (s *schemaServiceServer) PathPrefix() string {
+	return baseServicePath(s.pathPrefix, "sqlite.rpc.v0", "SchemaService")
+}
+
+// ======================
+// AdminService Interface
+// ======================
+
+// `AdminService` manages the database as a whole.
+type AdminService interface {
+	Backup(context.Context, *BackupRequest) (*BackupResponse, error)
+
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
+}
+
+// ============================
+// AdminService Protobuf Client
+// ============================
+
+type adminServiceProtobufClient struct {
 	client      HTTPClient
 	urls        [2]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
 
-// NewDatabaseServiceProtobufClient creates a Protobuf client that implements the DatabaseService interface.
+// NewAdminServiceProtobufClient creates a Protobuf client that implements the AdminService interface.
 // It communicates using Protobuf and can be configured with a custom HTTPClient.
-func NewDatabaseServiceProtobufClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) DatabaseService {
+func NewAdminServiceProtobufClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) AdminService {
 	if c, ok := client.(*http.Client); ok {
 		client = withoutRedirects(c)
 	}
@@ -71,13 +4132,13 @@ func NewDatabaseServiceProtobufClient(baseURL string, client HTTPClient, opts ..
 
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
-	serviceURL += baseServicePath(pathPrefix, "sqlite.rpc.v0", "DatabaseService")
+	serviceURL += baseServicePath(pathPrefix, "sqlite.rpc.v0", "AdminService")
 	urls := [2]string{
-		serviceURL + "Exec",
-		serviceURL + "Query",
+		serviceURL + "Backup",
+		serviceURL + "Restore",
 	}
 
-	return &databaseServiceProtobufClient{
+	return &adminServiceProtobufClient{
 		client:      client,
 		urls:        urls,
 		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
@@ -85,26 +4146,26 @@ func NewDatabaseServiceProtobufClient(baseURL string, client HTTPClient, opts ..
 	}
 }
 
-func (c *databaseServiceProtobufClient) Exec(ctx context.Context, in *ExecRequest) (*ExecResponse, error) {
+func (c *adminServiceProtobufClient) Backup(ctx context.Context, in *BackupRequest) (*BackupResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
-	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
-	ctx = ctxsetters.WithMethodName(ctx, "Exec")
-	caller := c.callExec
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "Backup")
+	caller := c.callBackup
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *ExecRequest) (*ExecResponse, error) {
+		caller = func(ctx context.Context, req *BackupRequest) (*BackupResponse, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*ExecRequest)
+					typedReq, ok := req.(*BackupRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*ExecRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*BackupRequest) when calling interceptor")
 					}
-					return c.callExec(ctx, typedReq)
+					return c.callBackup(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*ExecResponse)
+				typedResp, ok := resp.(*BackupResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*ExecResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*BackupResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -114,8 +4175,8 @@ func (c *databaseServiceProtobufClient) Exec(ctx context.Context, in *ExecReques
 	return caller(ctx, in)
 }
 
-func (c *databaseServiceProtobufClient) callExec(ctx context.Context, in *ExecRequest) (*ExecResponse, error) {
-	out := new(ExecResponse)
+func (c *adminServiceProtobufClient) callBackup(ctx context.Context, in *BackupRequest) (*BackupResponse, error) {
+	out := new(BackupResponse)
 	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
@@ -131,26 +4192,26 @@ func (c *databaseServiceProtobufClient) callExec(ctx context.Context, in *ExecRe
 	return out, nil
 }
 
-func (c *databaseServiceProtobufClient) Query(ctx context.Context, in *QueryRequest) (*QueryResponse, error) {
+func (c *adminServiceProtobufClient) Restore(ctx context.Context, in *RestoreRequest) (*RestoreResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
-	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
-	ctx = ctxsetters.WithMethodName(ctx, "Query")
-	caller := c.callQuery
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "Restore")
+	caller := c.callRestore
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
+		caller = func(ctx context.Context, req *RestoreRequest) (*RestoreResponse, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*QueryRequest)
+					typedReq, ok := req.(*RestoreRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*QueryRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*RestoreRequest) when calling interceptor")
 					}
-					return c.callQuery(ctx, typedReq)
+					return c.callRestore(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*QueryResponse)
+				typedResp, ok := resp.(*RestoreResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*QueryResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*RestoreResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -160,8 +4221,8 @@ func (c *databaseServiceProtobufClient) Query(ctx context.Context, in *QueryRequ
 	return caller(ctx, in)
 }
 
-func (c *databaseServiceProtobufClient) callQuery(ctx context.Context, in *QueryRequest) (*QueryResponse, error) {
-	out := new(QueryResponse)
+func (c *adminServiceProtobufClient) callRestore(ctx context.Context, in *RestoreRequest) (*RestoreResponse, error) {
+	out := new(RestoreResponse)
 	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
@@ -177,20 +4238,20 @@ func (c *databaseServiceProtobufClient) callQuery(ctx context.Context, in *Query
 	return out, nil
 }
 
-// ===========================
-// DatabaseService JSON Client
-// ===========================
+// ========================
+// AdminService JSON Client
+// ========================
 
-type databaseServiceJSONClient struct {
+type adminServiceJSONClient struct {
 	client      HTTPClient
 	urls        [2]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
 
-// NewDatabaseServiceJSONClient creates a JSON client that implements the DatabaseService interface.
+// NewAdminServiceJSONClient creates a JSON client that implements the AdminService interface.
 // It communicates using JSON and can be configured with a custom HTTPClient.
-func NewDatabaseServiceJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) DatabaseService {
+func NewAdminServiceJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) AdminService {
 	if c, ok := client.(*http.Client); ok {
 		client = withoutRedirects(c)
 	}
@@ -210,13 +4271,13 @@ func NewDatabaseServiceJSONClient(baseURL string, client HTTPClient, opts ...twi
 
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
-	serviceURL += baseServicePath(pathPrefix, "sqlite.rpc.v0", "DatabaseService")
+	serviceURL += baseServicePath(pathPrefix, "sqlite.rpc.v0", "AdminService")
 	urls := [2]string{
-		serviceURL + "Exec",
-		serviceURL + "Query",
+		serviceURL + "Backup",
+		serviceURL + "Restore",
 	}
 
-	return &databaseServiceJSONClient{
+	return &adminServiceJSONClient{
 		client:      client,
 		urls:        urls,
 		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
@@ -224,26 +4285,26 @@ func NewDatabaseServiceJSONClient(baseURL string, client HTTPClient, opts ...twi
 	}
 }
 
-func (c *databaseServiceJSONClient) Exec(ctx context.Context, in *ExecRequest) (*ExecResponse, error) {
+func (c *adminServiceJSONClient) Backup(ctx context.Context, in *BackupRequest) (*BackupResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
-	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
-	ctx = ctxsetters.WithMethodName(ctx, "Exec")
-	caller := c.callExec
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "Backup")
+	caller := c.callBackup
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *ExecRequest) (*ExecResponse, error) {
+		caller = func(ctx context.Context, req *BackupRequest) (*BackupResponse, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*ExecRequest)
+					typedReq, ok := req.(*BackupRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*ExecRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*BackupRequest) when calling interceptor")
 					}
-					return c.callExec(ctx, typedReq)
+					return c.callBackup(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*ExecResponse)
+				typedResp, ok := resp.(*BackupResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*ExecResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*BackupResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -253,8 +4314,8 @@ func (c *databaseServiceJSONClient) Exec(ctx context.Context, in *ExecRequest) (
 	return caller(ctx, in)
 }
 
-func (c *databaseServiceJSONClient) callExec(ctx context.Context, in *ExecRequest) (*ExecResponse, error) {
-	out := new(ExecResponse)
+func (c *adminServiceJSONClient) callBackup(ctx context.Context, in *BackupRequest) (*BackupResponse, error) {
+	out := new(BackupResponse)
 	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
@@ -270,26 +4331,26 @@ func (c *databaseServiceJSONClient) callExec(ctx context.Context, in *ExecReques
 	return out, nil
 }
 
-func (c *databaseServiceJSONClient) Query(ctx context.Context, in *QueryRequest) (*QueryResponse, error) {
+func (c *adminServiceJSONClient) Restore(ctx context.Context, in *RestoreRequest) (*RestoreResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
-	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
-	ctx = ctxsetters.WithMethodName(ctx, "Query")
-	caller := c.callQuery
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "Restore")
+	caller := c.callRestore
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
+		caller = func(ctx context.Context, req *RestoreRequest) (*RestoreResponse, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*QueryRequest)
+					typedReq, ok := req.(*RestoreRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*QueryRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*RestoreRequest) when calling interceptor")
 					}
-					return c.callQuery(ctx, typedReq)
+					return c.callRestore(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*QueryResponse)
+				typedResp, ok := resp.(*RestoreResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*QueryResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*RestoreResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -299,8 +4360,8 @@ func (c *databaseServiceJSONClient) Query(ctx context.Context, in *QueryRequest)
 	return caller(ctx, in)
 }
 
-func (c *databaseServiceJSONClient) callQuery(ctx context.Context, in *QueryRequest) (*QueryResponse, error) {
-	out := new(QueryResponse)
+func (c *adminServiceJSONClient) callRestore(ctx context.Context, in *RestoreRequest) (*RestoreResponse, error) {
+	out := new(RestoreResponse)
 	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
@@ -316,12 +4377,12 @@ func (c *databaseServiceJSONClient) callQuery(ctx context.Context, in *QueryRequ
 	return out, nil
 }
 
-// ==============================
-// DatabaseService Server Handler
-// ==============================
+// ===========================
+// AdminService Server Handler
+// ===========================
 
-type databaseServiceServer struct {
-	DatabaseService
+type adminServiceServer struct {
+	AdminService
 	interceptor      twirp.Interceptor
 	hooks            *twirp.ServerHooks
 	pathPrefix       string // prefix for routing
@@ -329,10 +4390,10 @@ type databaseServiceServer struct {
 	jsonCamelCase    bool   // JSON fields are serialized as lowerCamelCase rather than keeping the original proto names
 }
 
-// NewDatabaseServiceServer builds a TwirpServer that can be used as an http.Handler to handle
+// NewAdminServiceServer builds a TwirpServer that can be used as an http.Handler to handle
 // HTTP requests that are routed to the right method in the provided svc implementation.
 // The opts are twirp.ServerOption modifiers, for example twirp.WithServerHooks(hooks).
-func NewDatabaseServiceServer(svc DatabaseService, opts ...interface{}) TwirpServer {
+func NewAdminServiceServer(svc AdminService, opts ...interface{}) TwirpServer {
 	serverOpts := newServerOpts(opts)
 
 	// Using ReadOpt allows backwards and forwads compatibility with new options in the future
@@ -345,8 +4406,8 @@ func NewDatabaseServiceServer(svc DatabaseService, opts ...interface{}) TwirpSer
 		pathPrefix = "/twirp" // default prefix
 	}
 
-	return &databaseServiceServer{
-		DatabaseService:  svc,
+	return &adminServiceServer{
+		AdminService:     svc,
 		hooks:            serverOpts.Hooks,
 		interceptor:      twirp.ChainInterceptors(serverOpts.Interceptors...),
 		pathPrefix:       pathPrefix,
@@ -357,12 +4418,12 @@ func NewDatabaseServiceServer(svc DatabaseService, opts ...interface{}) TwirpSer
 
 // writeError writes an HTTP response with a valid Twirp error format, and triggers hooks.
 // If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
-func (s *databaseServiceServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
+func (s *adminServiceServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
 	writeError(ctx, resp, err, s.hooks)
 }
 
 // handleRequestBodyError is used to handle error when the twirp server cannot read request
-func (s *databaseServiceServer) handleRequestBodyError(ctx context.Context, resp http.ResponseWriter, msg string, err error) {
+func (s *adminServiceServer) handleRequestBodyError(ctx context.Context, resp http.ResponseWriter, msg string, err error) {
 	if context.Canceled == ctx.Err() {
 		s.writeError(ctx, resp, twirp.NewError(twirp.Canceled, "failed to read request: context canceled"))
 		return
@@ -374,16 +4435,16 @@ func (s *databaseServiceServer) handleRequestBodyError(ctx context.Context, resp
 	s.writeError(ctx, resp, twirp.WrapError(malformedRequestError(msg), err))
 }
 
-// DatabaseServicePathPrefix is a convenience constant that may identify URL paths.
+// AdminServicePathPrefix is a convenience constant that may identify URL paths.
 // Should be used with caution, it only matches routes generated by Twirp Go clients,
 // with the default "/twirp" prefix and default CamelCase service and method names.
 // More info: https://twitchtv.github.io/twirp/docs/routing.html
-const DatabaseServicePathPrefix = "/twirp/sqlite.rpc.v0.DatabaseService/"
+const AdminServicePathPrefix = "/twirp/sqlite.rpc.v0.AdminService/"
 
-func (s *databaseServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+func (s *adminServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	ctx := req.Context()
 	ctx = ctxsetters.WithPackageName(ctx, "sqlite.rpc.v0")
-	ctx = ctxsetters.WithServiceName(ctx, "DatabaseService")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
 	ctx = ctxsetters.WithResponseWriter(ctx, resp)
 
 	var err error
@@ -401,7 +4462,7 @@ func (s *databaseServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Re
 
 	// Verify path format: [<prefix>]/<package>.<Service>/<Method>
 	prefix, pkgService, method := parseTwirpPath(req.URL.Path)
-	if pkgService != "sqlite.rpc.v0.DatabaseService" {
+	if pkgService != "sqlite.rpc.v0.AdminService" {
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
 		return
@@ -413,11 +4474,11 @@ func (s *databaseServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Re
 	}
 
 	switch method {
-	case "Exec":
-		s.serveExec(ctx, resp, req)
+	case "Backup":
+		s.serveBackup(ctx, resp, req)
 		return
-	case "Query":
-		s.serveQuery(ctx, resp, req)
+	case "Restore":
+		s.serveRestore(ctx, resp, req)
 		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
@@ -426,7 +4487,7 @@ func (s *databaseServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Re
 	}
 }
 
-func (s *databaseServiceServer) serveExec(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *adminServiceServer) serveBackup(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -434,9 +4495,9 @@ func (s *databaseServiceServer) serveExec(ctx context.Context, resp http.Respons
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveExecJSON(ctx, resp, req)
+		s.serveBackupJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveExecProtobuf(ctx, resp, req)
+		s.serveBackupProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -444,9 +4505,9 @@ func (s *databaseServiceServer) serveExec(ctx context.Context, resp http.Respons
 	}
 }
 
-func (s *databaseServiceServer) serveExecJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *adminServiceServer) serveBackupJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Exec")
+	ctx = ctxsetters.WithMethodName(ctx, "Backup")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -459,29 +4520,29 @@ func (s *databaseServiceServer) serveExecJSON(ctx context.Context, resp http.Res
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(ExecRequest)
+	reqContent := new(BackupRequest)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
 
-	handler := s.DatabaseService.Exec
+	handler := s.AdminService.Backup
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *ExecRequest) (*ExecResponse, error) {
+		handler = func(ctx context.Context, req *BackupRequest) (*BackupResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*ExecRequest)
+					typedReq, ok := req.(*BackupRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*ExecRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*BackupRequest) when calling interceptor")
 					}
-					return s.DatabaseService.Exec(ctx, typedReq)
+					return s.AdminService.Backup(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*ExecResponse)
+				typedResp, ok := resp.(*BackupResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*ExecResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*BackupResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -490,7 +4551,7 @@ func (s *databaseServiceServer) serveExecJSON(ctx context.Context, resp http.Res
 	}
 
 	// Call service method
-	var respContent *ExecResponse
+	var respContent *BackupResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -501,7 +4562,7 @@ func (s *databaseServiceServer) serveExecJSON(ctx context.Context, resp http.Res
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *ExecResponse and nil error while calling Exec. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BackupResponse and nil error while calling Backup. nil responses are not supported"))
 		return
 	}
 
@@ -527,9 +4588,9 @@ func (s *databaseServiceServer) serveExecJSON(ctx context.Context, resp http.Res
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *databaseServiceServer) serveExecProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *adminServiceServer) serveBackupProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Exec")
+	ctx = ctxsetters.WithMethodName(ctx, "Backup")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -541,28 +4602,28 @@ func (s *databaseServiceServer) serveExecProtobuf(ctx context.Context, resp http
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(ExecRequest)
+	reqContent := new(BackupRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
-	handler := s.DatabaseService.Exec
+	handler := s.AdminService.Backup
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *ExecRequest) (*ExecResponse, error) {
+		handler = func(ctx context.Context, req *BackupRequest) (*BackupResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*ExecRequest)
+					typedReq, ok := req.(*BackupRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*ExecRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*BackupRequest) when calling interceptor")
 					}
-					return s.DatabaseService.Exec(ctx, typedReq)
+					return s.AdminService.Backup(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*ExecResponse)
+				typedResp, ok := resp.(*BackupResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*ExecResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*BackupResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -571,7 +4632,7 @@ func (s *databaseServiceServer) serveExecProtobuf(ctx context.Context, resp http
 	}
 
 	// Call service method
-	var respContent *ExecResponse
+	var respContent *BackupResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -582,7 +4643,7 @@ func (s *databaseServiceServer) serveExecProtobuf(ctx context.Context, resp http
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *ExecResponse and nil error while calling Exec. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BackupResponse and nil error while calling Backup. nil responses are not supported"))
 		return
 	}
 
@@ -606,7 +4667,7 @@ func (s *databaseServiceServer) serveExecProtobuf(ctx context.Context, resp http
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *databaseServiceServer) serveQuery(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *adminServiceServer) serveRestore(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -614,9 +4675,9 @@ func (s *databaseServiceServer) serveQuery(ctx context.Context, resp http.Respon
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveQueryJSON(ctx, resp, req)
+		s.serveRestoreJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveQueryProtobuf(ctx, resp, req)
+		s.serveRestoreProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -624,9 +4685,9 @@ func (s *databaseServiceServer) serveQuery(ctx context.Context, resp http.Respon
 	}
 }
 
-func (s *databaseServiceServer) serveQueryJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *adminServiceServer) serveRestoreJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Query")
+	ctx = ctxsetters.WithMethodName(ctx, "Restore")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -639,29 +4700,29 @@ func (s *databaseServiceServer) serveQueryJSON(ctx context.Context, resp http.Re
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(QueryRequest)
+	reqContent := new(RestoreRequest)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
 
-	handler := s.DatabaseService.Query
+	handler := s.AdminService.Restore
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
+		handler = func(ctx context.Context, req *RestoreRequest) (*RestoreResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*QueryRequest)
+					typedReq, ok := req.(*RestoreRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*QueryRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*RestoreRequest) when calling interceptor")
 					}
-					return s.DatabaseService.Query(ctx, typedReq)
+					return s.AdminService.Restore(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*QueryResponse)
+				typedResp, ok := resp.(*RestoreResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*QueryResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*RestoreResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -670,7 +4731,7 @@ func (s *databaseServiceServer) serveQueryJSON(ctx context.Context, resp http.Re
 	}
 
 	// Call service method
-	var respContent *QueryResponse
+	var respContent *RestoreResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -681,7 +4742,7 @@ func (s *databaseServiceServer) serveQueryJSON(ctx context.Context, resp http.Re
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *QueryResponse and nil error while calling Query. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RestoreResponse and nil error while calling Restore. nil responses are not supported"))
 		return
 	}
 
@@ -707,9 +4768,9 @@ func (s *databaseServiceServer) serveQueryJSON(ctx context.Context, resp http.Re
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *databaseServiceServer) serveQueryProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *adminServiceServer) serveRestoreProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Query")
+	ctx = ctxsetters.WithMethodName(ctx, "Restore")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -721,28 +4782,28 @@ func (s *databaseServiceServer) serveQueryProtobuf(ctx context.Context, resp htt
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(QueryRequest)
+	reqContent := new(RestoreRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
-	handler := s.DatabaseService.Query
+	handler := s.AdminService.Restore
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
+		handler = func(ctx context.Context, req *RestoreRequest) (*RestoreResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*QueryRequest)
+					typedReq, ok := req.(*RestoreRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*QueryRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*RestoreRequest) when calling interceptor")
 					}
-					return s.DatabaseService.Query(ctx, typedReq)
+					return s.AdminService.Restore(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*QueryResponse)
+				typedResp, ok := resp.(*RestoreResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*QueryResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*RestoreResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -751,7 +4812,7 @@ func (s *databaseServiceServer) serveQueryProtobuf(ctx context.Context, resp htt
 	}
 
 	// Call service method
-	var respContent *QueryResponse
+	var respContent *RestoreResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -762,7 +4823,7 @@ func (s *databaseServiceServer) serveQueryProtobuf(ctx context.Context, resp htt
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *QueryResponse and nil error while calling Query. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RestoreResponse and nil error while calling Restore. nil responses are not supported"))
 		return
 	}
 
@@ -786,19 +4847,19 @@ func (s *databaseServiceServer) serveQueryProtobuf(ctx context.Context, resp htt
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *databaseServiceServer) ServiceDescriptor() ([]byte, int) {
-	return twirpFileDescriptor0, 0
+func (s *adminServiceServer) ServiceDescriptor() ([]byte, int) {
+	return twirpFileDescriptor0, 2
 }
 
-func (s *databaseServiceServer) ProtocGenTwirpVersion() string {
+func (s *adminServiceServer) ProtocGenTwirpVersion() string {
 	return "v8.1.2"
 }
 
 // PathPrefix returns the base service path, in the form: "/<prefix>/<package>.<Service>/"
 // that is everything in a Twirp route except for the <Method>. This can be used for routing,
 // for example to identify the requests that are targeted to this service in a mux.
-func (s *databaseServiceServer) PathPrefix() string {
-	return baseServicePath(s.pathPrefix, "sqlite.rpc.v0", "DatabaseService")
+func (s *adminServiceServer) PathPrefix() string {
+	return baseServicePath(s.pathPrefix, "sqlite.rpc.v0", "AdminService")
 }
 
 // =====
@@ -1364,54 +5425,181 @@ func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error)
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 771 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0xd1, 0x4e, 0xdb, 0x4a,
-	0x10, 0x86, 0x09, 0x71, 0x42, 0x32, 0x71, 0x38, 0x3e, 0x2b, 0xd0, 0xc9, 0x09, 0x54, 0x45, 0x6e,
-	0x2f, 0x50, 0x8b, 0x12, 0x14, 0x2a, 0x55, 0xb4, 0x17, 0x15, 0x09, 0x6e, 0x6b, 0x29, 0x24, 0xd4,
-	0x24, 0xa8, 0xf4, 0x26, 0xb2, 0x9d, 0x25, 0xb5, 0xb0, 0xbd, 0xc6, 0x5e, 0x53, 0x78, 0x91, 0xbe,
-	0x54, 0xdf, 0xa8, 0x57, 0x95, 0xd7, 0x6b, 0x27, 0xb6, 0x22, 0x22, 0xa4, 0xde, 0x79, 0x66, 0xff,
-	0x6f, 0x66, 0xbc, 0xff, 0xee, 0x82, 0x18, 0xdc, 0xda, 0x16, 0xc5, 0x2d, 0xcf, 0x27, 0x94, 0xa0,
-	0x3a, 0x8f, 0x7c, 0xcf, 0x6c, 0xdd, 0x1d, 0x36, 0x9f, 0xcf, 0x08, 0x99, 0xd9, 0xb8, 0xcd, 0x16,
-	0x8d, 0xf0, 0xba, 0x4d, 0x2d, 0x07, 0x07, 0x54, 0x77, 0xbc, 0x58, 0x2f, 0x1f, 0x81, 0x30, 0x7a,
-	0xf0, 0x30, 0x7a, 0x0d, 0x82, 0x49, 0xa6, 0xb8, 0x51, 0xd8, 0x2b, 0xec, 0x6f, 0x76, 0xfe, 0x6b,
-	0x65, 0xca, 0xb4, 0x22, 0x49, 0x8f, 0x4c, 0xb1, 0xc6, 0x44, 0xf2, 0xef, 0x22, 0x94, 0x2e, 0x75,
-	0x3b, 0xc4, 0xa8, 0x07, 0x75, 0xcb, 0xa5, 0x78, 0x86, 0xfd, 0xc9, 0x5d, 0x94, 0x60, 0x7c, 0xad,
-	0xb3, 0x9b, 0xe3, 0x55, 0x97, 0x62, 0x7f, 0x86, 0x7d, 0x06, 0x7d, 0x5e, 0xd3, 0x44, 0x0e, 0xc5,
-	0x45, 0x8e, 0x01, 0x28, 0xbe, 0xa7, 0xbc, 0xc2, 0x3a, 0xab, 0xd0, 0xc8, 0x4f, 0x80, 0xef, 0x69,
-	0x42, 0x57, 0x69, 0x12, 0x44, 0xa8, 0x61, 0x13, 0x83, 0xa3, 0xc5, 0xa5, 0x68, 0xd7, 0x26, 0x46,
-	0x8a, 0x1a, 0x49, 0x10, 0xa1, 0x3e, 0xd6, 0x6d, 0x8e, 0x0a, 0x4b, 0x51, 0x0d, 0xeb, 0x76, 0x8a,
-	0xfa, 0x49, 0x80, 0xba, 0x50, 0x77, 0x43, 0x07, 0xfb, 0x96, 0xc9, 0xe9, 0x12, 0xa3, 0x77, 0x72,
-	0xf4, 0x20, 0xd6, 0xa4, 0x3f, 0xed, 0x2e, 0xc4, 0x6c, 0x72, 0x42, 0x92, 0xf6, 0xe5, 0xe5, 0x93,
-	0x13, 0x32, 0x6f, 0x6f, 0x24, 0x01, 0xdb, 0x2f, 0xcb, 0xc1, 0x1c, 0xdd, 0x58, 0xbe, 0x5f, 0x96,
-	0x83, 0xe7, 0xfb, 0x95, 0x04, 0x11, 0xea, 0x86, 0x76, 0xd2, 0xb5, 0xb2, 0x14, 0x1d, 0x84, 0xf6,
-	0xbc, 0xab, 0x9b, 0x04, 0xdd, 0x32, 0x08, 0x37, 0x96, 0x3b, 0x95, 0xdf, 0x43, 0x3d, 0x63, 0x27,
-	0xda, 0x82, 0xd2, 0xdc, 0xfb, 0xa2, 0x16, 0x07, 0x3c, 0x6b, 0x4d, 0x99, 0x9f, 0x15, 0x2d, 0x0e,
-	0xe4, 0xb7, 0x50, 0x4d, 0x9d, 0xcc, 0x82, 0xd5, 0x95, 0x60, 0xea, 0x63, 0x16, 0x14, 0x57, 0x82,
-	0xa9, 0x8b, 0x59, 0xb0, 0xf0, 0x38, 0xf8, 0x0e, 0xc4, 0x45, 0x03, 0x9f, 0xc4, 0x46, 0xd3, 0xa6,
-	0x76, 0x65, 0xc0, 0xca, 0xe3, 0xe0, 0x05, 0x54, 0x53, 0xe7, 0xd0, 0xe1, 0x22, 0x58, 0xeb, 0x34,
-	0x5b, 0xf1, 0x65, 0x6e, 0x25, 0x97, 0x99, 0x99, 0xcc, 0x2e, 0xf3, 0xca, 0x69, 0x52, 0x4f, 0x9f,
-	0x34, 0xcd, 0x31, 0x54, 0xfb, 0x56, 0xc0, 0xdd, 0x3a, 0x80, 0x32, 0xd3, 0x06, 0x8d, 0xc2, 0x5e,
-	0x71, 0xbf, 0xd6, 0xd9, 0xca, 0x1d, 0x1b, 0xa6, 0xd2, 0xb8, 0x46, 0x1e, 0x43, 0x4d, 0xb9, 0xc7,
-	0xa6, 0x86, 0x6f, 0x43, 0x1c, 0x50, 0x24, 0x41, 0x31, 0xb8, 0xb5, 0xb9, 0xd1, 0xd1, 0x27, 0x7a,
-	0x03, 0xe0, 0xe9, 0xbe, 0xee, 0x60, 0x8a, 0xfd, 0xa0, 0xb1, 0xfe, 0x48, 0xc9, 0x05, 0x9d, 0x7c,
-	0x05, 0x62, 0x5c, 0x36, 0xf0, 0x88, 0x1b, 0x60, 0xf4, 0x12, 0x36, 0x6d, 0x3d, 0xa0, 0x13, 0xcb,
-	0x0d, 0xb0, 0x4f, 0x27, 0xd6, 0x94, 0x1f, 0x42, 0x31, 0xca, 0xaa, 0x2c, 0xa9, 0x4e, 0xd1, 0x0b,
-	0xa8, 0xfb, 0xe4, 0x47, 0x30, 0xd1, 0xaf, 0xaf, 0xb1, 0x49, 0x71, 0xfc, 0x97, 0x45, 0x4d, 0x8c,
-	0x92, 0x27, 0x3c, 0x27, 0x5f, 0x82, 0xf8, 0x25, 0xc4, 0xfe, 0xc3, 0xdf, 0x1e, 0xd9, 0x85, 0x3a,
-	0xaf, 0xcb, 0x67, 0x6e, 0xc3, 0x86, 0x49, 0xec, 0xd0, 0x71, 0x93, 0x9d, 0xdc, 0xce, 0xd5, 0xe8,
-	0xb1, 0x55, 0x2d, 0x51, 0xa1, 0x03, 0x10, 0xa2, 0x49, 0x79, 0xc7, 0xfc, 0x75, 0x4d, 0x1d, 0xd2,
-	0x98, 0x4a, 0x56, 0xa1, 0x1c, 0x17, 0x88, 0xde, 0x74, 0xfa, 0xe0, 0xad, 0x7e, 0xd3, 0x23, 0x11,
-	0x42, 0x20, 0xb8, 0xba, 0x13, 0x3f, 0xbf, 0x55, 0x8d, 0x7d, 0xbf, 0xfa, 0x55, 0x80, 0x4a, 0x22,
-	0x43, 0xff, 0xc3, 0xf6, 0xe8, 0xea, 0x5c, 0x99, 0xf4, 0x86, 0xa7, 0xca, 0x64, 0x3c, 0xb8, 0x38,
-	0x57, 0x7a, 0xea, 0x47, 0x55, 0x39, 0x95, 0xd6, 0xd0, 0x36, 0xfc, 0x3b, 0x5f, 0x52, 0x07, 0x23,
-	0xe5, 0x93, 0xa2, 0x49, 0x05, 0x84, 0x60, 0x73, 0x9e, 0x1e, 0x29, 0x5f, 0x47, 0xd2, 0x7a, 0x36,
-	0xd7, 0xed, 0x0f, 0xbb, 0x52, 0x31, 0x9b, 0xd3, 0x94, 0x93, 0xbe, 0x24, 0x64, 0x4b, 0x0e, 0xc6,
-	0x67, 0x8a, 0xa6, 0xf6, 0xa4, 0x52, 0x0e, 0x1f, 0x0e, 0xfb, 0x52, 0x39, 0xd7, 0x46, 0x3d, 0x53,
-	0xa4, 0x8d, 0x6c, 0x6e, 0x30, 0xee, 0xf7, 0xa5, 0x4a, 0xe7, 0x67, 0x01, 0xfe, 0x39, 0xd5, 0xa9,
-	0x6e, 0xe8, 0x01, 0xbe, 0xc0, 0xfe, 0x9d, 0x65, 0x62, 0xf4, 0x01, 0x84, 0xe8, 0x3c, 0xa1, 0x66,
-	0x6e, 0x73, 0x16, 0xce, 0x6e, 0x73, 0x67, 0xe9, 0x1a, 0x37, 0xb3, 0x0b, 0x25, 0xe6, 0x2e, 0xca,
-	0xab, 0x16, 0xcf, 0x52, 0x73, 0x77, 0xf9, 0x62, 0x5c, 0xa3, 0xfb, 0xec, 0xdb, 0xce, 0xcc, 0xa2,
-	0xdf, 0x43, 0xa3, 0x65, 0x12, 0xa7, 0x6d, 0xe8, 0x37, 0x96, 0x1b, 0xb4, 0x63, 0xc0, 0xf7, 0x4c,
-	0xa3, 0xcc, 0xee, 0xfb, 0xd1, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x19, 0x6c, 0x61, 0x77, 0xe9,
-	0x07, 0x00, 0x00,
+	// 2816 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0xcb, 0x73, 0x1b, 0x49,
+	0x19, 0xcf, 0xe8, 0xad, 0xcf, 0xb2, 0x35, 0xee, 0x75, 0xb2, 0x8a, 0xec, 0x38, 0xce, 0x6c, 0x96,
+	0xda, 0x35, 0xc1, 0xd9, 0x75, 0x02, 0xfb, 0x84, 0x2d, 0x4b, 0x9a, 0xac, 0x55, 0xf1, 0x23, 0x69,
+	0x29, 0xcb, 0xee, 0x52, 0x94, 0x6a, 0x34, 0x6a, 0xcb, 0x83, 0xa5, 0x19, 0x65, 0x66, 0x94, 0x58,
+	0xb9, 0x70, 0xe1, 0xc4, 0x81, 0x03, 0x70, 0xe3, 0xc0, 0x91, 0x0b, 0xc5, 0x05, 0xfe, 0x00, 0xee,
+	0x1c, 0xb8, 0x70, 0xe1, 0xc6, 0x3f, 0x01, 0x07, 0x4e, 0x54, 0xbf, 0xe6, 0xa5, 0xb1, 0xec, 0x6c,
+	0x6d, 0x15, 0xb7, 0xe9, 0xaf, 0x7f, 0x5f, 0x77, 0x7f, 0x5f, 0x7f, 0xcf, 0x1e, 0xa8, 0x78, 0xcf,
+	0x47, 0x96, 0x4f, 0x76, 0x26, 0xae, 0xe3, 0x3b, 0x68, 0x59, 0x8c, 0xdc, 0x89, 0xb9, 0xf3, 0xe2,
+	0xbd, 0xfa, 0xe6, 0xd0, 0x71, 0x86, 0x23, 0x72, 0x9f, 0x4d, 0xf6, 0xa7, 0x27, 0xf7, 0x07, 0x53,
+	0xd7, 0xf0, 0x2d, 0xc7, 0xe6, 0xf0, 0xfa, 0xed, 0xe4, 0xbc, 0x6f, 0x8d, 0x89, 0xe7, 0x1b, 0xe3,
+	0x09, 0x07, 0x68, 0x0f, 0x20, 0xd7, 0x9d, 0x4d, 0x08, 0xfa, 0x2e, 0xe4, 0x4c, 0x67, 0x40, 0x6a,
+	0xca, 0x96, 0xf2, 0xce, 0xca, 0xee, 0x9b, 0x3b, 0xb1, 0x6d, 0x76, 0x28, 0xa4, 0xe9, 0x0c, 0x08,
+	0x66, 0x20, 0xed, 0x57, 0x39, 0xc8, 0x7f, 0x61, 0x8c, 0xa6, 0x04, 0x35, 0x61, 0xd9, 0xb2, 0x7d,
+	0x32, 0x24, 0x6e, 0xef, 0x05, 0x25, 0x30, 0xfe, 0xa5, 0xdd, 0x8d, 0x04, 0x7f, 0xdb, 0xf6, 0x89,
+	0x3b, 0x24, 0x2e, 0x63, 0xda, 0xbf, 0x86, 0x2b, 0x82, 0x89, 0x2f, 0xf2, 0x11, 0x80, 0x4f, 0xce,
+	0x7d, 0xb1, 0x42, 0x86, 0xad, 0x50, 0x4b, 0x9e, 0x80, 0x9c, 0xfb, 0x92, 0xbb, 0xec, 0xcb, 0x01,
+	0x65, 0xed, 0x8f, 0x9c, 0xbe, 0x60, 0xcd, 0xa6, 0xb2, 0x36, 0x46, 0x4e, 0x3f, 0x60, 0xed, 0xcb,
+	0x01, 0x65, 0x75, 0x89, 0x31, 0x12, 0xac, 0xb9, 0x54, 0x56, 0x4c, 0x8c, 0x51, 0xc0, 0xea, 0xca,
+	0x01, 0x6a, 0xc0, 0xb2, 0x3d, 0x1d, 0x13, 0xd7, 0x32, 0x05, 0x77, 0x9e, 0x71, 0xaf, 0x27, 0xb8,
+	0x8f, 0x38, 0x26, 0x10, 0xda, 0x8e, 0x8c, 0xd9, 0xc9, 0x1d, 0x47, 0x6e, 0x5f, 0x48, 0x3f, 0xb9,
+	0xe3, 0x84, 0xdb, 0xf7, 0xe5, 0x80, 0xe9, 0xcb, 0x1a, 0x13, 0xc1, 0x5a, 0x4c, 0xd7, 0x97, 0x35,
+	0x26, 0xa1, 0xbe, 0xe4, 0x80, 0xb2, 0xda, 0xd3, 0x91, 0xdc, 0xb5, 0x94, 0xca, 0x7a, 0x34, 0x1d,
+	0x85, 0xbb, 0xda, 0x72, 0x80, 0x10, 0xe4, 0x6c, 0x63, 0x4c, 0x6a, 0xe5, 0x2d, 0xe5, 0x9d, 0x32,
+	0x66, 0xdf, 0x8d, 0x02, 0xe4, 0xce, 0x2c, 0x7b, 0xa0, 0x7d, 0x02, 0xcb, 0xb1, 0x2b, 0x46, 0x6b,
+	0x90, 0x0f, 0xed, 0x21, 0x8b, 0xf3, 0x2f, 0x22, 0x54, 0x6b, 0xc0, 0xee, 0xb8, 0x84, 0xf9, 0x40,
+	0xfb, 0x00, 0xca, 0xc1, 0xed, 0xc6, 0x19, 0xcb, 0x97, 0x32, 0x06, 0x77, 0x1b, 0x67, 0xac, 0x5c,
+	0xca, 0x18, 0xdc, 0x6c, 0x9c, 0x51, 0x59, 0xcc, 0xf8, 0x31, 0x54, 0xa2, 0x97, 0xfa, 0x5a, 0xbc,
+	0xf4, 0xb4, 0xc1, 0x15, 0xc6, 0x18, 0x4b, 0x8b, 0x19, 0x3b, 0x50, 0x0e, 0x6e, 0x13, 0xbd, 0x17,
+	0x65, 0x5c, 0xda, 0xad, 0xef, 0x70, 0x07, 0xdf, 0x91, 0x0e, 0xbe, 0xd3, 0x95, 0x0e, 0x7e, 0xe9,
+	0x69, 0x82, 0x7b, 0x7e, 0xad, 0xd3, 0x7c, 0x04, 0xe5, 0x03, 0xcb, 0x13, 0xb7, 0x75, 0x0f, 0x0a,
+	0x0c, 0xeb, 0xd5, 0x94, 0xad, 0xec, 0x3b, 0x4b, 0xbb, 0x6b, 0x09, 0x53, 0x62, 0x28, 0x2c, 0x30,
+	0xda, 0x3f, 0x14, 0x58, 0xd2, 0xcf, 0x89, 0x89, 0xc9, 0xf3, 0x29, 0xf1, 0x7c, 0xa4, 0x42, 0xd6,
+	0x7b, 0x3e, 0x12, 0x37, 0x4d, 0x3f, 0xd1, 0x43, 0x80, 0x89, 0xe1, 0x1a, 0x63, 0xe2, 0x13, 0xd7,
+	0xab, 0x65, 0x16, 0xac, 0x19, 0xc1, 0xa1, 0xb7, 0x61, 0xc5, 0x77, 0x0d, 0xdb, 0x33, 0x4c, 0x1a,
+	0xf9, 0x7a, 0xd6, 0x80, 0x05, 0x82, 0x32, 0x5e, 0x8e, 0x50, 0xdb, 0x03, 0x74, 0x07, 0x2a, 0x9e,
+	0x6f, 0xf8, 0x64, 0x4c, 0x6c, 0x9f, 0x82, 0x72, 0x0c, 0xb4, 0x14, 0xd0, 0xda, 0x03, 0xf4, 0x00,
+	0x8a, 0xd4, 0x57, 0x9c, 0xa9, 0x2f, 0x5c, 0xfa, 0xe6, 0x9c, 0x7e, 0x5b, 0x22, 0xc0, 0x62, 0x89,
+	0xd4, 0xbe, 0x82, 0x0a, 0x97, 0xca, 0x9b, 0x38, 0xb6, 0x47, 0xd0, 0x5d, 0x58, 0x19, 0x19, 0x9e,
+	0xdf, 0xb3, 0x6c, 0x8f, 0xb8, 0x6c, 0x27, 0xee, 0x04, 0x15, 0x4a, 0x6d, 0x33, 0x62, 0x7b, 0x80,
+	0xde, 0x82, 0x65, 0xd7, 0x79, 0xe9, 0xf5, 0x8c, 0x93, 0x13, 0x62, 0xfa, 0x84, 0x6b, 0x39, 0x8b,
+	0x2b, 0x94, 0xb8, 0x27, 0x68, 0xda, 0x7f, 0x32, 0x50, 0x79, 0x3a, 0x25, 0xee, 0xec, 0xff, 0xa4,
+	0xb2, 0x75, 0x28, 0x4f, 0x8c, 0x21, 0xe9, 0x79, 0xd6, 0x2b, 0x1e, 0x22, 0xf3, 0xb8, 0x44, 0x09,
+	0x1d, 0xeb, 0x15, 0x41, 0xb7, 0xe8, 0xce, 0x43, 0xd2, 0xf3, 0x9d, 0x33, 0x62, 0x33, 0x7d, 0x95,
+	0x31, 0x83, 0x77, 0x29, 0x61, 0x4e, 0xdd, 0x85, 0x85, 0xea, 0x2e, 0x5e, 0x55, 0xdd, 0xe8, 0x26,
+	0x94, 0xc6, 0xc6, 0x79, 0x8f, 0xea, 0x89, 0x05, 0xb0, 0x2c, 0x2e, 0x8e, 0x8d, 0x73, 0xec, 0xbc,
+	0xf4, 0xd0, 0x3d, 0x40, 0x6c, 0x4a, 0xdc, 0x44, 0xaf, 0x3f, 0xf3, 0x89, 0xc7, 0x02, 0x56, 0x16,
+	0xab, 0x14, 0x24, 0x26, 0x1a, 0x94, 0x8e, 0xea, 0x50, 0xf2, 0xdd, 0xa9, 0x6d, 0x1a, 0x3e, 0xa9,
+	0x01, 0x33, 0xf1, 0x60, 0xac, 0xfd, 0x45, 0x81, 0x65, 0xa1, 0x78, 0x71, 0xab, 0xf7, 0xa1, 0x68,
+	0x3a, 0xa3, 0xe9, 0xd8, 0x96, 0xb6, 0x7e, 0x3d, 0xa1, 0xe4, 0x26, 0x9b, 0xc5, 0x12, 0x85, 0xee,
+	0x41, 0x8e, 0x9d, 0x91, 0x5f, 0x49, 0x32, 0xc8, 0x06, 0x3e, 0x84, 0x19, 0x0a, 0x7d, 0x07, 0xaa,
+	0x36, 0xcd, 0x81, 0x11, 0x8d, 0x8a, 0x1b, 0xa1, 0xe4, 0x27, 0x81, 0x56, 0x37, 0xa0, 0x2c, 0x0f,
+	0xc9, 0x2d, 0xb8, 0x84, 0x43, 0x82, 0x66, 0x42, 0x81, 0x1f, 0x83, 0xe6, 0x73, 0x7f, 0x36, 0xb9,
+	0x3c, 0x9f, 0x53, 0x50, 0x10, 0xda, 0x33, 0x61, 0x68, 0x47, 0x35, 0x28, 0x0e, 0x66, 0xb6, 0x31,
+	0xb6, 0x4c, 0x76, 0x90, 0x12, 0x96, 0x43, 0xad, 0x07, 0x95, 0x06, 0x19, 0x5a, 0xb6, 0xb4, 0xc9,
+	0x5d, 0xc8, 0x8d, 0xc3, 0xd2, 0x61, 0x33, 0xb9, 0x55, 0x68, 0x50, 0x87, 0x6c, 0x47, 0x8a, 0xa5,
+	0x86, 0xe5, 0x12, 0x63, 0xd0, 0x73, 0xec, 0xd1, 0x4c, 0xc4, 0x97, 0x12, 0x25, 0x1c, 0xdb, 0xa3,
+	0x99, 0xf6, 0x03, 0x58, 0x16, 0x1b, 0x08, 0xdd, 0xcf, 0x5b, 0xab, 0x92, 0x62, 0xad, 0x94, 0xaf,
+	0xe9, 0x8c, 0xc7, 0x96, 0x2f, 0x4f, 0x76, 0x45, 0x3e, 0x15, 0x56, 0x24, 0x1f, 0xdf, 0x50, 0xfb,
+	0x10, 0xaa, 0xd8, 0x19, 0x8d, 0xfa, 0x86, 0x79, 0xf6, 0x9a, 0x6b, 0x21, 0x50, 0x43, 0x4e, 0xb1,
+	0xda, 0xcf, 0xa0, 0xd2, 0x30, 0x7c, 0xf3, 0x54, 0x2e, 0xb5, 0x03, 0x79, 0xcf, 0x27, 0x13, 0x69,
+	0x48, 0x73, 0x59, 0x9f, 0x62, 0x3b, 0x3e, 0x99, 0x60, 0x0e, 0x43, 0xdb, 0xb0, 0x6a, 0x3a, 0xb6,
+	0x6f, 0xd9, 0x53, 0xd2, 0x73, 0xec, 0x1e, 0x71, 0x5d, 0xc7, 0x15, 0x4a, 0xab, 0xca, 0x89, 0x63,
+	0x5b, 0xa7, 0x64, 0xed, 0x15, 0x94, 0x03, 0x7e, 0xf4, 0x1e, 0xe4, 0xc8, 0x39, 0x31, 0x83, 0x5c,
+	0x11, 0xdf, 0x27, 0x12, 0x8a, 0xf7, 0xaf, 0x61, 0x86, 0x44, 0x0f, 0x20, 0xff, 0x9c, 0x9a, 0x7d,
+	0x2d, 0x93, 0x5a, 0xd1, 0x44, 0x63, 0xd1, 0xfe, 0x35, 0xcc, 0xb1, 0xb4, 0x0a, 0xa0, 0x07, 0xd5,
+	0x4c, 0x58, 0x16, 0x72, 0x8a, 0x7b, 0x7b, 0x08, 0x45, 0x97, 0x78, 0xd3, 0x91, 0x2f, 0x45, 0xad,
+	0xa7, 0x89, 0x8a, 0x19, 0x04, 0x4b, 0x28, 0x35, 0x71, 0x93, 0x5d, 0x87, 0x8c, 0x8a, 0x25, 0x1c,
+	0x12, 0xb4, 0x3f, 0x2b, 0xb0, 0x14, 0x61, 0x43, 0xef, 0xc7, 0x64, 0x5c, 0x4f, 0x95, 0x91, 0x1f,
+	0x27, 0x10, 0xf2, 0x61, 0x5c, 0xc8, 0x8d, 0x74, 0x21, 0x03, 0x26, 0x0e, 0x46, 0xef, 0x43, 0x9e,
+	0x6b, 0x3e, 0x2b, 0x42, 0x55, 0x8a, 0x28, 0xec, 0x0e, 0x28, 0x0b, 0x43, 0x36, 0x4a, 0x50, 0xe0,
+	0x42, 0x69, 0x7f, 0x54, 0x00, 0x42, 0x04, 0x75, 0xb8, 0xa0, 0xda, 0x2e, 0xf3, 0xa2, 0x9a, 0x3a,
+	0xdc, 0x98, 0x78, 0x9e, 0x31, 0x94, 0x7e, 0x28, 0x87, 0xe8, 0x03, 0xc8, 0x8d, 0x89, 0x6f, 0xd4,
+	0xb2, 0x4c, 0x87, 0x6f, 0x5d, 0xb8, 0xf1, 0xce, 0x21, 0xf1, 0x0d, 0xdd, 0xf6, 0xdd, 0x19, 0x66,
+	0x0c, 0xf5, 0x0f, 0xa0, 0x1c, 0x90, 0x68, 0xea, 0x38, 0x23, 0x33, 0x99, 0x3a, 0xce, 0xc8, 0x2c,
+	0x4c, 0xfb, 0x99, 0x48, 0xad, 0xf5, 0x71, 0xe6, 0x43, 0x45, 0xfb, 0xa7, 0x02, 0xab, 0x54, 0x75,
+	0x1d, 0xd3, 0xb5, 0x26, 0xfe, 0xb7, 0x9d, 0x7c, 0xb6, 0x60, 0x29, 0xe2, 0x34, 0x22, 0xbc, 0x44,
+	0x49, 0x29, 0xce, 0x96, 0x4b, 0x4b, 0x4f, 0xdf, 0x28, 0x5d, 0x3f, 0x06, 0x14, 0x15, 0x4d, 0x98,
+	0xea, 0xf7, 0x93, 0xa6, 0xba, 0xc8, 0x92, 0x02, 0x5b, 0xd5, 0x4e, 0x00, 0x31, 0x73, 0xe9, 0xf8,
+	0x2e, 0x31, 0xc6, 0x52, 0x51, 0xef, 0x4b, 0x03, 0x53, 0x2e, 0xf5, 0x22, 0x69, 0x5d, 0xb7, 0x00,
+	0xfa, 0xf4, 0x22, 0x79, 0xaa, 0xcd, 0xb0, 0x54, 0x5b, 0x66, 0x14, 0x9a, 0x6b, 0xb5, 0xff, 0x2a,
+	0xa0, 0x46, 0x36, 0x7a, 0x44, 0x75, 0x89, 0x7e, 0x18, 0x4d, 0x49, 0x74, 0xa3, 0x3b, 0x69, 0x1b,
+	0x71, 0x0e, 0x9e, 0x16, 0xbc, 0xfd, 0x6b, 0x61, 0x82, 0x7a, 0x18, 0x24, 0x28, 0xca, 0xbb, 0x79,
+	0x31, 0x2f, 0xcd, 0xad, 0xd4, 0x79, 0x28, 0x1a, 0xed, 0xc6, 0xdd, 0x20, 0xe9, 0xd1, 0x9c, 0x23,
+	0xee, 0x07, 0x74, 0xa7, 0x81, 0x63, 0xcb, 0x26, 0x6b, 0xc1, 0x4e, 0x2d, 0xc7, 0x66, 0x6e, 0x4a,
+	0xd1, 0x8d, 0x22, 0xe4, 0x4f, 0xa8, 0x9c, 0x9a, 0x0e, 0x68, 0x5e, 0x92, 0xd7, 0x4e, 0xc8, 0xda,
+	0x67, 0x50, 0x4d, 0x08, 0x15, 0xe4, 0x68, 0xe5, 0x2a, 0x39, 0x5a, 0x5b, 0x85, 0x6a, 0xe2, 0xac,
+	0xda, 0x9f, 0x14, 0x58, 0x8a, 0x88, 0xfc, 0x9a, 0x8e, 0xfd, 0x61, 0xcc, 0xb1, 0xef, 0x5e, 0xac,
+	0xca, 0x6f, 0xcf, 0xb3, 0x1f, 0x00, 0x6a, 0x8e, 0x1c, 0x8f, 0x34, 0xa7, 0xae, 0xe7, 0xb8, 0xd2,
+	0x60, 0xe3, 0xa5, 0x9c, 0x92, 0x28, 0xe5, 0xb4, 0xeb, 0xf0, 0x46, 0x8c, 0x49, 0xe4, 0xb5, 0x9f,
+	0x40, 0xbe, 0x6b, 0xf4, 0x47, 0x61, 0xfd, 0xa0, 0x44, 0xea, 0x87, 0x7b, 0xa2, 0x00, 0xc9, 0xb0,
+	0xaa, 0x60, 0xae, 0x3d, 0xa5, 0x7c, 0xb4, 0x0a, 0x11, 0x15, 0x88, 0x08, 0x2d, 0xd9, 0x20, 0xb4,
+	0x68, 0x3f, 0x82, 0x55, 0xaa, 0x7f, 0x06, 0xf4, 0xe4, 0x39, 0xdf, 0x05, 0xd5, 0xb2, 0xcd, 0xd1,
+	0x74, 0x40, 0x7a, 0x16, 0xed, 0x37, 0x6d, 0x63, 0x24, 0x7a, 0x96, 0xaa, 0xa0, 0xb7, 0x05, 0x59,
+	0x6b, 0x00, 0x8a, 0xf2, 0x0b, 0x37, 0xbf, 0x07, 0x05, 0x9f, 0x51, 0x2e, 0x68, 0x58, 0x18, 0x1c,
+	0x0b, 0x8c, 0xb6, 0x0d, 0x6b, 0x2d, 0xe2, 0x99, 0xae, 0xd5, 0x27, 0x7c, 0x42, 0x1c, 0x23, 0x45,
+	0x5e, 0xed, 0x77, 0x19, 0xb8, 0x9e, 0x00, 0x8b, 0x3d, 0xb7, 0x21, 0xcf, 0xd6, 0x13, 0x4e, 0x9a,
+	0xbe, 0x25, 0x87, 0xd0, 0x8c, 0x29, 0x8d, 0x3a, 0x93, 0x9a, 0x31, 0x19, 0x3a, 0x59, 0x6a, 0xee,
+	0x40, 0xd1, 0xb2, 0x07, 0xe4, 0x9c, 0x78, 0xc2, 0x94, 0xd6, 0xe6, 0xde, 0x5f, 0x06, 0xe4, 0x1c,
+	0x4b, 0x10, 0xfa, 0x14, 0x2a, 0x27, 0x8e, 0x4b, 0xac, 0xa1, 0xdd, 0x3b, 0x23, 0x33, 0xaf, 0x96,
+	0xdb, 0xca, 0xa6, 0x64, 0xb4, 0x47, 0x1c, 0xf2, 0x98, 0xcc, 0xf0, 0xd2, 0x49, 0xf0, 0x4d, 0x23,
+	0x40, 0xc9, 0x77, 0xad, 0xe1, 0x90, 0x86, 0xfc, 0x3c, 0xe3, 0xbc, 0x31, 0x57, 0xf3, 0xb1, 0x69,
+	0x1c, 0xe0, 0xb4, 0x7f, 0x2b, 0xb0, 0x14, 0x39, 0x7a, 0xaa, 0xc5, 0xbc, 0x05, 0xcb, 0x03, 0x62,
+	0x8e, 0x0c, 0x97, 0x0c, 0x7a, 0x81, 0xe9, 0x94, 0x71, 0x45, 0x12, 0xe5, 0x3b, 0x15, 0x9b, 0xcb,
+	0x5e, 0xa5, 0xae, 0xbd, 0x09, 0x25, 0xdb, 0xf1, 0x7b, 0xf4, 0x0d, 0x43, 0xd4, 0xca, 0x45, 0xdb,
+	0xf1, 0x69, 0xdf, 0xcb, 0x37, 0x3b, 0x31, 0xa6, 0x23, 0x3f, 0xf2, 0x84, 0xc3, 0x36, 0x63, 0x44,
+	0xde, 0xde, 0xde, 0x86, 0xa5, 0x53, 0xc3, 0xeb, 0x09, 0x1a, 0xeb, 0x60, 0x4a, 0x18, 0x4e, 0x0d,
+	0xaf, 0xc5, 0x29, 0x14, 0x30, 0x71, 0xad, 0xb1, 0xe1, 0xce, 0xa8, 0x22, 0x59, 0x13, 0x93, 0xc7,
+	0x20, 0x48, 0x8f, 0xc9, 0x4c, 0xfb, 0x83, 0x02, 0x79, 0xa6, 0xfc, 0x54, 0x89, 0xd7, 0xa4, 0x65,
+	0x08, 0x37, 0x65, 0x03, 0x74, 0x03, 0x0a, 0x53, 0xdb, 0x7a, 0x2e, 0xde, 0xb3, 0x4a, 0x58, 0x8c,
+	0x28, 0xdd, 0x71, 0xad, 0xa1, 0x65, 0x8b, 0x64, 0x28, 0x46, 0x34, 0xbe, 0x4c, 0x0c, 0xd7, 0xb7,
+	0x8c, 0x11, 0x13, 0xa2, 0x84, 0xe5, 0x90, 0xce, 0x48, 0x6b, 0x2a, 0x6c, 0x65, 0x69, 0xe4, 0x11,
+	0x43, 0xe9, 0x6f, 0xc5, 0xd0, 0xdf, 0xfe, 0xa5, 0x00, 0x84, 0x37, 0x8e, 0x56, 0x20, 0x23, 0x4a,
+	0xdc, 0x3c, 0xce, 0x58, 0x03, 0x54, 0x8b, 0x1b, 0x66, 0x64, 0xa9, 0x77, 0x41, 0x75, 0xc9, 0x09,
+	0x71, 0x89, 0x6d, 0xd2, 0x8b, 0x63, 0xf2, 0x70, 0x3f, 0xae, 0x86, 0x74, 0x1e, 0x27, 0xbe, 0x07,
+	0x28, 0x02, 0x95, 0xeb, 0xe5, 0xd8, 0x7a, 0xab, 0xe1, 0x8c, 0x8c, 0xf0, 0xeb, 0x50, 0x76, 0xec,
+	0xde, 0x74, 0x32, 0xa0, 0x1d, 0x1a, 0xbf, 0x9f, 0x92, 0x63, 0x3f, 0x63, 0x63, 0x31, 0x39, 0x20,
+	0x23, 0xe2, 0x13, 0xd1, 0x5b, 0x96, 0x1c, 0xbb, 0xc5, 0xc6, 0x54, 0xb1, 0x63, 0x9a, 0x3b, 0x85,
+	0x80, 0x7c, 0xa0, 0xe9, 0x50, 0x14, 0x96, 0xf9, 0x1a, 0xb7, 0x31, 0x1f, 0x99, 0xb6, 0x79, 0x64,
+	0x69, 0x73, 0x67, 0x92, 0x31, 0x61, 0x2d, 0xea, 0xe5, 0x92, 0x5b, 0xd3, 0xe1, 0x8d, 0x18, 0x56,
+	0x84, 0x84, 0x88, 0xc3, 0x2a, 0x57, 0x70, 0x58, 0xad, 0x0d, 0x2b, 0x4f, 0x5c, 0x32, 0x31, 0x5c,
+	0x72, 0x71, 0x2d, 0x36, 0x5f, 0x33, 0x65, 0xd2, 0x1a, 0x94, 0xa7, 0x50, 0x0d, 0x96, 0x12, 0xa7,
+	0x49, 0x76, 0xea, 0xca, 0x7c, 0xa7, 0xbe, 0x0e, 0x65, 0x7b, 0x3a, 0xee, 0x59, 0xf6, 0x64, 0xea,
+	0x8b, 0xea, 0xa4, 0x64, 0x4f, 0xc7, 0x6d, 0x3a, 0xd6, 0x3e, 0x86, 0xeb, 0x2c, 0x3d, 0x74, 0x24,
+	0x83, 0x3c, 0xe4, 0xe5, 0x0b, 0x6b, 0x35, 0xb8, 0x91, 0xe4, 0x15, 0xd9, 0xa5, 0x4a, 0xbb, 0x09,
+	0xf3, 0x6c, 0x3a, 0x11, 0xab, 0x69, 0xf7, 0x60, 0x45, 0x12, 0xc4, 0xc1, 0xeb, 0x50, 0x1a, 0x18,
+	0xbe, 0xd1, 0x37, 0x3c, 0xf9, 0xec, 0x17, 0x8c, 0xb5, 0xdf, 0xb0, 0x3e, 0x81, 0xc2, 0x79, 0xb1,
+	0x74, 0x03, 0xf2, 0xe6, 0xe9, 0xd4, 0x3e, 0xe3, 0x40, 0x5a, 0x9b, 0xb0, 0x61, 0x58, 0xcf, 0x64,
+	0xae, 0x5e, 0xcf, 0xdc, 0x17, 0xf5, 0xcc, 0x45, 0x9d, 0x00, 0xdd, 0x35, 0xbd, 0x94, 0xa9, 0x00,
+	0x84, 0xd3, 0x54, 0x22, 0x4c, 0x3c, 0xdf, 0x09, 0xaf, 0x75, 0x91, 0x44, 0xab, 0x50, 0x0d, 0xd0,
+	0x42, 0x47, 0x87, 0xa0, 0x76, 0xa6, 0x7d, 0x9e, 0x74, 0xe4, 0x12, 0x37, 0x62, 0x29, 0xae, 0x2c,
+	0x93, 0x19, 0xbd, 0x0c, 0x99, 0x3b, 0x83, 0xb2, 0xaf, 0x84, 0x97, 0x04, 0x8d, 0x96, 0x43, 0xda,
+	0x5f, 0x15, 0x58, 0x09, 0xd6, 0xe3, 0x6a, 0xfb, 0x04, 0x8a, 0x9e, 0x6f, 0xb8, 0xb4, 0x15, 0xe3,
+	0xe9, 0xeb, 0x76, 0x52, 0x41, 0x12, 0xdf, 0xe1, 0x30, 0x5a, 0x61, 0x0a, 0x0e, 0x96, 0xcd, 0x4e,
+	0x0d, 0x7b, 0x48, 0xbc, 0x0b, 0x5e, 0xf5, 0x9b, 0x6c, 0xb6, 0x43, 0x68, 0x33, 0x29, 0xa1, 0xdf,
+	0xa4, 0xc2, 0x0c, 0x15, 0x8c, 0x40, 0x4d, 0x9e, 0x48, 0xfb, 0x39, 0x94, 0x83, 0x8d, 0xa8, 0x86,
+	0x3d, 0xaa, 0x29, 0xdb, 0xe4, 0x1a, 0xce, 0xe1, 0x60, 0xcc, 0x4a, 0xca, 0xe0, 0xbc, 0xa9, 0x25,
+	0x25, 0x9b, 0x0d, 0x8f, 0xfa, 0x36, 0xac, 0x78, 0xe6, 0x29, 0x19, 0x1b, 0x3d, 0x4e, 0x19, 0x88,
+	0x90, 0xbd, 0xcc, 0xa9, 0x1c, 0x3e, 0xd0, 0xfe, 0xae, 0x40, 0x81, 0x7f, 0xa7, 0x87, 0x09, 0xf4,
+	0x29, 0x94, 0x9d, 0x09, 0xe1, 0x9d, 0x8a, 0xa8, 0x98, 0x36, 0x53, 0xb7, 0x3e, 0x96, 0x28, 0x1c,
+	0x32, 0xd0, 0x35, 0x5d, 0xe7, 0xa5, 0x78, 0xc3, 0xcb, 0x62, 0x3e, 0x88, 0xd6, 0xc7, 0xb9, 0x2b,
+	0x3d, 0x58, 0x6d, 0x43, 0xd6, 0x75, 0x5e, 0xd6, 0xf2, 0xa9, 0x37, 0x15, 0xd6, 0xc2, 0x14, 0xb4,
+	0xfd, 0x37, 0x05, 0x4a, 0x32, 0xd9, 0xa2, 0x9b, 0x70, 0xbd, 0xfb, 0xd5, 0x13, 0xbd, 0xd7, 0x3c,
+	0x6e, 0xe9, 0xbd, 0x67, 0x47, 0x9d, 0x27, 0x7a, 0xb3, 0xfd, 0xa8, 0xad, 0xb7, 0xd4, 0x6b, 0xe8,
+	0x3a, 0xac, 0x86, 0x53, 0xed, 0xa3, 0xae, 0xfe, 0xb9, 0x8e, 0x55, 0x05, 0x21, 0x58, 0x09, 0xc9,
+	0x5d, 0xfd, 0xcb, 0xae, 0x9a, 0x89, 0xd3, 0x1a, 0x07, 0xc7, 0x0d, 0x35, 0x1b, 0xa7, 0x61, 0x7d,
+	0xef, 0x40, 0xcd, 0xc5, 0x97, 0x3c, 0x7a, 0x76, 0xa8, 0xe3, 0x76, 0x53, 0xcd, 0x27, 0xd8, 0x8f,
+	0x8f, 0x0f, 0xd4, 0x42, 0x62, 0x9b, 0xf6, 0xa1, 0xae, 0x16, 0xe3, 0xb4, 0xa3, 0x67, 0x07, 0x07,
+	0x6a, 0x69, 0xfb, 0xd7, 0x0a, 0x54, 0x13, 0xef, 0x54, 0x68, 0x0b, 0x36, 0xba, 0x78, 0xef, 0xa8,
+	0xb3, 0xd7, 0xec, 0xb6, 0x8f, 0x8f, 0x7a, 0x87, 0xf3, 0xb2, 0xdd, 0x82, 0x9b, 0x73, 0x88, 0x96,
+	0xfe, 0x48, 0xc7, 0x58, 0x6f, 0xa9, 0x0a, 0xda, 0x84, 0xfa, 0xdc, 0x74, 0xfb, 0xf0, 0x50, 0x6f,
+	0xb5, 0xf7, 0xba, 0xba, 0x9a, 0x49, 0x9d, 0xd7, 0xbf, 0x6c, 0x1e, 0x3c, 0xeb, 0xb4, 0xbf, 0xd0,
+	0xd5, 0xec, 0x36, 0x86, 0x72, 0x50, 0x25, 0xa3, 0x3a, 0xdc, 0xe8, 0xee, 0x35, 0x0e, 0xf4, 0x1e,
+	0x3b, 0x7b, 0xfc, 0x1c, 0x6b, 0xa0, 0x46, 0xe6, 0xd8, 0xa7, 0xaa, 0xa0, 0x37, 0xa0, 0x1a, 0xa1,
+	0x7e, 0xd1, 0xd6, 0x7f, 0xac, 0x66, 0xb6, 0x7f, 0xa9, 0x40, 0x35, 0x61, 0x48, 0x54, 0xd0, 0xe6,
+	0xfe, 0xde, 0xd1, 0xe7, 0x7a, 0xef, 0xf8, 0x89, 0x8e, 0xf7, 0xd8, 0x61, 0xe2, 0x1b, 0xac, 0xc3,
+	0x9b, 0x73, 0x88, 0xf6, 0x51, 0x47, 0xc7, 0x5d, 0x55, 0x49, 0x9d, 0x7c, 0xf6, 0xa4, 0xc5, 0x65,
+	0x4c, 0x9b, 0x6c, 0xe9, 0x07, 0x7a, 0x57, 0x57, 0xb3, 0xbb, 0xbf, 0x28, 0x40, 0xb5, 0x25, 0x82,
+	0x5b, 0x87, 0xb8, 0x2f, 0x2c, 0x93, 0xa0, 0xcf, 0x20, 0x47, 0x1b, 0x6d, 0xb4, 0xe0, 0xad, 0xaa,
+	0xbe, 0xa8, 0x33, 0x47, 0x0d, 0xc8, 0xb3, 0x1e, 0x0d, 0x2d, 0x6a, 0xba, 0xeb, 0x0b, 0x9f, 0x7c,
+	0xe8, 0x1a, 0xec, 0xfd, 0x71, 0x6e, 0x8d, 0xe8, 0xb3, 0x67, 0x7d, 0x23, 0x7d, 0x52, 0xac, 0xa1,
+	0xd3, 0x97, 0x58, 0xfa, 0x66, 0x85, 0x36, 0xe6, 0xdc, 0x2e, 0xf2, 0x44, 0x59, 0xbf, 0x75, 0xc1,
+	0xac, 0x58, 0xe6, 0x31, 0x94, 0xe4, 0x73, 0x22, 0x4a, 0x46, 0x84, 0xc4, 0x0b, 0x65, 0xfd, 0xf6,
+	0x85, 0xf3, 0x11, 0xb9, 0x68, 0x21, 0x34, 0x2f, 0x57, 0xe4, 0x75, 0xb2, 0xbe, 0x91, 0x3e, 0x29,
+	0xd6, 0xe8, 0xc2, 0x52, 0xa4, 0x15, 0x44, 0xc9, 0x17, 0x87, 0xf9, 0xde, 0xb2, 0xae, 0x2d, 0x82,
+	0x88, 0x55, 0xf7, 0xa1, 0x28, 0x8a, 0x12, 0x94, 0x54, 0x48, 0xbc, 0xee, 0xa9, 0x6f, 0x5e, 0x34,
+	0x2d, 0x56, 0xfa, 0x29, 0xac, 0xc4, 0xeb, 0x09, 0x74, 0x37, 0x6d, 0xff, 0x64, 0xa9, 0x52, 0x7f,
+	0xfb, 0x12, 0x94, 0x58, 0xfe, 0x29, 0x40, 0xf8, 0x78, 0x84, 0xb6, 0x52, 0x2c, 0x31, 0xf6, 0x64,
+	0x56, 0xbf, 0xb3, 0x00, 0xc1, 0x97, 0xdc, 0xfd, 0x6d, 0x06, 0x96, 0x3b, 0x2c, 0x5d, 0x48, 0x27,
+	0x78, 0x0a, 0x10, 0xb6, 0xae, 0x73, 0x9b, 0xcc, 0x75, 0xc5, 0xf5, 0x3b, 0x0b, 0x10, 0xe2, 0xdc,
+	0x5f, 0xc3, 0x72, 0xac, 0x39, 0x45, 0xc9, 0x57, 0xc4, 0xb4, 0x3e, 0xb7, 0x7e, 0x77, 0x31, 0x28,
+	0x34, 0x89, 0x48, 0x8d, 0x8b, 0xd2, 0x4e, 0x13, 0xaf, 0x95, 0xeb, 0xda, 0x22, 0x88, 0x50, 0xcb,
+	0xef, 0x15, 0xa8, 0xec, 0x0d, 0xc6, 0x96, 0x2d, 0xb5, 0xa2, 0x43, 0x81, 0x97, 0x4e, 0x68, 0x23,
+	0xb5, 0xe0, 0xba, 0xc8, 0xa3, 0x12, 0x35, 0xe3, 0x3e, 0x14, 0x45, 0x15, 0x35, 0x67, 0x6a, 0xf1,
+	0x5a, 0xac, 0xbe, 0x79, 0xd1, 0xb4, 0xf8, 0x89, 0x74, 0xeb, 0xeb, 0xf5, 0xa1, 0xe5, 0x9f, 0x4e,
+	0xfb, 0x3b, 0xa6, 0x33, 0xbe, 0xdf, 0x37, 0xce, 0x2c, 0xdb, 0xbb, 0xcf, 0x59, 0xdc, 0x89, 0xd9,
+	0x2f, 0xb0, 0x37, 0xc8, 0x07, 0xff, 0x1b, 0x00, 0x8b, 0xc5, 0x6c, 0x3f, 0xc0, 0x21, 0x00, 0x00,
 }
@@ -0,0 +1,126 @@
+package sqliterpc
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// QueryStreamPath is the path of the streaming query endpoint,
+// relative to the base URL of the server.
+const QueryStreamPath = "/stream/sqlite.rpc.v0.DatabaseService/Query"
+
+// SubscribePath is the path of the endpoint that streams committed changes,
+// relative to the base URL of the server.
+const SubscribePath = "/stream/sqlite.rpc.v0.DatabaseService/Subscribe"
+
+// BackupStreamPath is the path of the endpoint that streams a backup of the
+// database, relative to the base URL of the server.
+const BackupStreamPath = "/stream/sqlite.rpc.v0.AdminService/Backup"
+
+// The streaming endpoints accept a request encoded as protobuf or JSON,
+// selected by its Content-Type like any Twirp method, and respond with frames
+// in the same encoding. Protobuf frames are written by WriteMessage, and JSON
+// frames by WriteJSONMessage.
+const (
+	ContentTypeProtobuf = "application/protobuf"
+	ContentTypeJSON     = "application/json"
+)
+
+// MaxMessageSize is the largest message ReadMessage will accept.
+const MaxMessageSize = 64 << 20
+
+// WriteMessage writes m prefixed with its length as a big-endian uint32.
+func WriteMessage(w io.Writer, m proto.Message) error {
+	data, err := proto.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(data)))
+
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+
+	return err
+}
+
+// ReadMessage reads a message written by WriteMessage into m.
+// io.EOF is returned only if there are no more messages.
+func ReadMessage(r io.Reader, m proto.Message) error {
+	var header [4]byte
+
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return err
+	}
+
+	size := binary.BigEndian.Uint32(header[:])
+	if size > MaxMessageSize {
+		return fmt.Errorf("message size %d exceeds maximum %d", size, MaxMessageSize)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	return proto.Unmarshal(data, m)
+}
+
+// WriteJSONMessage writes m as a single line of JSON, with the field names
+// and defaults of the Twirp JSON encoding.
+func WriteJSONMessage(w io.Writer, m proto.Message) error {
+	marshaler := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+	data, err := marshaler.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(data, '\n'))
+
+	return err
+}
+
+// ReadJSONMessage reads a message written by WriteJSONMessage into m.
+// io.EOF is returned only if there are no more messages.
+func ReadJSONMessage(r *bufio.Reader, m proto.Message) error {
+	var line []byte
+
+	for {
+		data, err := r.ReadSlice('\n')
+		if len(line)+len(data) > MaxMessageSize+1 {
+			return fmt.Errorf("message size exceeds maximum %d", MaxMessageSize)
+		}
+
+		line = append(line, data...)
+
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+
+		if err != nil {
+			if err == io.EOF && len(line) > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+
+		break
+	}
+
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+
+	return unmarshaler.Unmarshal(line[:len(line)-1], m)
+}